@@ -2,53 +2,92 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
-	config "github.com/SyneHQ/apollo"
+	cfg "github.com/SyneHQ/apollo"
 	"github.com/SyneHQ/apollo/keys"
 	"github.com/SyneHQ/apollo/proto"
 	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
 	_secrets "github.com/SyneHQ/apollo/secrets"
 	jobsserver "github.com/SyneHQ/apollo/server"
+	"github.com/infisical/go-sdk/packages/models"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	validateSchedules := flag.Bool("validate-schedules", false, "check every stored schedule spec, report those that do not parse and exit")
+	flag.Parse()
 
 	log.Println("Starting Dramatic Jobs")
 
-	useInfisical := os.Getenv("USE_INFISICAL") == "true"
+	provider, strictSecrets, err := keys.NewSecretsProvider()
+	if err != nil {
+		log.Fatalf("Error selecting secrets provider: %v", err)
+	}
 
-	secrets, err := keys.NewInfisicalSecrets(useInfisical)
+	secrets, err := provider.Load(context.Background())
 
 	if err != nil {
-		if useInfisical {
-			os.Exit(1)
+		if strictSecrets {
+			log.Fatalf("Error loading secrets: %v", err)
 		}
-		log.Printf("Error loading infisical secrets: %v", err)
+		log.Printf("Error loading secrets: %v", err)
 	}
 
 	log.Println("Loading config")
 
-	config, err := config.Load()
+	config, err := cfg.Load()
 
 	if err != nil {
 		panic(err)
 	}
+	if err := config.Validate(); err != nil {
+		log.Fatalf("Invalid config:\n%v", err)
+	}
 
-	secrets = _secrets.FilterSecrets(secrets, config.Jobs.Secrets)
+	secrets = _secrets.FilterSecrets(secrets, config.Jobs.Secrets, config.GetSecretPrefixes()...)
 
-	// Choose runner
-	var r runner.Runner
-	switch config.JobsProvider {
-	case "cloudrun":
-		r = runner.NewBatchRunner(config.GCPProjectID, config.GCPRegion, config.Jobs.Image, secrets)
-	default:
-		r = runner.NewLocalRunner(config.Jobs.Image, secrets)
+	// Choose the primary runner, then any named runner profiles
+	r := newRunner(config, config.PrimaryRunner(), secrets)
+	profiles := make(map[string]runner.Runner, len(config.Jobs.Runners))
+	for _, rc := range config.Jobs.Runners {
+		profiles[rc.Name] = newRunner(config, config.ResolveRunner(rc), secrets)
+	}
+
+	// Open the store; the local provider schedules from it, the cloud provider
+	// records its schedules there so they can be reconciled
+	var store scheduler.Store
+	if config.Store.Driver != "" && config.Store.Path != "" {
+		// best-effort open local sqlite at ./jobs.db
+		st, err := scheduler.OpenStore(config.Store.Driver, config.Store.Path, scheduler.Options{
+			CompressResults: config.Store.CompressResults,
+			MaxOpenConns:    config.Store.MaxOpenConns,
+			MaxIdleConns:    config.Store.MaxIdleConns,
+			ConnMaxLifetime: config.Store.ConnMaxLifetime,
+			ConnMaxIdleTime: config.Store.ConnMaxIdleTime,
+			ReadPath:        config.Store.ReadPath,
+			SpecParser:      jobsserver.SpecParserFor(config),
+		})
+		if err != nil {
+			log.Printf("Error opening store: %v", err)
+		} else {
+			store = st
+		}
+	}
+	if *validateSchedules {
+		os.Exit(validateStoredSchedules(store, config))
 	}
 
 	// Start gRPC server
@@ -56,10 +95,30 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	grpcServer := grpc.NewServer()
-	js := jobsserver.NewJobsServer(r, config)
+	js := jobsserver.NewJobsServer(r, profiles, config, store)
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(config.GRPCMaxMessageBytes),
+		grpc.MaxSendMsgSize(config.GRPCMaxMessageBytes),
+		grpc.ChainUnaryInterceptor(jobsserver.RecoveryInterceptor(), js.AuthInterceptor(), js.AuditInterceptor()),
+		grpc.ChainStreamInterceptor(jobsserver.RecoveryStreamInterceptor(), js.AuthStreamInterceptor()),
+	)
+	if config.ReconcileOrphanedJobs {
+		js.ReconcileOrphans(context.Background())
+	}
 	js.Reload(context.Background())
+	js.StartReconciler(config.ScheduleReconcileInterval, config.ScheduleReconcileFix)
+	js.StartRetention(config.ExecutionRetention)
+	js.StartSubmittedPoller(config.SubmittedPollInterval)
+	if config.WatchJobsConfig {
+		go watchJobsConfig(js)
+	}
 	proto.RegisterJobsServiceServer(grpcServer, js)
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	js.StartHealth(healthServer, config.HealthCheckInterval)
+	if config.GRPCReflection {
+		reflection.Register(grpcServer)
+	}
 	go func() {
 		if err := grpcServer.Serve(lis); err != nil {
 			panic(err)
@@ -68,17 +127,207 @@ func main() {
 
 	log.Printf("Server starting on port %s", config.Port)
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	var httpServer *http.Server
+	if config.HTTPPort != "" {
+		httpServer = &http.Server{Addr: ":" + config.HTTPPort, Handler: js.HTTPHandler(triggerVerifier(config))}
+		go func() {
+			if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				panic(err)
+			}
+		}()
+		log.Printf("HTTP server starting on port %s", config.HTTPPort)
+	} else if config.ProgressURL != "" {
+		log.Printf("PROGRESS_URL is set but HTTP_PORT is not; jobs cannot report progress")
+	}
+
+	// Reload secrets on SIGHUP so rotated values reach later jobs without a
+	// restart
+	runners := []runner.Runner{r}
+	for _, p := range profiles {
+		runners = append(runners, p)
+	}
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
 	go func() {
-		<-c
-		log.Println("Shutting down server...")
-		grpcServer.GracefulStop()
-		os.Exit(0)
+		for range hup {
+			reloadSecrets(js.Config(), provider, strictSecrets, runners)
+		}
 	}()
 
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	<-c
 	log.Println("Shutting down server...")
-	grpcServer.GracefulStop()
+	healthServer.Shutdown()
+
+	// Stop taking runs and stop cron right away, alongside the gRPC drain.
+	// Runs still going when its timeout ends are stopped and recorded, which
+	// also returns the RunJob calls waiting on them.
+	runsCtx, cancelRuns := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancelRuns()
+	var shutdownErr error
+	runsDone := make(chan struct{})
+	go func() {
+		shutdownErr = js.Shutdown(runsCtx)
+		close(runsDone)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		// the calls whose runs Shutdown stops return once those are recorded
+		select {
+		case <-stopped:
+		case <-runsDone:
+			log.Printf("gRPC calls still running after %s, stopping anyway", config.ShutdownTimeout)
+			grpcServer.Stop()
+		}
+	}
+
+	if httpServer != nil {
+		if err := httpServer.Shutdown(ctx); err != nil {
+			log.Printf("Error shutting down HTTP server: %v", err)
+		}
+	}
+	// Shutdown flushes in-flight executions to the store before exiting
+	<-runsDone
+	if shutdownErr != nil {
+		log.Printf("Error during shutdown: %v", shutdownErr)
+	}
+}
+
+func newRunner(config *cfg.Config, rc cfg.RunnerConfig, secrets []models.Secret) runner.Runner {
+	var security *runner.Security
+	if rc.Security != nil {
+		security = &runner.Security{CapDrop: rc.Security.CapDrop, CapAdd: rc.Security.CapAdd, NoNewPrivileges: rc.Security.NoNewPrivileges}
+	}
+	switch rc.Provider {
+	case "cloudrun":
+		b := runner.NewBatchRunner(rc.GCPProjectID, rc.GCPRegion, config.Jobs.Image, secrets)
+		b.NetworkTags = rc.NetworkTags
+		b.Zones = rc.Zones
+		b.MaxOutstandingJobs = rc.MaxOutstandingJobs
+		b.MachineType = rc.MachineType
+		b.Security = security
+		b.StopSignal = rc.StopSignal
+		b.TriggerURL = config.SchedulerTriggerURL
+		b.ServiceAccountEmail = config.SchedulerServiceAccount
+		for _, s := range config.Jobs.Secrets {
+			if s.SecretManagerRef != "" {
+				if b.SecretRefs == nil {
+					b.SecretRefs = map[string]string{}
+				}
+				b.SecretRefs[s.Name] = s.SecretManagerRef
+			}
+		}
+		for _, p := range config.Jobs.Prices {
+			b.Prices = append(b.Prices, runner.MachinePrice{
+				MachineType: p.MachineType,
+				Region:      p.Region,
+				Hourly:      p.Hourly,
+				SpotHourly:  p.SpotHourly,
+			})
+		}
+		return b
+	default:
+		l := runner.NewLocalRunner(config.Jobs.Image, secrets)
+		l.Security = security
+		l.StopSignal = rc.StopSignal
+		l.StopTimeout = rc.StopTimeout
+		l.PullPolicy = rc.PullPolicy
+		l.Network = rc.Network
+		l.AllowedNetworks = rc.AllowedNetworks
+		l.Runtime = rc.Runtime
+		if ra := config.RegistryAuth; ra != nil {
+			l.RegistryAuth = &runner.RegistryAuth{Server: ra.Server, Username: ra.Username, Password: ra.Password}
+		}
+		return l
+	}
+}
+
+// jobsConfigDebounce is how long the jobs config must be left alone after a
+// change before it is reloaded, so a file is not read while being written.
+const jobsConfigDebounce = 500 * time.Millisecond
+
+// watchJobsConfig reloads the jobs config into js whenever its file changes.
+// A config that can't be read or fails validation is logged and the current
+// one kept.
+func watchJobsConfig(js *jobsserver.JobsServer) {
+	err := cfg.WatchJobsConfig(context.Background(), jobsConfigDebounce, func(jobs *cfg.JobsConfig, err error) {
+		if err == nil {
+			err = js.ReloadConfig(context.Background(), jobs)
+		}
+		if err != nil {
+			log.Printf("Jobs config not reloaded: %v", err)
+		}
+	})
+	if err != nil {
+		log.Printf("Not watching the jobs config: %v", err)
+	}
+}
+
+// reloadSecrets loads the secrets again, filtered by the current jobs config,
+// and hands them to every runner that can swap them. Runs already started
+// keep their secrets; when the provider is strict and cannot be read, the
+// runners keep the secrets they have.
+func reloadSecrets(config *cfg.Config, provider keys.SecretsProvider, strict bool, runners []runner.Runner) {
+	log.Println("Reloading secrets")
+	secrets, err := provider.Load(context.Background())
+	if err != nil {
+		if strict {
+			log.Printf("Secret reload failed, keeping the current secrets: %v", err)
+			return
+		}
+		log.Printf("Error loading secrets: %v", err)
+	}
+	secrets = _secrets.FilterSecrets(secrets, config.Jobs.Secrets, config.GetSecretPrefixes()...)
+	swapped := 0
+	for _, rn := range runners {
+		if swapper, ok := rn.(runner.SecretSwapper); ok {
+			swapper.SetSecrets(secrets)
+			swapped++
+		}
+	}
+	log.Printf("Reloaded %d secret(s) into %d runner(s)", len(secrets), swapped)
+}
+
+// triggerVerifier authenticates schedule triggers with the OIDC tokens Cloud
+// Scheduler sends as the configured service account; triggers are disabled
+// without a trigger URL.
+func triggerVerifier(config *cfg.Config) jobsserver.TokenVerifier {
+	if config.SchedulerTriggerURL == "" {
+		return nil
+	}
+	return jobsserver.GoogleOIDCVerifier(config.SchedulerTriggerURL, config.SchedulerServiceAccount)
+}
+
+// validateStoredSchedules reports stored schedules whose spec the provider's
+// scheduler would reject and returns the process exit code.
+func validateStoredSchedules(store scheduler.Store, config *cfg.Config) int {
+	if store == nil {
+		log.Println("No store configured; nothing to validate")
+		return 1
+	}
+	invalid, err := scheduler.ValidateStored(context.Background(), store, jobsserver.SpecParserFor(config))
+	if err != nil {
+		log.Printf("Error listing schedules: %v", err)
+		return 1
+	}
+	for _, e := range invalid {
+		fmt.Printf("%s: invalid schedule %q: %v\n", e.Name, e.Spec, e.Err)
+	}
+	if len(invalid) > 0 {
+		return 1
+	}
+	fmt.Println("All stored schedules are valid")
+	return 0
 }
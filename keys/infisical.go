@@ -10,57 +10,71 @@ import (
 	"github.com/infisical/go-sdk/packages/models"
 )
 
-type InfisicalSecrets struct {
-	client  infisical.InfisicalClientInterface
-	secrets []models.Secret
+// InfisicalProvider reads the secrets of one Infisical project environment,
+// logging in with a universal auth machine identity on every Load. Loaded
+// secrets are also set in the process environment where it does not already
+// set them.
+type InfisicalProvider struct {
+	SiteURL      string // default: https://app.infisical.com
+	ClientID     string
+	ClientSecret string
+	ProjectID    string
+	Environment  string
 }
 
-func (i *InfisicalSecrets) GetClient() infisical.InfisicalClientInterface {
-	return i.client
+// NewInfisicalProviderFromEnv configures Infisical from INFISICAL_API_URL,
+// INFISICAL_CLIENT_ID, INFISICAL_CLIENT_SECRET, INFISICAL_PROJECT_ID and
+// INFISICAL_ENV.
+func NewInfisicalProviderFromEnv() *InfisicalProvider {
+	return &InfisicalProvider{
+		SiteURL:      os.Getenv("INFISICAL_API_URL"),
+		ClientID:     os.Getenv("INFISICAL_CLIENT_ID"),
+		ClientSecret: os.Getenv("INFISICAL_CLIENT_SECRET"),
+		ProjectID:    os.Getenv("INFISICAL_PROJECT_ID"),
+		Environment:  os.Getenv("INFISICAL_ENV"),
+	}
 }
 
-func NewInfisicalSecrets(exitOnError bool) ([]models.Secret, error) {
-	log.Printf("🔑 Line 18 - NewInfisicalSecrets: Starting Infisical client initialization")
-
-	client := infisical.NewInfisicalClient(context.Background(), infisical.Config{
-		SiteUrl:          os.Getenv("INFISICAL_API_URL"), // Optional, default is https://app.infisical.com
-		AutoTokenRefresh: true,                           // Wether or not to let the SDK handle the access token lifecycle. Defaults to true if not specified.
-	})
-
-	infisicalSecrets := InfisicalSecrets{
-		client:  client,
-		secrets: make([]models.Secret, 0),
+// Load logs in, lists the secrets and revokes the access token again. The
+// SDK's calls take no context, so Load returns ctx's error as soon as ctx
+// ends and leaves them to finish in the background.
+func (p *InfisicalProvider) Load(ctx context.Context) ([]models.Secret, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the client's token refresh
+	type result struct {
+		secrets []models.Secret
+		err     error
 	}
+	done := make(chan result, 1)
+	go func() {
+		secrets, err := p.load(ctx)
+		done <- result{secrets, err}
+	}()
+	select {
+	case r := <-done:
+		return r.secrets, r.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to load secrets from Infisical: %w", ctx.Err())
+	}
+}
 
-	log.Printf("🔐 Line 28 - NewInfisicalSecrets: Attempting universal auth login")
-	_, err := infisicalSecrets.client.Auth().UniversalAuthLogin(os.Getenv("INFISICAL_CLIENT_ID"), os.Getenv("INFISICAL_CLIENT_SECRET"))
-
-	if err != nil {
-		log.Printf("❌ Line 32 - NewInfisicalSecrets: Auth login failed - %v", err)
-		if exitOnError {
-			os.Exit(1)
-		}
+func (p *InfisicalProvider) load(ctx context.Context) ([]models.Secret, error) {
+	client := infisical.NewInfisicalClient(ctx, infisical.Config{SiteUrl: p.SiteURL})
+	if _, err := client.Auth().UniversalAuthLogin(p.ClientID, p.ClientSecret); err != nil {
 		return nil, fmt.Errorf("failed to authenticate with Infisical: %w", err)
 	}
-
-	log.Printf("✅ Line 39 - NewInfisicalSecrets: Auth login successful, loading secrets")
-	// load the secrets
-	sec, err := infisicalSecrets.client.Secrets().List(infisical.ListSecretsOptions{
-		ProjectID:          os.Getenv("INFISICAL_PROJECT_ID"),
-		Environment:        os.Getenv("INFISICAL_ENV"),
+	defer func() {
+		if err := client.Auth().RevokeAccessToken(); err != nil {
+			log.Printf("Failed to revoke the Infisical access token: %v", err)
+		}
+	}()
+	secrets, err := client.Secrets().List(infisical.ListSecretsOptions{
+		ProjectID:          p.ProjectID,
+		Environment:        p.Environment,
 		AttachToProcessEnv: true,
 	})
-
 	if err != nil {
-		log.Printf("❌ Line 47 - NewInfisicalSecrets: Failed to load secrets - %v", err)
-		if exitOnError {
-			os.Exit(1)
-		}
 		return nil, fmt.Errorf("failed to load secrets from Infisical: %w", err)
 	}
-
-	log.Printf("🎉 Line 54 - NewInfisicalSecrets: Infisical client successfully initialized and secrets loaded")
-
-	infisicalSecrets.secrets = sec
-	return infisicalSecrets.secrets, nil
+	return secrets, nil
 }
@@ -0,0 +1,29 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/infisical/go-sdk/packages/models"
+)
+
+// SecretsProvider loads the secrets jobs can receive from a secrets backend.
+type SecretsProvider interface {
+	Load(ctx context.Context) ([]models.Secret, error)
+}
+
+// NewSecretsProvider returns the backend named by SECRETS_PROVIDER:
+// "infisical" (the default) or "vault". strict reports whether the server
+// must not start without its secrets: always with Vault, which is only used
+// when asked for, and with Infisical when USE_INFISICAL is "true".
+func NewSecretsProvider() (provider SecretsProvider, strict bool, err error) {
+	switch name := os.Getenv("SECRETS_PROVIDER"); name {
+	case "", "infisical":
+		return NewInfisicalProviderFromEnv(), os.Getenv("USE_INFISICAL") == "true", nil
+	case "vault":
+		return NewVaultSecretsFromEnv(), true, nil
+	default:
+		return nil, false, fmt.Errorf("unknown SECRETS_PROVIDER %q, want \"infisical\" or \"vault\"", name)
+	}
+}
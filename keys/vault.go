@@ -0,0 +1,212 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/infisical/go-sdk/packages/models"
+)
+
+// VaultSecrets reads secrets from HashiCorp Vault KV v2 secrets. Every key of
+// every path becomes a secret; a key in a later path replaces the same key
+// in an earlier one. A token from an AppRole login is revoked once the
+// secrets are read.
+type VaultSecrets struct {
+	Address string // e.g. https://vault.example.com:8200
+	Token   string
+	// RoleID and SecretID log in with AppRole when Token is empty
+	RoleID   string
+	SecretID string
+	// Namespace is the Vault Enterprise namespace (default: none)
+	Namespace string
+	// Mount is where the KV v2 engine is mounted (default "secret")
+	Mount string
+	// Paths are the secrets to read, relative to Mount, e.g. "apollo/prod"
+	Paths []string
+	// Client sends the requests (default: one giving up after
+	// defaultVaultTimeout)
+	Client *http.Client
+	// AttachToProcessEnv also sets loaded secrets in the process environment
+	// where it does not already set them, as the Infisical provider does
+	AttachToProcessEnv bool
+}
+
+// defaultVaultTimeout bounds each Vault request of a client without its own
+// Client.
+const defaultVaultTimeout = 30 * time.Second
+
+var defaultVaultClient = &http.Client{Timeout: defaultVaultTimeout}
+
+// NewVaultSecretsFromEnv configures Vault from VAULT_ADDR, VAULT_TOKEN or
+// VAULT_ROLE_ID and VAULT_SECRET_ID, VAULT_NAMESPACE, VAULT_KV_MOUNT and
+// VAULT_SECRET_PATHS (comma separated), attaching secrets to the process
+// environment.
+func NewVaultSecretsFromEnv() *VaultSecrets {
+	var paths []string
+	for _, p := range strings.Split(os.Getenv("VAULT_SECRET_PATHS"), ",") {
+		if p = strings.Trim(strings.TrimSpace(p), "/"); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return &VaultSecrets{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		RoleID:    os.Getenv("VAULT_ROLE_ID"),
+		SecretID:  os.Getenv("VAULT_SECRET_ID"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		Mount:     os.Getenv("VAULT_KV_MOUNT"),
+		Paths:     paths,
+
+		AttachToProcessEnv: true,
+	}
+}
+
+func (v *VaultSecrets) Load(ctx context.Context) ([]models.Secret, error) {
+	if v.Address == "" {
+		return nil, errors.New("vault: VAULT_ADDR is not set")
+	}
+	if len(v.Paths) == 0 {
+		return nil, errors.New("vault: no secret paths, set VAULT_SECRET_PATHS")
+	}
+	token, err := v.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if v.Token == "" {
+		defer v.revoke(ctx, token)
+	}
+	mount := strings.Trim(v.Mount, "/")
+	if mount == "" {
+		mount = "secret"
+	}
+	byKey := map[string]models.Secret{}
+	for _, path := range v.Paths {
+		var resp struct {
+			Data struct {
+				Data     map[string]any `json:"data"`
+				Metadata struct {
+					Version int `json:"version"`
+				} `json:"metadata"`
+			} `json:"data"`
+		}
+		if err := v.do(ctx, http.MethodGet, mount+"/data/"+path, token, nil, &resp); err != nil {
+			return nil, fmt.Errorf("vault: read %s/%s: %w", mount, path, err)
+		}
+		for key, value := range resp.Data.Data {
+			byKey[key] = models.Secret{
+				SecretKey:   key,
+				SecretValue: secretValue(value),
+				SecretPath:  path,
+				Version:     resp.Data.Metadata.Version,
+			}
+		}
+	}
+	secrets := make([]models.Secret, 0, len(byKey))
+	for _, key := range slices.Sorted(maps.Keys(byKey)) {
+		secrets = append(secrets, byKey[key])
+		if v.AttachToProcessEnv && os.Getenv(key) == "" {
+			os.Setenv(key, byKey[key].SecretValue)
+		}
+	}
+	return secrets, nil
+}
+
+// token is the configured token, or one from an AppRole login.
+func (v *VaultSecrets) token(ctx context.Context) (string, error) {
+	if v.Token != "" {
+		return v.Token, nil
+	}
+	if v.RoleID == "" || v.SecretID == "" {
+		return "", errors.New("vault: no credentials, set VAULT_TOKEN or VAULT_ROLE_ID and VAULT_SECRET_ID")
+	}
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	login := map[string]string{"role_id": v.RoleID, "secret_id": v.SecretID}
+	if err := v.do(ctx, http.MethodPost, "auth/approle/login", "", login, &resp); err != nil {
+		return "", fmt.Errorf("vault: AppRole login: %w", err)
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+// revoke revokes a token from an AppRole login, so it stops working as soon
+// as Load is done with it. Failing to is logged; the token still expires.
+func (v *VaultSecrets) revoke(ctx context.Context, token string) {
+	if err := v.do(context.WithoutCancel(ctx), http.MethodPost, "auth/token/revoke-self", token, nil, nil); err != nil {
+		log.Printf("vault: failed to revoke the AppRole token: %v", err)
+	}
+}
+
+// do calls the Vault API at path and decodes its JSON answer into out, unless
+// out is nil.
+func (v *VaultSecrets) do(ctx context.Context, method, path, token string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	u, err := url.JoinPath(v.Address, "v1", path)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+	client := v.Client
+	if client == nil {
+		client = defaultVaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		var failure struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&failure)
+		if len(failure.Errors) > 0 {
+			return fmt.Errorf("%s: %s", resp.Status, strings.Join(failure.Errors, "; "))
+		}
+		return errors.New(resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// secretValue renders a KV value as an env var value: strings as they are,
+// anything else as JSON.
+func secretValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
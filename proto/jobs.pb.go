@@ -68,10 +68,64 @@ func (JobType) EnumDescriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{0}
 }
 
+type JobState int32
+
+const (
+	JobState_JOB_STATE_PENDING   JobState = 0
+	JobState_JOB_STATE_RUNNING   JobState = 1
+	JobState_JOB_STATE_SUCCEEDED JobState = 2
+	JobState_JOB_STATE_FAILED    JobState = 3
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_STATE_PENDING",
+		1: "JOB_STATE_RUNNING",
+		2: "JOB_STATE_SUCCEEDED",
+		3: "JOB_STATE_FAILED",
+	}
+	JobState_value = map[string]int32{
+		"JOB_STATE_PENDING":   0,
+		"JOB_STATE_RUNNING":   1,
+		"JOB_STATE_SUCCEEDED": 2,
+		"JOB_STATE_FAILED":    3,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_jobs_proto_enumTypes[1].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_jobs_proto_enumTypes[1]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{1}
+}
+
 type Resources struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cpu           string                 `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
 	Memory        string                 `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	GpuCount      int32                  `protobuf:"varint,3,opt,name=gpu_count,json=gpuCount,proto3" json:"gpu_count,omitempty"` // GPUs attached to the job's Batch VM; none when 0
+	GpuType       string                 `protobuf:"bytes,4,opt,name=gpu_type,json=gpuType,proto3" json:"gpu_type,omitempty"`     // e.g. "nvidia-tesla-t4"; required with gpu_count
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -120,18 +174,50 @@ func (x *Resources) GetMemory() string {
 	return ""
 }
 
+func (x *Resources) GetGpuCount() int32 {
+	if x != nil {
+		return x.GpuCount
+	}
+	return 0
+}
+
+func (x *Resources) GetGpuType() string {
+	if x != nil {
+		return x.GpuType
+	}
+	return ""
+}
+
 type RunJobRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Optional: if not provided, will be auto-generated
-	Command       string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`          // e.g., "ack", "migrateJob", etc.
-	ArgsBase64    string                 `protobuf:"bytes,4,opt,name=args_base64,json=argsBase64,proto3" json:"args_base64,omitempty"`
-	Resources     *Resources             `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
-	Type          JobType                `protobuf:"varint,6,opt,name=type,proto3,enum=jobs.JobType" json:"type,omitempty"`
-	Schedule      string                 `protobuf:"bytes,7,opt,name=schedule,proto3" json:"schedule,omitempty"`   // cron or duration string
-	Overrides     *JobOverrides          `protobuf:"bytes,8,opt,name=overrides,proto3" json:"overrides,omitempty"` // Optional runtime overrides
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JobId          string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Optional: if not provided, will be auto-generated
+	Command        string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`          // e.g., "ack", "migrateJob", etc.
+	ArgsBase64     string                 `protobuf:"bytes,4,opt,name=args_base64,json=argsBase64,proto3" json:"args_base64,omitempty"`
+	Resources      *Resources             `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	Type           JobType                `protobuf:"varint,6,opt,name=type,proto3,enum=jobs.JobType" json:"type,omitempty"`
+	Schedule       string                 `protobuf:"bytes,7,opt,name=schedule,proto3" json:"schedule,omitempty"`                                                                        // cron or duration string
+	Overrides      *JobOverrides          `protobuf:"bytes,8,opt,name=overrides,proto3" json:"overrides,omitempty"`                                                                      // Optional runtime overrides
+	CoalesceMissed *bool                  `protobuf:"varint,9,opt,name=coalesce_missed,json=coalesceMissed,proto3,oneof" json:"coalesce_missed,omitempty"`                               // Collapse ticks missed during downtime into one run (default true)
+	MaxCatchup     int32                  `protobuf:"varint,10,opt,name=max_catchup,json=maxCatchup,proto3" json:"max_catchup,omitempty"`                                                // Max missed ticks replayed on restart when not coalescing
+	Runner         string                 `protobuf:"bytes,11,opt,name=runner,proto3" json:"runner,omitempty"`                                                                           // Optional runner profile; defaults to the job's configured runner, then the primary runner
+	Singleton      bool                   `protobuf:"varint,12,opt,name=singleton,proto3" json:"singleton,omitempty"`                                                                    // Run each tick on only one replica cluster-wide, via a store lease
+	RunAt          int64                  `protobuf:"varint,13,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`                                                               // One-time jobs only: defer the run to this unix time instead of running now
+	RunIfMissed    *bool                  `protobuf:"varint,14,opt,name=run_if_missed,json=runIfMissed,proto3,oneof" json:"run_if_missed,omitempty"`                                     // Run on restart when run_at passed while the server was down (default true)
+	Labels         map[string]string      `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Tag the execution records; override the server's default labels
+	BatchId        string                 `protobuf:"bytes,16,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`                                                          // Group the run's executions with others, e.g. those of one RunJobs call
+	Timeout        string                 `protobuf:"bytes,17,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                         // Bounds the run, e.g. "30m"; Batch defaults to 24h, local runs to none
+	MaxRetries     *int32                 `protobuf:"varint,18,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`                                          // Batch retries of a failed task (default 3; 0 disables them)
+	SkipIfRunning  bool                   `protobuf:"varint,20,opt,name=skip_if_running,json=skipIfRunning,proto3" json:"skip_if_running,omitempty"`                                     // Skip a tick while the schedule's previous run is still running
+	MachineType    string                 `protobuf:"bytes,19,opt,name=machine_type,json=machineType,proto3" json:"machine_type,omitempty"`                                              // Batch VM machine type, e.g. "n1-highmem-4"; derived from resources when empty
+	TimeZone       string                 `protobuf:"bytes,21,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                                                       // IANA time zone the schedule runs in, e.g. "Asia/Kolkata"; a CRON_TZ= prefix on schedule works too
+	Security       *Security              `protobuf:"bytes,22,opt,name=security,proto3" json:"security,omitempty"`                                                                       // Further hardens the job's container beyond the job's configured security, else the runner's; it cannot loosen them
+	Wait           bool                   `protobuf:"varint,23,opt,name=wait,proto3" json:"wait,omitempty"`                                                                              // Batch only: return once the job has finished, with its final state, instead of once it was created
+	StopSignal     string                 `protobuf:"bytes,24,opt,name=stop_signal,json=stopSignal,proto3" json:"stop_signal,omitempty"`                                                 // Signal the container receives when the run is cancelled, e.g. "SIGUSR1"; defaults to the job's configured signal, then the runner's, then SIGTERM
+	DryRun         bool                   `protobuf:"varint,25,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                                            // Return what would run in logs, with secret values redacted, instead of running, scheduling or recording anything
+	Deduplicate    bool                   `protobuf:"varint,26,opt,name=deduplicate,proto3" json:"deduplicate,omitempty"`                                                                // Fail with ALREADY_EXISTS instead of running while a run of the same name is in flight on this server
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RunJobRequest) Reset() {
@@ -220,30 +306,155 @@ func (x *RunJobRequest) GetOverrides() *JobOverrides {
 	return nil
 }
 
-type JobOverrides struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Args          []string               `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`                             // Override container args
-	Env           []*EnvVar              `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`                               // Override environment variables
-	Resources     *Resources             `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`                   // Override resource limits
-	TaskCount     int32                  `protobuf:"varint,4,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"` // Override task count for parallel execution
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *RunJobRequest) GetCoalesceMissed() bool {
+	if x != nil && x.CoalesceMissed != nil {
+		return *x.CoalesceMissed
+	}
+	return false
 }
 
-func (x *JobOverrides) Reset() {
-	*x = JobOverrides{}
+func (x *RunJobRequest) GetMaxCatchup() int32 {
+	if x != nil {
+		return x.MaxCatchup
+	}
+	return 0
+}
+
+func (x *RunJobRequest) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+func (x *RunJobRequest) GetSingleton() bool {
+	if x != nil {
+		return x.Singleton
+	}
+	return false
+}
+
+func (x *RunJobRequest) GetRunAt() int64 {
+	if x != nil {
+		return x.RunAt
+	}
+	return 0
+}
+
+func (x *RunJobRequest) GetRunIfMissed() bool {
+	if x != nil && x.RunIfMissed != nil {
+		return *x.RunIfMissed
+	}
+	return false
+}
+
+func (x *RunJobRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RunJobRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *RunJobRequest) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *RunJobRequest) GetMaxRetries() int32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RunJobRequest) GetSkipIfRunning() bool {
+	if x != nil {
+		return x.SkipIfRunning
+	}
+	return false
+}
+
+func (x *RunJobRequest) GetMachineType() string {
+	if x != nil {
+		return x.MachineType
+	}
+	return ""
+}
+
+func (x *RunJobRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *RunJobRequest) GetSecurity() *Security {
+	if x != nil {
+		return x.Security
+	}
+	return nil
+}
+
+func (x *RunJobRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+func (x *RunJobRequest) GetStopSignal() string {
+	if x != nil {
+		return x.StopSignal
+	}
+	return ""
+}
+
+func (x *RunJobRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *RunJobRequest) GetDeduplicate() bool {
+	if x != nil {
+		return x.Deduplicate
+	}
+	return false
+}
+
+type Security struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CapDrop         []string               `protobuf:"bytes,1,rep,name=cap_drop,json=capDrop,proto3" json:"cap_drop,omitempty"`                            // Linux capabilities to drop, e.g. "ALL"
+	CapAdd          []string               `protobuf:"bytes,2,rep,name=cap_add,json=capAdd,proto3" json:"cap_add,omitempty"`                               // Linux capabilities to add back, e.g. "NET_BIND_SERVICE"; only those the configured security adds back
+	NoNewPrivileges bool                   `protobuf:"varint,3,opt,name=no_new_privileges,json=noNewPrivileges,proto3" json:"no_new_privileges,omitempty"` // Stop processes from gaining privileges, e.g. through setuid binaries
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Security) Reset() {
+	*x = Security{}
 	mi := &file_jobs_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JobOverrides) String() string {
+func (x *Security) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JobOverrides) ProtoMessage() {}
+func (*Security) ProtoMessage() {}
 
-func (x *JobOverrides) ProtoReflect() protoreflect.Message {
+func (x *Security) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -255,61 +466,54 @@ func (x *JobOverrides) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JobOverrides.ProtoReflect.Descriptor instead.
-func (*JobOverrides) Descriptor() ([]byte, []int) {
+// Deprecated: Use Security.ProtoReflect.Descriptor instead.
+func (*Security) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *JobOverrides) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
-}
-
-func (x *JobOverrides) GetEnv() []*EnvVar {
+func (x *Security) GetCapDrop() []string {
 	if x != nil {
-		return x.Env
+		return x.CapDrop
 	}
 	return nil
 }
 
-func (x *JobOverrides) GetResources() *Resources {
+func (x *Security) GetCapAdd() []string {
 	if x != nil {
-		return x.Resources
+		return x.CapAdd
 	}
 	return nil
 }
 
-func (x *JobOverrides) GetTaskCount() int32 {
+func (x *Security) GetNoNewPrivileges() bool {
 	if x != nil {
-		return x.TaskCount
+		return x.NoNewPrivileges
 	}
-	return 0
+	return false
 }
 
-type EnvVar struct {
+type RunJobsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Jobs          []*RunJobRequest       `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	BatchId       string                 `protobuf:"bytes,2,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"` // Applied to every job; generated when empty
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnvVar) Reset() {
-	*x = EnvVar{}
+func (x *RunJobsRequest) Reset() {
+	*x = RunJobsRequest{}
 	mi := &file_jobs_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnvVar) String() string {
+func (x *RunJobsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnvVar) ProtoMessage() {}
+func (*RunJobsRequest) ProtoMessage() {}
 
-func (x *EnvVar) ProtoReflect() protoreflect.Message {
+func (x *RunJobsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -321,47 +525,47 @@ func (x *EnvVar) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnvVar.ProtoReflect.Descriptor instead.
-func (*EnvVar) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunJobsRequest.ProtoReflect.Descriptor instead.
+func (*RunJobsRequest) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *EnvVar) GetName() string {
+func (x *RunJobsRequest) GetJobs() []*RunJobRequest {
 	if x != nil {
-		return x.Name
+		return x.Jobs
 	}
-	return ""
+	return nil
 }
 
-func (x *EnvVar) GetValue() string {
+func (x *RunJobsRequest) GetBatchId() string {
 	if x != nil {
-		return x.Value
+		return x.BatchId
 	}
 	return ""
 }
 
-type RunJobResponse struct {
+type RunJobsResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Logs          string                 `protobuf:"bytes,2,opt,name=logs,proto3" json:"logs,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // Empty when the job was run or scheduled
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunJobResponse) Reset() {
-	*x = RunJobResponse{}
+func (x *RunJobsResult) Reset() {
+	*x = RunJobsResult{}
 	mi := &file_jobs_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunJobResponse) String() string {
+func (x *RunJobsResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunJobResponse) ProtoMessage() {}
+func (*RunJobsResult) ProtoMessage() {}
 
-func (x *RunJobResponse) ProtoReflect() protoreflect.Message {
+func (x *RunJobsResult) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -373,46 +577,47 @@ func (x *RunJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunJobResponse.ProtoReflect.Descriptor instead.
-func (*RunJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunJobsResult.ProtoReflect.Descriptor instead.
+func (*RunJobsResult) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RunJobResponse) GetId() string {
+func (x *RunJobsResult) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *RunJobResponse) GetLogs() string {
+func (x *RunJobsResult) GetError() string {
 	if x != nil {
-		return x.Logs
+		return x.Error
 	}
 	return ""
 }
 
-type DeleteJobRequest struct {
+type RunJobsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Results       []*RunJobsResult       `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"` // In request order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobRequest) Reset() {
-	*x = DeleteJobRequest{}
+func (x *RunJobsResponse) Reset() {
+	*x = RunJobsResponse{}
 	mi := &file_jobs_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobRequest) String() string {
+func (x *RunJobsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobRequest) ProtoMessage() {}
+func (*RunJobsResponse) ProtoMessage() {}
 
-func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+func (x *RunJobsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -424,38 +629,46 @@ func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
-func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RunJobsResponse.ProtoReflect.Descriptor instead.
+func (*RunJobsResponse) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DeleteJobRequest) GetName() string {
+func (x *RunJobsResponse) GetBatchId() string {
 	if x != nil {
-		return x.Name
+		return x.BatchId
 	}
 	return ""
 }
 
-type DeleteJobResponse struct {
+func (x *RunJobsResponse) GetResults() []*RunJobsResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type GetBatchStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteJobResponse) Reset() {
-	*x = DeleteJobResponse{}
+func (x *GetBatchStatusRequest) Reset() {
+	*x = GetBatchStatusRequest{}
 	mi := &file_jobs_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteJobResponse) String() string {
+func (x *GetBatchStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteJobResponse) ProtoMessage() {}
+func (*GetBatchStatusRequest) ProtoMessage() {}
 
-func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+func (x *GetBatchStatusRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -467,33 +680,43 @@ func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
-func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetBatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchStatusRequest) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{6}
 }
 
-type UpdateScheduleRequest struct {
+func (x *GetBatchStatusRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type GetBatchStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Schedule      string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Running       int32                  `protobuf:"varint,3,opt,name=running,proto3" json:"running,omitempty"`
+	Succeeded     int32                  `protobuf:"varint,4,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed        int32                  `protobuf:"varint,5,opt,name=failed,proto3" json:"failed,omitempty"` // Includes timed out and interrupted executions
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateScheduleRequest) Reset() {
-	*x = UpdateScheduleRequest{}
+func (x *GetBatchStatusResponse) Reset() {
+	*x = GetBatchStatusResponse{}
 	mi := &file_jobs_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateScheduleRequest) String() string {
+func (x *GetBatchStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateScheduleRequest) ProtoMessage() {}
+func (*GetBatchStatusResponse) ProtoMessage() {}
 
-func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
+func (x *GetBatchStatusResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -505,45 +728,72 @@ func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateScheduleRequest.ProtoReflect.Descriptor instead.
-func (*UpdateScheduleRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetBatchStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBatchStatusResponse) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *UpdateScheduleRequest) GetName() string {
+func (x *GetBatchStatusResponse) GetBatchId() string {
 	if x != nil {
-		return x.Name
+		return x.BatchId
 	}
 	return ""
 }
 
-func (x *UpdateScheduleRequest) GetSchedule() string {
+func (x *GetBatchStatusResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Schedule
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-type UpdateScheduleResponse struct {
+func (x *GetBatchStatusResponse) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *GetBatchStatusResponse) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *GetBatchStatusResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type JobOverrides struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Args          []string               `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`                             // Override container args
+	Env           []*EnvVar              `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`                               // Override environment variables
+	Resources     *Resources             `protobuf:"bytes,3,opt,name=resources,proto3" json:"resources,omitempty"`                   // Override resource limits
+	TaskCount     int32                  `protobuf:"varint,4,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"` // Override task count for parallel execution
+	Volumes       []*VolumeMount         `protobuf:"bytes,5,rep,name=volumes,proto3" json:"volumes,omitempty"`                       // Bind mount host paths into the container; on Batch the host is the job's VM
+	Network       string                 `protobuf:"bytes,6,opt,name=network,proto3" json:"network,omitempty"`                       // Docker network the container joins, e.g. "host" (local runner only)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateScheduleResponse) Reset() {
-	*x = UpdateScheduleResponse{}
+func (x *JobOverrides) Reset() {
+	*x = JobOverrides{}
 	mi := &file_jobs_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateScheduleResponse) String() string {
+func (x *JobOverrides) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateScheduleResponse) ProtoMessage() {}
+func (*JobOverrides) ProtoMessage() {}
 
-func (x *UpdateScheduleResponse) ProtoReflect() protoreflect.Message {
+func (x *JobOverrides) ProtoReflect() protoreflect.Message {
 	mi := &file_jobs_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -555,32 +805,2680 @@ func (x *UpdateScheduleResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateScheduleResponse.ProtoReflect.Descriptor instead.
-func (*UpdateScheduleResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JobOverrides.ProtoReflect.Descriptor instead.
+func (*JobOverrides) Descriptor() ([]byte, []int) {
 	return file_jobs_proto_rawDescGZIP(), []int{8}
 }
 
-type ListSchedulesRequest struct {
+func (x *JobOverrides) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *JobOverrides) GetEnv() []*EnvVar {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *JobOverrides) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *JobOverrides) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+func (x *JobOverrides) GetVolumes() []*VolumeMount {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *JobOverrides) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+type VolumeMount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HostPath      string                 `protobuf:"bytes,1,opt,name=host_path,json=hostPath,proto3" json:"host_path,omitempty"`                // Absolute path on the host
+	ContainerPath string                 `protobuf:"bytes,2,opt,name=container_path,json=containerPath,proto3" json:"container_path,omitempty"` // Absolute path in the container
+	ReadOnly      bool                   `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeMount) Reset() {
+	*x = VolumeMount{}
+	mi := &file_jobs_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeMount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeMount) ProtoMessage() {}
+
+func (x *VolumeMount) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeMount.ProtoReflect.Descriptor instead.
+func (*VolumeMount) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VolumeMount) GetHostPath() string {
+	if x != nil {
+		return x.HostPath
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetContainerPath() string {
+	if x != nil {
+		return x.ContainerPath
+	}
+	return ""
+}
+
+func (x *VolumeMount) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+type EnvVar struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnvVar) Reset() {
+	*x = EnvVar{}
+	mi := &file_jobs_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvVar) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvVar) ProtoMessage() {}
+
+func (x *EnvVar) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvVar.ProtoReflect.Descriptor instead.
+func (*EnvVar) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EnvVar) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnvVar) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type RunJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Logs          string                 `protobuf:"bytes,2,opt,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunJobResponse) Reset() {
+	*x = RunJobResponse{}
+	mi := &file_jobs_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJobResponse) ProtoMessage() {}
+
+func (x *RunJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJobResponse.ProtoReflect.Descriptor instead.
+func (*RunJobResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RunJobResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RunJobResponse) GetLogs() string {
+	if x != nil {
+		return x.Logs
+	}
+	return ""
+}
+
+type DeleteJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                // Delete the job's schedule and stop all of its runs
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // Stop only the run with this job ID, keeping the schedule
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobRequest) Reset() {
+	*x = DeleteJobRequest{}
+	mi := &file_jobs_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRequest) ProtoMessage() {}
+
+func (x *DeleteJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRequest.ProtoReflect.Descriptor instead.
+func (*DeleteJobRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type DeleteJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteJobResponse) Reset() {
+	*x = DeleteJobResponse{}
+	mi := &file_jobs_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobResponse) ProtoMessage() {}
+
+func (x *DeleteJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobResponse.ProtoReflect.Descriptor instead.
+func (*DeleteJobResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{13}
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Job ID of the execution to stop; its schedule is kept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_jobs_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobResponse) Reset() {
+	*x = CancelJobResponse{}
+	mi := &file_jobs_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobResponse) ProtoMessage() {}
+
+func (x *CancelJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelJobResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{15}
+}
+
+type UpdateScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Schedule      string                 `protobuf:"bytes,2,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduleRequest) Reset() {
+	*x = UpdateScheduleRequest{}
+	mi := &file_jobs_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateScheduleRequest) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+type UpdateScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduleResponse) Reset() {
+	*x = UpdateScheduleResponse{}
+	mi := &file_jobs_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleResponse) ProtoMessage() {}
+
+func (x *UpdateScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{17}
+}
+
+// Paused schedules keep their definition but do not fire until resumed
+type PauseScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleRequest) Reset() {
+	*x = PauseScheduleRequest{}
+	mi := &file_jobs_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleRequest) ProtoMessage() {}
+
+func (x *PauseScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleRequest.ProtoReflect.Descriptor instead.
+func (*PauseScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PauseScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PauseScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseScheduleResponse) Reset() {
+	*x = PauseScheduleResponse{}
+	mi := &file_jobs_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseScheduleResponse) ProtoMessage() {}
+
+func (x *PauseScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseScheduleResponse.ProtoReflect.Descriptor instead.
+func (*PauseScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{19}
+}
+
+type ResumeScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeScheduleRequest) Reset() {
+	*x = ResumeScheduleRequest{}
+	mi := &file_jobs_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduleRequest) ProtoMessage() {}
+
+func (x *ResumeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*ResumeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResumeScheduleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ResumeScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeScheduleResponse) Reset() {
+	*x = ResumeScheduleResponse{}
+	mi := &file_jobs_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeScheduleResponse) ProtoMessage() {}
+
+func (x *ResumeScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeScheduleResponse.ProtoReflect.Descriptor instead.
+func (*ResumeScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{21}
+}
+
+type ListSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`   // By name; unlimited when 0
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // Skip this many schedules
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_jobs_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSchedulesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSchedulesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ScheduleItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	ArgsBase64    string                 `protobuf:"bytes,3,opt,name=args_base64,json=argsBase64,proto3" json:"args_base64,omitempty"`
+	Cron          string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
+	Resources     *Resources             `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	Runner        string                 `protobuf:"bytes,6,opt,name=runner,proto3" json:"runner,omitempty"`
+	Singleton     bool                   `protobuf:"varint,7,opt,name=singleton,proto3" json:"singleton,omitempty"`
+	RunAt         int64                  `protobuf:"varint,8,opt,name=run_at,json=runAt,proto3" json:"run_at,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	SkipIfRunning bool                   `protobuf:"varint,10,opt,name=skip_if_running,json=skipIfRunning,proto3" json:"skip_if_running,omitempty"`
+	TimeZone      string                 `protobuf:"bytes,11,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Paused        bool                   `protobuf:"varint,12,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleItem) Reset() {
+	*x = ScheduleItem{}
+	mi := &file_jobs_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleItem) ProtoMessage() {}
+
+func (x *ScheduleItem) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleItem.ProtoReflect.Descriptor instead.
+func (*ScheduleItem) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScheduleItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduleItem) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ScheduleItem) GetArgsBase64() string {
+	if x != nil {
+		return x.ArgsBase64
+	}
+	return ""
+}
+
+func (x *ScheduleItem) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *ScheduleItem) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ScheduleItem) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+func (x *ScheduleItem) GetSingleton() bool {
+	if x != nil {
+		return x.Singleton
+	}
+	return false
+}
+
+func (x *ScheduleItem) GetRunAt() int64 {
+	if x != nil {
+		return x.RunAt
+	}
+	return 0
+}
+
+func (x *ScheduleItem) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ScheduleItem) GetSkipIfRunning() bool {
+	if x != nil {
+		return x.SkipIfRunning
+	}
+	return false
+}
+
+func (x *ScheduleItem) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *ScheduleItem) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+type ListSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ScheduleItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesResponse) Reset() {
+	*x = ListSchedulesResponse{}
+	mi := &file_jobs_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesResponse) ProtoMessage() {}
+
+func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListSchedulesResponse) GetItems() []*ScheduleItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RetryPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxRetries    int32                  `protobuf:"varint,1,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	InitialDelay  string                 `protobuf:"bytes,2,opt,name=initial_delay,json=initialDelay,proto3" json:"initial_delay,omitempty"`
+	Multiplier    float64                `protobuf:"fixed64,3,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	MaxDelay      string                 `protobuf:"bytes,4,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"` // Empty when uncapped
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_jobs_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RetryPolicy) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetInitialDelay() string {
+	if x != nil {
+		return x.InitialDelay
+	}
+	return ""
+}
+
+func (x *RetryPolicy) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetMaxDelay() string {
+	if x != nil {
+		return x.MaxDelay
+	}
+	return ""
+}
+
+type GetEffectiveJobConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEffectiveJobConfigRequest) Reset() {
+	*x = GetEffectiveJobConfigRequest{}
+	mi := &file_jobs_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveJobConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveJobConfigRequest) ProtoMessage() {}
+
+func (x *GetEffectiveJobConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveJobConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetEffectiveJobConfigRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetEffectiveJobConfigRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetEffectiveJobConfigResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Name               string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Provider           string                 `protobuf:"bytes,2,opt,name=provider,proto3" json:"provider,omitempty"`
+	Image              string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	CommandPrefix      string                 `protobuf:"bytes,4,opt,name=command_prefix,json=commandPrefix,proto3" json:"command_prefix,omitempty"` // Binary the command is passed to inside the image
+	Command            string                 `protobuf:"bytes,5,opt,name=command,proto3" json:"command,omitempty"`
+	Resources          *Resources             `protobuf:"bytes,6,opt,name=resources,proto3" json:"resources,omitempty"`
+	Env                []*EnvVar              `protobuf:"bytes,7,rep,name=env,proto3" json:"env,omitempty"`           // Values are redacted
+	Timeout            string                 `protobuf:"bytes,8,opt,name=timeout,proto3" json:"timeout,omitempty"`   // Empty when scheduled runs are unbounded
+	Schedule           string                 `protobuf:"bytes,9,opt,name=schedule,proto3" json:"schedule,omitempty"` // Empty when the job is not scheduled
+	SuccessExitCodes   []int32                `protobuf:"varint,10,rep,packed,name=success_exit_codes,json=successExitCodes,proto3" json:"success_exit_codes,omitempty"`
+	SuccessOutputRegex string                 `protobuf:"bytes,11,opt,name=success_output_regex,json=successOutputRegex,proto3" json:"success_output_regex,omitempty"`
+	Runner             string                 `protobuf:"bytes,12,opt,name=runner,proto3" json:"runner,omitempty"` // Runner profile name; empty for the primary runner
+	Retry              *RetryPolicy           `protobuf:"bytes,13,opt,name=retry,proto3" json:"retry,omitempty"`   // Server-side retries of failed scheduled runs
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetEffectiveJobConfigResponse) Reset() {
+	*x = GetEffectiveJobConfigResponse{}
+	mi := &file_jobs_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEffectiveJobConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEffectiveJobConfigResponse) ProtoMessage() {}
+
+func (x *GetEffectiveJobConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEffectiveJobConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetEffectiveJobConfigResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetEffectiveJobConfigResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetCommandPrefix() string {
+	if x != nil {
+		return x.CommandPrefix
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetResources() *Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *GetEffectiveJobConfigResponse) GetEnv() []*EnvVar {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *GetEffectiveJobConfigResponse) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetSuccessExitCodes() []int32 {
+	if x != nil {
+		return x.SuccessExitCodes
+	}
+	return nil
+}
+
+func (x *GetEffectiveJobConfigResponse) GetSuccessOutputRegex() string {
+	if x != nil {
+		return x.SuccessOutputRegex
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+func (x *GetEffectiveJobConfigResponse) GetRetry() *RetryPolicy {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+type GetLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                   // Container name locally, Batch job ID on the cloud provider
+	TaskIndex     *int32                 `protobuf:"varint,2,opt,name=task_index,json=taskIndex,proto3,oneof" json:"task_index,omitempty"` // Only this task's logs; all tasks when unset
+	Runner        string                 `protobuf:"bytes,3,opt,name=runner,proto3" json:"runner,omitempty"`                               // Runner profile the job ran on; defaults to the primary runner
+	MaxLines      int32                  `protobuf:"varint,4,opt,name=max_lines,json=maxLines,proto3" json:"max_lines,omitempty"`          // Only the last max_lines lines; all when 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogsRequest) Reset() {
+	*x = GetLogsRequest{}
+	mi := &file_jobs_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsRequest) ProtoMessage() {}
+
+func (x *GetLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetLogsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetLogsRequest) GetTaskIndex() int32 {
+	if x != nil && x.TaskIndex != nil {
+		return *x.TaskIndex
+	}
+	return 0
+}
+
+func (x *GetLogsRequest) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+func (x *GetLogsRequest) GetMaxLines() int32 {
+	if x != nil {
+		return x.MaxLines
+	}
+	return 0
+}
+
+type GetLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          string                 `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogsResponse) Reset() {
+	*x = GetLogsResponse{}
+	mi := &file_jobs_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogsResponse) ProtoMessage() {}
+
+func (x *GetLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetLogsResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetLogsResponse) GetLogs() string {
+	if x != nil {
+		return x.Logs
+	}
+	return ""
+}
+
+type GetJobStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // Job ID the job was run with (its name when none was given)
+	Runner        string                 `protobuf:"bytes,2,opt,name=runner,proto3" json:"runner,omitempty"` // Runner profile the job ran on; defaults to the primary runner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobStatusRequest) Reset() {
+	*x = GetJobStatusRequest{}
+	mi := &file_jobs_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusRequest) ProtoMessage() {}
+
+func (x *GetJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetJobStatusRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetJobStatusRequest) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+type GetJobStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         JobState               `protobuf:"varint,1,opt,name=state,proto3,enum=jobs.JobState" json:"state,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`    // Unix seconds; 0 until the job started
+	FinishedAt    int64                  `protobuf:"varint,4,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // Unix seconds; 0 until the job finished
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobStatusResponse) Reset() {
+	*x = GetJobStatusResponse{}
+	mi := &file_jobs_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusResponse) ProtoMessage() {}
+
+func (x *GetJobStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetJobStatusResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetJobStatusResponse) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_PENDING
+}
+
+func (x *GetJobStatusResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *GetJobStatusResponse) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+type ListJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runner        string                 `protobuf:"bytes,1,opt,name=runner,proto3" json:"runner,omitempty"` // Runner profile to list; defaults to the primary runner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_jobs_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListJobsRequest) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+type JobInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Job ID, as GetJobStatus accepts it
+	State         JobState               `protobuf:"varint,2,opt,name=state,proto3,enum=jobs.JobState" json:"state,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobInfo) Reset() {
+	*x = JobInfo{}
+	mi := &file_jobs_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobInfo) ProtoMessage() {}
+
+func (x *JobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobInfo.ProtoReflect.Descriptor instead.
+func (*JobInfo) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *JobInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobInfo) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_PENDING
+}
+
+func (x *JobInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*JobInfo             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_jobs_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListJobsResponse) GetItems() []*JobInfo {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RenderCommandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       string                 `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderCommandResponse) Reset() {
+	*x = RenderCommandResponse{}
+	mi := &file_jobs_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderCommandResponse) ProtoMessage() {}
+
+func (x *RenderCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderCommandResponse.ProtoReflect.Descriptor instead.
+func (*RenderCommandResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RenderCommandResponse) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+type RunNamedJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                               // Catalog entry from jobs.yml
+	Params        map[string]string      `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Merged over the entry's default args
+	JobId         string                 `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`                                                                // Optional: if not provided, will be auto-generated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunNamedJobRequest) Reset() {
+	*x = RunNamedJobRequest{}
+	mi := &file_jobs_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunNamedJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunNamedJobRequest) ProtoMessage() {}
+
+func (x *RunNamedJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunNamedJobRequest.ProtoReflect.Descriptor instead.
+func (*RunNamedJobRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RunNamedJobRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunNamedJobRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *RunNamedJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ReconcileSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fix           bool                   `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty"` // Rewrite drifted provider schedules from the store
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileSchedulesRequest) Reset() {
+	*x = ReconcileSchedulesRequest{}
+	mi := &file_jobs_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileSchedulesRequest) ProtoMessage() {}
+
+func (x *ReconcileSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReconcileSchedulesRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+type ScheduleDrift struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`       // "missing", "spec", "time_zone" or "target"
+	Expected      string                 `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"` // From the store
+	Actual        string                 `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`     // From the provider
+	Fixed         bool                   `protobuf:"varint,5,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"` // Why the schedule could not be inspected or fixed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleDrift) Reset() {
+	*x = ScheduleDrift{}
+	mi := &file_jobs_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleDrift) ProtoMessage() {}
+
+func (x *ScheduleDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleDrift.ProtoReflect.Descriptor instead.
+func (*ScheduleDrift) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ScheduleDrift) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduleDrift) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ScheduleDrift) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *ScheduleDrift) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+func (x *ScheduleDrift) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+func (x *ScheduleDrift) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ReconcileSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drifts        []*ScheduleDrift       `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileSchedulesResponse) Reset() {
+	*x = ReconcileSchedulesResponse{}
+	mi := &file_jobs_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileSchedulesResponse) ProtoMessage() {}
+
+func (x *ReconcileSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReconcileSchedulesResponse) GetDrifts() []*ScheduleDrift {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+type ListExecutionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                               // Only executions of this job; all when empty
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`                                                                            // Unix seconds; only executions started at or after
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                                                                            // Most recent first; unlimited when 0
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Only executions carrying all of these labels
+	BatchId       string                 `protobuf:"bytes,5,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`                                                          // Only executions of this batch
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                                                           // Only executions with this status
+	Until         int64                  `protobuf:"varint,7,opt,name=until,proto3" json:"until,omitempty"`                                                                            // Unix seconds; only executions started before
+	Offset        int32                  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`                                                                          // Skip this many of the matching executions
+	Metrics       []string               `protobuf:"bytes,9,rep,name=metrics,proto3" json:"metrics,omitempty"`                                                                         // Only executions whose metrics satisfy all of these, e.g. "rows>=1000"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsRequest) Reset() {
+	*x = ListExecutionsRequest{}
+	mi := &file_jobs_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsRequest) ProtoMessage() {}
+
+func (x *ListExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ListExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListExecutionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListExecutionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListExecutionsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListExecutionsRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListExecutionsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ListExecutionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListExecutionsRequest) GetMetrics() []string {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+type ExecutionItem struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Command           string                 `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Status            string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Error             string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt         int64                  `protobuf:"varint,6,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt        int64                  `protobuf:"varint,7,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	EstimatedCost     float64                `protobuf:"fixed64,8,opt,name=estimated_cost,json=estimatedCost,proto3" json:"estimated_cost,omitempty"` // 0 until a cloud run has finished and been priced
+	RetryDecision     string                 `protobuf:"bytes,9,opt,name=retry_decision,json=retryDecision,proto3" json:"retry_decision,omitempty"`   // why a failed scheduled run was or was not retried
+	Progress          float64                `protobuf:"fixed64,10,opt,name=progress,proto3" json:"progress,omitempty"`                               // last percentage (0-100) the job reported
+	ProgressMessage   string                 `protobuf:"bytes,11,opt,name=progress_message,json=progressMessage,proto3" json:"progress_message,omitempty"`
+	ProgressUpdatedAt int64                  `protobuf:"varint,12,opt,name=progress_updated_at,json=progressUpdatedAt,proto3" json:"progress_updated_at,omitempty"`                         // 0 until the job reports progress
+	Labels            map[string]string      `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Server defaults merged under the request's labels
+	BatchId           string                 `protobuf:"bytes,14,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	ResultHash        string                 `protobuf:"bytes,15,opt,name=result_hash,json=resultHash,proto3" json:"result_hash,omitempty"`                                                     // hex SHA-256 of the run's output, for jobs configured with hash_result
+	ResultUnchanged   bool                   `protobuf:"varint,16,opt,name=result_unchanged,json=resultUnchanged,proto3" json:"result_unchanged,omitempty"`                                     // the output matches that of the previous successful run of the same name
+	Metrics           map[string]float64     `protobuf:"bytes,17,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // numbers extracted from the output for jobs configured with metrics, e.g. rows
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExecutionItem) Reset() {
+	*x = ExecutionItem{}
+	mi := &file_jobs_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecutionItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionItem) ProtoMessage() {}
+
+func (x *ExecutionItem) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionItem.ProtoReflect.Descriptor instead.
+func (*ExecutionItem) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExecutionItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *ExecutionItem) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *ExecutionItem) GetEstimatedCost() float64 {
+	if x != nil {
+		return x.EstimatedCost
+	}
+	return 0
+}
+
+func (x *ExecutionItem) GetRetryDecision() string {
+	if x != nil {
+		return x.RetryDecision
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *ExecutionItem) GetProgressMessage() string {
+	if x != nil {
+		return x.ProgressMessage
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetProgressUpdatedAt() int64 {
+	if x != nil {
+		return x.ProgressUpdatedAt
+	}
+	return 0
+}
+
+func (x *ExecutionItem) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ExecutionItem) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetResultHash() string {
+	if x != nil {
+		return x.ResultHash
+	}
+	return ""
+}
+
+func (x *ExecutionItem) GetResultUnchanged() bool {
+	if x != nil {
+		return x.ResultUnchanged
+	}
+	return false
+}
+
+func (x *ExecutionItem) GetMetrics() map[string]float64 {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+type ListExecutionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ExecutionItem       `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExecutionsResponse) Reset() {
+	*x = ListExecutionsResponse{}
+	mi := &file_jobs_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExecutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExecutionsResponse) ProtoMessage() {}
+
+func (x *ListExecutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExecutionsResponse.ProtoReflect.Descriptor instead.
+func (*ListExecutionsResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListExecutionsResponse) GetItems() []*ExecutionItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExecutionRequest) Reset() {
+	*x = GetExecutionRequest{}
+	mi := &file_jobs_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExecutionRequest) ProtoMessage() {}
+
+func (x *GetExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExecutionRequest.ProtoReflect.Descriptor instead.
+func (*GetExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetExecutionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CostReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Since         int64                  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Only executions carrying all of these labels
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostReportRequest) Reset() {
+	*x = CostReportRequest{}
+	mi := &file_jobs_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostReportRequest) ProtoMessage() {}
+
+func (x *CostReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostReportRequest.ProtoReflect.Descriptor instead.
+func (*CostReportRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CostReportRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CostReportRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *CostReportRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type CostReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Executions    int32                  `protobuf:"varint,2,opt,name=executions,proto3" json:"executions,omitempty"`                             // Executions in the window
+	EstimatedCost float64                `protobuf:"fixed64,3,opt,name=estimated_cost,json=estimatedCost,proto3" json:"estimated_cost,omitempty"` // Sum over the window
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CostReportResponse) Reset() {
+	*x = CostReportResponse{}
+	mi := &file_jobs_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CostReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostReportResponse) ProtoMessage() {}
+
+func (x *CostReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostReportResponse.ProtoReflect.Descriptor instead.
+func (*CostReportResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CostReportResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CostReportResponse) GetExecutions() int32 {
+	if x != nil {
+		return x.Executions
+	}
+	return 0
+}
+
+func (x *CostReportResponse) GetEstimatedCost() float64 {
+	if x != nil {
+		return x.EstimatedCost
+	}
+	return 0
+}
+
+type ExportExecutionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                                                                           // "csv" (default) or "json"
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                                                               // Only executions of this job; all when empty
+	Since         int64                  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`                                                                            // Unix seconds; only executions started at or after
+	Until         int64                  `protobuf:"varint,4,opt,name=until,proto3" json:"until,omitempty"`                                                                            // Unix seconds; only executions started before
+	Labels        map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Only executions carrying all of these labels
+	BatchId       string                 `protobuf:"bytes,6,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`                                                          // Only executions of this batch
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                                                           // Only executions with this status
+	ExcludeResult bool                   `protobuf:"varint,8,opt,name=exclude_result,json=excludeResult,proto3" json:"exclude_result,omitempty"`                                       // Leave out each run's output, the largest column
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportExecutionsRequest) Reset() {
+	*x = ExportExecutionsRequest{}
+	mi := &file_jobs_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportExecutionsRequest) ProtoMessage() {}
+
+func (x *ExportExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*ExportExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExportExecutionsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportExecutionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportExecutionsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ExportExecutionsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ExportExecutionsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ExportExecutionsRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *ExportExecutionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ExportExecutionsRequest) GetExcludeResult() bool {
+	if x != nil {
+		return x.ExcludeResult
+	}
+	return false
+}
+
+type ExportExecutionsChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportExecutionsChunk) Reset() {
+	*x = ExportExecutionsChunk{}
+	mi := &file_jobs_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportExecutionsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportExecutionsChunk) ProtoMessage() {}
+
+func (x *ExportExecutionsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportExecutionsChunk.ProtoReflect.Descriptor instead.
+func (*ExportExecutionsChunk) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ExportExecutionsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Follows a run's output while it runs. Start the run with a job_id to know
+// what to follow before RunJob returns.
+type StreamLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLogsRequest) Reset() {
+	*x = StreamLogsRequest{}
+	mi := &file_jobs_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLogsRequest) ProtoMessage() {}
+
+func (x *StreamLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamLogsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *StreamLogsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type LogChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogChunk) Reset() {
+	*x = LogChunk{}
+	mi := &file_jobs_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogChunk) ProtoMessage() {}
+
+func (x *LogChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogChunk.ProtoReflect.Descriptor instead.
+func (*LogChunk) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *LogChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_jobs_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{50}
+}
+
+type RunnerStats struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Runner             string                 `protobuf:"bytes,1,opt,name=runner,proto3" json:"runner,omitempty"`                                                      // Runner profile; empty for the primary runner
+	OutstandingJobs    int32                  `protobuf:"varint,2,opt,name=outstanding_jobs,json=outstandingJobs,proto3" json:"outstanding_jobs,omitempty"`            // Submitted jobs that have not finished yet
+	MaxOutstandingJobs int32                  `protobuf:"varint,3,opt,name=max_outstanding_jobs,json=maxOutstandingJobs,proto3" json:"max_outstanding_jobs,omitempty"` // 0 when submissions are not capped
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RunnerStats) Reset() {
+	*x = RunnerStats{}
+	mi := &file_jobs_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunnerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunnerStats) ProtoMessage() {}
+
+func (x *RunnerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunnerStats.ProtoReflect.Descriptor instead.
+func (*RunnerStats) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RunnerStats) GetRunner() string {
+	if x != nil {
+		return x.Runner
+	}
+	return ""
+}
+
+func (x *RunnerStats) GetOutstandingJobs() int32 {
+	if x != nil {
+		return x.OutstandingJobs
+	}
+	return 0
+}
+
+func (x *RunnerStats) GetMaxOutstandingJobs() int32 {
+	if x != nil {
+		return x.MaxOutstandingJobs
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Runners         []*RunnerStats         `protobuf:"bytes,1,rep,name=runners,proto3" json:"runners,omitempty"`
+	Schedules       int32                  `protobuf:"varint,2,opt,name=schedules,proto3" json:"schedules,omitempty"`                                    // Stored schedules
+	MaxSchedules    int32                  `protobuf:"varint,3,opt,name=max_schedules,json=maxSchedules,proto3" json:"max_schedules,omitempty"`          // 0 when schedule creation is not capped
+	MaintenanceMode bool                   `protobuf:"varint,4,opt,name=maintenance_mode,json=maintenanceMode,proto3" json:"maintenance_mode,omitempty"` // Scheduled ticks are skipped and RunJob is refused
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_jobs_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetStatsResponse) GetRunners() []*RunnerStats {
+	if x != nil {
+		return x.Runners
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetSchedules() int32 {
+	if x != nil {
+		return x.Schedules
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMaxSchedules() int32 {
+	if x != nil {
+		return x.MaxSchedules
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetMaintenanceMode() bool {
+	if x != nil {
+		return x.MaintenanceMode
+	}
+	return false
+}
+
+// In maintenance mode scheduled ticks are recorded as skipped and new runs
+// are refused; the mode is persisted so it survives restarts
+type SetMaintenanceModeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	On            bool                   `protobuf:"varint,1,opt,name=on,proto3" json:"on,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSchedulesRequest) Reset() {
-	*x = ListSchedulesRequest{}
-	mi := &file_jobs_proto_msgTypes[9]
+func (x *SetMaintenanceModeRequest) Reset() {
+	*x = SetMaintenanceModeRequest{}
+	mi := &file_jobs_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSchedulesRequest) String() string {
+func (x *SetMaintenanceModeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSchedulesRequest) ProtoMessage() {}
+func (*SetMaintenanceModeRequest) ProtoMessage() {}
 
-func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_jobs_proto_msgTypes[9]
+func (x *SetMaintenanceModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,37 +3489,40 @@ func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
-func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
-	return file_jobs_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SetMaintenanceModeRequest.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{53}
 }
 
-type ScheduleItem struct {
+func (x *SetMaintenanceModeRequest) GetOn() bool {
+	if x != nil {
+		return x.On
+	}
+	return false
+}
+
+type SetMaintenanceModeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Command       string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
-	ArgsBase64    string                 `protobuf:"bytes,3,opt,name=args_base64,json=argsBase64,proto3" json:"args_base64,omitempty"`
-	Cron          string                 `protobuf:"bytes,4,opt,name=cron,proto3" json:"cron,omitempty"`
-	Resources     *Resources             `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	On            bool                   `protobuf:"varint,1,opt,name=on,proto3" json:"on,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ScheduleItem) Reset() {
-	*x = ScheduleItem{}
-	mi := &file_jobs_proto_msgTypes[10]
+func (x *SetMaintenanceModeResponse) Reset() {
+	*x = SetMaintenanceModeResponse{}
+	mi := &file_jobs_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ScheduleItem) String() string {
+func (x *SetMaintenanceModeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScheduleItem) ProtoMessage() {}
+func (*SetMaintenanceModeResponse) ProtoMessage() {}
 
-func (x *ScheduleItem) ProtoReflect() protoreflect.Message {
-	mi := &file_jobs_proto_msgTypes[10]
+func (x *SetMaintenanceModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,68 +3533,216 @@ func (x *ScheduleItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScheduleItem.ProtoReflect.Descriptor instead.
-func (*ScheduleItem) Descriptor() ([]byte, []int) {
-	return file_jobs_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SetMaintenanceModeResponse.ProtoReflect.Descriptor instead.
+func (*SetMaintenanceModeResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *ScheduleItem) GetName() string {
+func (x *SetMaintenanceModeResponse) GetOn() bool {
 	if x != nil {
-		return x.Name
+		return x.On
+	}
+	return false
+}
+
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`  // Only calls of this full method, e.g. /jobs.JobsService/RunJob
+	Caller        string                 `protobuf:"bytes,2,opt,name=caller,proto3" json:"caller,omitempty"`  // Only calls by this caller
+	Since         int64                  `protobuf:"varint,3,opt,name=since,proto3" json:"since,omitempty"`   // Unix seconds; only events at or after
+	Until         int64                  `protobuf:"varint,4,opt,name=until,proto3" json:"until,omitempty"`   // Unix seconds; only events before
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`   // Most recent first; unlimited when 0
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"` // Skip this many events
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_jobs_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListAuditEventsRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
 	return ""
 }
 
-func (x *ScheduleItem) GetCommand() string {
+func (x *ListAuditEventsRequest) GetCaller() string {
 	if x != nil {
-		return x.Command
+		return x.Caller
 	}
 	return ""
 }
 
-func (x *ScheduleItem) GetArgsBase64() string {
+func (x *ListAuditEventsRequest) GetSince() int64 {
 	if x != nil {
-		return x.ArgsBase64
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	At            int64                  `protobuf:"varint,2,opt,name=at,proto3" json:"at,omitempty"`          // Unix seconds
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`   // Full gRPC method
+	Caller        string                 `protobuf:"bytes,4,opt,name=caller,proto3" json:"caller,omitempty"`   // Who made the call
+	Args          string                 `protobuf:"bytes,5,opt,name=args,proto3" json:"args,omitempty"`       // The request as JSON, secret values redacted
+	Outcome       string                 `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"` // gRPC status code, e.g. OK or InvalidArgument
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_jobs_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ScheduleItem) GetCron() string {
+func (x *AuditEvent) GetAt() int64 {
 	if x != nil {
-		return x.Cron
+		return x.At
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
 	}
 	return ""
 }
 
-func (x *ScheduleItem) GetResources() *Resources {
+func (x *AuditEvent) GetCaller() string {
 	if x != nil {
-		return x.Resources
+		return x.Caller
 	}
-	return nil
+	return ""
 }
 
-type ListSchedulesResponse struct {
+func (x *AuditEvent) GetArgs() string {
+	if x != nil {
+		return x.Args
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*ScheduleItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Items         []*AuditEvent          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSchedulesResponse) Reset() {
-	*x = ListSchedulesResponse{}
-	mi := &file_jobs_proto_msgTypes[11]
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_jobs_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSchedulesResponse) String() string {
+func (x *ListAuditEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSchedulesResponse) ProtoMessage() {}
+func (*ListAuditEventsResponse) ProtoMessage() {}
 
-func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_jobs_proto_msgTypes[11]
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jobs_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,12 +3753,12 @@ func (x *ListSchedulesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSchedulesResponse.ProtoReflect.Descriptor instead.
-func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
-	return file_jobs_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_jobs_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ListSchedulesResponse) GetItems() []*ScheduleItem {
+func (x *ListAuditEventsResponse) GetItems() []*AuditEvent {
 	if x != nil {
 		return x.Items
 	}
@@ -721,10 +3770,12 @@ var File_jobs_proto protoreflect.FileDescriptor
 const file_jobs_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"jobs.proto\x12\x04jobs\"5\n" +
+	"jobs.proto\x12\x04jobs\"m\n" +
 	"\tResources\x12\x10\n" +
 	"\x03cpu\x18\x01 \x01(\tR\x03cpu\x12\x16\n" +
-	"\x06memory\x18\x02 \x01(\tR\x06memory\"\x95\x02\n" +
+	"\x06memory\x18\x02 \x01(\tR\x06memory\x12\x1b\n" +
+	"\tgpu_count\x18\x03 \x01(\x05R\bgpuCount\x12\x19\n" +
+	"\bgpu_type\x18\x04 \x01(\tR\agpuType\"\xe3\a\n" +
 	"\rRunJobRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
 	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x18\n" +
@@ -734,44 +3785,330 @@ const file_jobs_proto_rawDesc = "" +
 	"\tresources\x18\x05 \x01(\v2\x0f.jobs.ResourcesR\tresources\x12!\n" +
 	"\x04type\x18\x06 \x01(\x0e2\r.jobs.JobTypeR\x04type\x12\x1a\n" +
 	"\bschedule\x18\a \x01(\tR\bschedule\x120\n" +
-	"\toverrides\x18\b \x01(\v2\x12.jobs.JobOverridesR\toverrides\"\x90\x01\n" +
+	"\toverrides\x18\b \x01(\v2\x12.jobs.JobOverridesR\toverrides\x12,\n" +
+	"\x0fcoalesce_missed\x18\t \x01(\bH\x00R\x0ecoalesceMissed\x88\x01\x01\x12\x1f\n" +
+	"\vmax_catchup\x18\n" +
+	" \x01(\x05R\n" +
+	"maxCatchup\x12\x16\n" +
+	"\x06runner\x18\v \x01(\tR\x06runner\x12\x1c\n" +
+	"\tsingleton\x18\f \x01(\bR\tsingleton\x12\x15\n" +
+	"\x06run_at\x18\r \x01(\x03R\x05runAt\x12'\n" +
+	"\rrun_if_missed\x18\x0e \x01(\bH\x01R\vrunIfMissed\x88\x01\x01\x127\n" +
+	"\x06labels\x18\x0f \x03(\v2\x1f.jobs.RunJobRequest.LabelsEntryR\x06labels\x12\x19\n" +
+	"\bbatch_id\x18\x10 \x01(\tR\abatchId\x12\x18\n" +
+	"\atimeout\x18\x11 \x01(\tR\atimeout\x12$\n" +
+	"\vmax_retries\x18\x12 \x01(\x05H\x02R\n" +
+	"maxRetries\x88\x01\x01\x12&\n" +
+	"\x0fskip_if_running\x18\x14 \x01(\bR\rskipIfRunning\x12!\n" +
+	"\fmachine_type\x18\x13 \x01(\tR\vmachineType\x12\x1b\n" +
+	"\ttime_zone\x18\x15 \x01(\tR\btimeZone\x12*\n" +
+	"\bsecurity\x18\x16 \x01(\v2\x0e.jobs.SecurityR\bsecurity\x12\x12\n" +
+	"\x04wait\x18\x17 \x01(\bR\x04wait\x12\x1f\n" +
+	"\vstop_signal\x18\x18 \x01(\tR\n" +
+	"stopSignal\x12\x17\n" +
+	"\adry_run\x18\x19 \x01(\bR\x06dryRun\x12 \n" +
+	"\vdeduplicate\x18\x1a \x01(\bR\vdeduplicate\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
+	"\x10_coalesce_missedB\x10\n" +
+	"\x0e_run_if_missedB\x0e\n" +
+	"\f_max_retries\"j\n" +
+	"\bSecurity\x12\x19\n" +
+	"\bcap_drop\x18\x01 \x03(\tR\acapDrop\x12\x17\n" +
+	"\acap_add\x18\x02 \x03(\tR\x06capAdd\x12*\n" +
+	"\x11no_new_privileges\x18\x03 \x01(\bR\x0fnoNewPrivileges\"T\n" +
+	"\x0eRunJobsRequest\x12'\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x13.jobs.RunJobRequestR\x04jobs\x12\x19\n" +
+	"\bbatch_id\x18\x02 \x01(\tR\abatchId\"5\n" +
+	"\rRunJobsResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"[\n" +
+	"\x0fRunJobsResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12-\n" +
+	"\aresults\x18\x02 \x03(\v2\x13.jobs.RunJobsResultR\aresults\"2\n" +
+	"\x15GetBatchStatusRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"\x99\x01\n" +
+	"\x16GetBatchStatusResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\x05R\arunning\x12\x1c\n" +
+	"\tsucceeded\x18\x04 \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\x05 \x01(\x05R\x06failed\"\xd7\x01\n" +
 	"\fJobOverrides\x12\x12\n" +
 	"\x04args\x18\x01 \x03(\tR\x04args\x12\x1e\n" +
 	"\x03env\x18\x02 \x03(\v2\f.jobs.EnvVarR\x03env\x12-\n" +
 	"\tresources\x18\x03 \x01(\v2\x0f.jobs.ResourcesR\tresources\x12\x1d\n" +
 	"\n" +
-	"task_count\x18\x04 \x01(\x05R\ttaskCount\"2\n" +
+	"task_count\x18\x04 \x01(\x05R\ttaskCount\x12+\n" +
+	"\avolumes\x18\x05 \x03(\v2\x11.jobs.VolumeMountR\avolumes\x12\x18\n" +
+	"\anetwork\x18\x06 \x01(\tR\anetwork\"n\n" +
+	"\vVolumeMount\x12\x1b\n" +
+	"\thost_path\x18\x01 \x01(\tR\bhostPath\x12%\n" +
+	"\x0econtainer_path\x18\x02 \x01(\tR\rcontainerPath\x12\x1b\n" +
+	"\tread_only\x18\x03 \x01(\bR\breadOnly\"2\n" +
 	"\x06EnvVar\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value\"4\n" +
 	"\x0eRunJobResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04logs\x18\x02 \x01(\tR\x04logs\"&\n" +
+	"\x04logs\x18\x02 \x01(\tR\x04logs\"=\n" +
 	"\x10DeleteJobRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"\x13\n" +
-	"\x11DeleteJobResponse\"G\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\"\x13\n" +
+	"\x11DeleteJobResponse\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
+	"\x11CancelJobResponse\"G\n" +
 	"\x15UpdateScheduleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\bschedule\x18\x02 \x01(\tR\bschedule\"\x18\n" +
-	"\x16UpdateScheduleResponse\"\x16\n" +
-	"\x14ListSchedulesRequest\"\xa0\x01\n" +
+	"\x16UpdateScheduleResponse\"*\n" +
+	"\x14PauseScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x17\n" +
+	"\x15PauseScheduleResponse\"+\n" +
+	"\x15ResumeScheduleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x18\n" +
+	"\x16ResumeScheduleResponse\"D\n" +
+	"\x14ListSchedulesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\xbd\x03\n" +
 	"\fScheduleItem\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\acommand\x18\x02 \x01(\tR\acommand\x12\x1f\n" +
 	"\vargs_base64\x18\x03 \x01(\tR\n" +
 	"argsBase64\x12\x12\n" +
 	"\x04cron\x18\x04 \x01(\tR\x04cron\x12-\n" +
-	"\tresources\x18\x05 \x01(\v2\x0f.jobs.ResourcesR\tresources\"A\n" +
+	"\tresources\x18\x05 \x01(\v2\x0f.jobs.ResourcesR\tresources\x12\x16\n" +
+	"\x06runner\x18\x06 \x01(\tR\x06runner\x12\x1c\n" +
+	"\tsingleton\x18\a \x01(\bR\tsingleton\x12\x15\n" +
+	"\x06run_at\x18\b \x01(\x03R\x05runAt\x126\n" +
+	"\x06labels\x18\t \x03(\v2\x1e.jobs.ScheduleItem.LabelsEntryR\x06labels\x12&\n" +
+	"\x0fskip_if_running\x18\n" +
+	" \x01(\bR\rskipIfRunning\x12\x1b\n" +
+	"\ttime_zone\x18\v \x01(\tR\btimeZone\x12\x16\n" +
+	"\x06paused\x18\f \x01(\bR\x06paused\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"A\n" +
 	"\x15ListSchedulesResponse\x12(\n" +
-	"\x05items\x18\x01 \x03(\v2\x12.jobs.ScheduleItemR\x05items*9\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.jobs.ScheduleItemR\x05items\"\x90\x01\n" +
+	"\vRetryPolicy\x12\x1f\n" +
+	"\vmax_retries\x18\x01 \x01(\x05R\n" +
+	"maxRetries\x12#\n" +
+	"\rinitial_delay\x18\x02 \x01(\tR\finitialDelay\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x03 \x01(\x01R\n" +
+	"multiplier\x12\x1b\n" +
+	"\tmax_delay\x18\x04 \x01(\tR\bmaxDelay\"2\n" +
+	"\x1cGetEffectiveJobConfigRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xcc\x03\n" +
+	"\x1dGetEffectiveJobConfigResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bprovider\x18\x02 \x01(\tR\bprovider\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12%\n" +
+	"\x0ecommand_prefix\x18\x04 \x01(\tR\rcommandPrefix\x12\x18\n" +
+	"\acommand\x18\x05 \x01(\tR\acommand\x12-\n" +
+	"\tresources\x18\x06 \x01(\v2\x0f.jobs.ResourcesR\tresources\x12\x1e\n" +
+	"\x03env\x18\a \x03(\v2\f.jobs.EnvVarR\x03env\x12\x18\n" +
+	"\atimeout\x18\b \x01(\tR\atimeout\x12\x1a\n" +
+	"\bschedule\x18\t \x01(\tR\bschedule\x12,\n" +
+	"\x12success_exit_codes\x18\n" +
+	" \x03(\x05R\x10successExitCodes\x120\n" +
+	"\x14success_output_regex\x18\v \x01(\tR\x12successOutputRegex\x12\x16\n" +
+	"\x06runner\x18\f \x01(\tR\x06runner\x12'\n" +
+	"\x05retry\x18\r \x01(\v2\x11.jobs.RetryPolicyR\x05retry\"\x8c\x01\n" +
+	"\x0eGetLogsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\n" +
+	"task_index\x18\x02 \x01(\x05H\x00R\ttaskIndex\x88\x01\x01\x12\x16\n" +
+	"\x06runner\x18\x03 \x01(\tR\x06runner\x12\x1b\n" +
+	"\tmax_lines\x18\x04 \x01(\x05R\bmaxLinesB\r\n" +
+	"\v_task_index\"%\n" +
+	"\x0fGetLogsResponse\x12\x12\n" +
+	"\x04logs\x18\x01 \x01(\tR\x04logs\"A\n" +
+	"\x13GetJobStatusRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06runner\x18\x02 \x01(\tR\x06runner\"\x99\x01\n" +
+	"\x14GetJobStatusResponse\x12$\n" +
+	"\x05state\x18\x01 \x01(\x0e2\x0e.jobs.JobStateR\x05state\x12\x1b\n" +
+	"\texit_code\x18\x02 \x01(\x05R\bexitCode\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x04 \x01(\x03R\n" +
+	"finishedAt\")\n" +
+	"\x0fListJobsRequest\x12\x16\n" +
+	"\x06runner\x18\x01 \x01(\tR\x06runner\"b\n" +
+	"\aJobInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x0e.jobs.JobStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\x03R\tcreatedAt\"7\n" +
+	"\x10ListJobsResponse\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.jobs.JobInfoR\x05items\"1\n" +
+	"\x15RenderCommandResponse\x12\x18\n" +
+	"\acommand\x18\x01 \x01(\tR\acommand\"\xb8\x01\n" +
+	"\x12RunNamedJobRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12<\n" +
+	"\x06params\x18\x02 \x03(\v2$.jobs.RunNamedJobRequest.ParamsEntryR\x06params\x12\x15\n" +
+	"\x06job_id\x18\x03 \x01(\tR\x05jobId\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"-\n" +
+	"\x19ReconcileSchedulesRequest\x12\x10\n" +
+	"\x03fix\x18\x01 \x01(\bR\x03fix\"\x99\x01\n" +
+	"\rScheduleDrift\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x1a\n" +
+	"\bexpected\x18\x03 \x01(\tR\bexpected\x12\x16\n" +
+	"\x06actual\x18\x04 \x01(\tR\x06actual\x12\x14\n" +
+	"\x05fixed\x18\x05 \x01(\bR\x05fixed\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"I\n" +
+	"\x1aReconcileSchedulesResponse\x12+\n" +
+	"\x06drifts\x18\x01 \x03(\v2\x13.jobs.ScheduleDriftR\x06drifts\"\xce\x02\n" +
+	"\x15ListExecutionsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12?\n" +
+	"\x06labels\x18\x04 \x03(\v2'.jobs.ListExecutionsRequest.LabelsEntryR\x06labels\x12\x19\n" +
+	"\bbatch_id\x18\x05 \x01(\tR\abatchId\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x14\n" +
+	"\x05until\x18\a \x01(\x03R\x05until\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offset\x12\x18\n" +
+	"\ametrics\x18\t \x03(\tR\ametrics\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x05\n" +
+	"\rExecutionItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x06 \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\a \x01(\x03R\n" +
+	"finishedAt\x12%\n" +
+	"\x0eestimated_cost\x18\b \x01(\x01R\restimatedCost\x12%\n" +
+	"\x0eretry_decision\x18\t \x01(\tR\rretryDecision\x12\x1a\n" +
+	"\bprogress\x18\n" +
+	" \x01(\x01R\bprogress\x12)\n" +
+	"\x10progress_message\x18\v \x01(\tR\x0fprogressMessage\x12.\n" +
+	"\x13progress_updated_at\x18\f \x01(\x03R\x11progressUpdatedAt\x127\n" +
+	"\x06labels\x18\r \x03(\v2\x1f.jobs.ExecutionItem.LabelsEntryR\x06labels\x12\x19\n" +
+	"\bbatch_id\x18\x0e \x01(\tR\abatchId\x12\x1f\n" +
+	"\vresult_hash\x18\x0f \x01(\tR\n" +
+	"resultHash\x12)\n" +
+	"\x10result_unchanged\x18\x10 \x01(\bR\x0fresultUnchanged\x12:\n" +
+	"\ametrics\x18\x11 \x03(\v2 .jobs.ExecutionItem.MetricsEntryR\ametrics\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fMetricsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"C\n" +
+	"\x16ListExecutionsResponse\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.jobs.ExecutionItemR\x05items\"%\n" +
+	"\x13GetExecutionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb5\x01\n" +
+	"\x11CostReportRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\x03R\x05since\x12;\n" +
+	"\x06labels\x18\x03 \x03(\v2#.jobs.CostReportRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"o\n" +
+	"\x12CostReportResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"executions\x18\x02 \x01(\x05R\n" +
+	"executions\x12%\n" +
+	"\x0eestimated_cost\x18\x03 \x01(\x01R\restimatedCost\"\xc9\x02\n" +
+	"\x17ExportExecutionsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x04 \x01(\x03R\x05until\x12A\n" +
+	"\x06labels\x18\x05 \x03(\v2).jobs.ExportExecutionsRequest.LabelsEntryR\x06labels\x12\x19\n" +
+	"\bbatch_id\x18\x06 \x01(\tR\abatchId\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12%\n" +
+	"\x0eexclude_result\x18\b \x01(\bR\rexcludeResult\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"+\n" +
+	"\x15ExportExecutionsChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"*\n" +
+	"\x11StreamLogsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\x1e\n" +
+	"\bLogChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x11\n" +
+	"\x0fGetStatsRequest\"\x82\x01\n" +
+	"\vRunnerStats\x12\x16\n" +
+	"\x06runner\x18\x01 \x01(\tR\x06runner\x12)\n" +
+	"\x10outstanding_jobs\x18\x02 \x01(\x05R\x0foutstandingJobs\x120\n" +
+	"\x14max_outstanding_jobs\x18\x03 \x01(\x05R\x12maxOutstandingJobs\"\xad\x01\n" +
+	"\x10GetStatsResponse\x12+\n" +
+	"\arunners\x18\x01 \x03(\v2\x11.jobs.RunnerStatsR\arunners\x12\x1c\n" +
+	"\tschedules\x18\x02 \x01(\x05R\tschedules\x12#\n" +
+	"\rmax_schedules\x18\x03 \x01(\x05R\fmaxSchedules\x12)\n" +
+	"\x10maintenance_mode\x18\x04 \x01(\bR\x0fmaintenanceMode\"+\n" +
+	"\x19SetMaintenanceModeRequest\x12\x0e\n" +
+	"\x02on\x18\x01 \x01(\bR\x02on\",\n" +
+	"\x1aSetMaintenanceModeResponse\x12\x0e\n" +
+	"\x02on\x18\x01 \x01(\bR\x02on\"\xa2\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x16\n" +
+	"\x06caller\x18\x02 \x01(\tR\x06caller\x12\x14\n" +
+	"\x05since\x18\x03 \x01(\x03R\x05since\x12\x14\n" +
+	"\x05until\x18\x04 \x01(\x03R\x05until\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\"\xa0\x01\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x0e\n" +
+	"\x02at\x18\x02 \x01(\x03R\x02at\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x16\n" +
+	"\x06caller\x18\x04 \x01(\tR\x06caller\x12\x12\n" +
+	"\x04args\x18\x05 \x01(\tR\x04args\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\"A\n" +
+	"\x17ListAuditEventsResponse\x12&\n" +
+	"\x05items\x18\x01 \x03(\v2\x10.jobs.AuditEventR\x05items*9\n" +
 	"\aJobType\x12\x15\n" +
 	"\x11JOB_TYPE_ONE_TIME\x10\x00\x12\x17\n" +
-	"\x13JOB_TYPE_REPEATABLE\x10\x012\x97\x02\n" +
+	"\x13JOB_TYPE_REPEATABLE\x10\x01*g\n" +
+	"\bJobState\x12\x15\n" +
+	"\x11JOB_STATE_PENDING\x10\x00\x12\x15\n" +
+	"\x11JOB_STATE_RUNNING\x10\x01\x12\x17\n" +
+	"\x13JOB_STATE_SUCCEEDED\x10\x02\x12\x14\n" +
+	"\x10JOB_STATE_FAILED\x10\x032\xa5\r\n" +
 	"\vJobsService\x123\n" +
-	"\x06RunJob\x12\x13.jobs.RunJobRequest\x1a\x14.jobs.RunJobResponse\x12<\n" +
-	"\tDeleteJob\x12\x16.jobs.DeleteJobRequest\x1a\x17.jobs.DeleteJobResponse\x12K\n" +
+	"\x06RunJob\x12\x13.jobs.RunJobRequest\x1a\x14.jobs.RunJobResponse\x126\n" +
+	"\aRunJobs\x12\x14.jobs.RunJobsRequest\x1a\x15.jobs.RunJobsResponse\x12K\n" +
+	"\x0eGetBatchStatus\x12\x1b.jobs.GetBatchStatusRequest\x1a\x1c.jobs.GetBatchStatusResponse\x12<\n" +
+	"\tDeleteJob\x12\x16.jobs.DeleteJobRequest\x1a\x17.jobs.DeleteJobResponse\x12<\n" +
+	"\tCancelJob\x12\x16.jobs.CancelJobRequest\x1a\x17.jobs.CancelJobResponse\x12K\n" +
 	"\x0eUpdateSchedule\x12\x1b.jobs.UpdateScheduleRequest\x1a\x1c.jobs.UpdateScheduleResponse\x12H\n" +
-	"\rListSchedules\x12\x1a.jobs.ListSchedulesRequest\x1a\x1b.jobs.ListSchedulesResponseB&Z$github.com/SyneHQ/apollo/proto;protob\x06proto3"
+	"\rPauseSchedule\x12\x1a.jobs.PauseScheduleRequest\x1a\x1b.jobs.PauseScheduleResponse\x12K\n" +
+	"\x0eResumeSchedule\x12\x1b.jobs.ResumeScheduleRequest\x1a\x1c.jobs.ResumeScheduleResponse\x12H\n" +
+	"\rListSchedules\x12\x1a.jobs.ListSchedulesRequest\x1a\x1b.jobs.ListSchedulesResponse\x12`\n" +
+	"\x15GetEffectiveJobConfig\x12\".jobs.GetEffectiveJobConfigRequest\x1a#.jobs.GetEffectiveJobConfigResponse\x126\n" +
+	"\aGetLogs\x12\x14.jobs.GetLogsRequest\x1a\x15.jobs.GetLogsResponse\x12E\n" +
+	"\fGetJobStatus\x12\x19.jobs.GetJobStatusRequest\x1a\x1a.jobs.GetJobStatusResponse\x129\n" +
+	"\bListJobs\x12\x15.jobs.ListJobsRequest\x1a\x16.jobs.ListJobsResponse\x12A\n" +
+	"\rRenderCommand\x12\x13.jobs.RunJobRequest\x1a\x1b.jobs.RenderCommandResponse\x12K\n" +
+	"\x0eListExecutions\x12\x1b.jobs.ListExecutionsRequest\x1a\x1c.jobs.ListExecutionsResponse\x12>\n" +
+	"\fGetExecution\x12\x19.jobs.GetExecutionRequest\x1a\x13.jobs.ExecutionItem\x12?\n" +
+	"\n" +
+	"CostReport\x12\x17.jobs.CostReportRequest\x1a\x18.jobs.CostReportResponse\x12=\n" +
+	"\vRunNamedJob\x12\x18.jobs.RunNamedJobRequest\x1a\x14.jobs.RunJobResponse\x12W\n" +
+	"\x12ReconcileSchedules\x12\x1f.jobs.ReconcileSchedulesRequest\x1a .jobs.ReconcileSchedulesResponse\x129\n" +
+	"\bGetStats\x12\x15.jobs.GetStatsRequest\x1a\x16.jobs.GetStatsResponse\x12W\n" +
+	"\x12SetMaintenanceMode\x12\x1f.jobs.SetMaintenanceModeRequest\x1a .jobs.SetMaintenanceModeResponse\x12N\n" +
+	"\x0fListAuditEvents\x12\x1c.jobs.ListAuditEventsRequest\x1a\x1d.jobs.ListAuditEventsResponse\x12P\n" +
+	"\x10ExportExecutions\x12\x1d.jobs.ExportExecutionsRequest\x1a\x1b.jobs.ExportExecutionsChunk0\x01\x127\n" +
+	"\n" +
+	"StreamLogs\x12\x17.jobs.StreamLogsRequest\x1a\x0e.jobs.LogChunk0\x01B&Z$github.com/SyneHQ/apollo/proto;protob\x06proto3"
 
 var (
 	file_jobs_proto_rawDescOnce sync.Once
@@ -785,44 +4122,161 @@ func file_jobs_proto_rawDescGZIP() []byte {
 	return file_jobs_proto_rawDescData
 }
 
-var file_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 66)
 var file_jobs_proto_goTypes = []any{
-	(JobType)(0),                   // 0: jobs.JobType
-	(*Resources)(nil),              // 1: jobs.Resources
-	(*RunJobRequest)(nil),          // 2: jobs.RunJobRequest
-	(*JobOverrides)(nil),           // 3: jobs.JobOverrides
-	(*EnvVar)(nil),                 // 4: jobs.EnvVar
-	(*RunJobResponse)(nil),         // 5: jobs.RunJobResponse
-	(*DeleteJobRequest)(nil),       // 6: jobs.DeleteJobRequest
-	(*DeleteJobResponse)(nil),      // 7: jobs.DeleteJobResponse
-	(*UpdateScheduleRequest)(nil),  // 8: jobs.UpdateScheduleRequest
-	(*UpdateScheduleResponse)(nil), // 9: jobs.UpdateScheduleResponse
-	(*ListSchedulesRequest)(nil),   // 10: jobs.ListSchedulesRequest
-	(*ScheduleItem)(nil),           // 11: jobs.ScheduleItem
-	(*ListSchedulesResponse)(nil),  // 12: jobs.ListSchedulesResponse
+	(JobType)(0),                          // 0: jobs.JobType
+	(JobState)(0),                         // 1: jobs.JobState
+	(*Resources)(nil),                     // 2: jobs.Resources
+	(*RunJobRequest)(nil),                 // 3: jobs.RunJobRequest
+	(*Security)(nil),                      // 4: jobs.Security
+	(*RunJobsRequest)(nil),                // 5: jobs.RunJobsRequest
+	(*RunJobsResult)(nil),                 // 6: jobs.RunJobsResult
+	(*RunJobsResponse)(nil),               // 7: jobs.RunJobsResponse
+	(*GetBatchStatusRequest)(nil),         // 8: jobs.GetBatchStatusRequest
+	(*GetBatchStatusResponse)(nil),        // 9: jobs.GetBatchStatusResponse
+	(*JobOverrides)(nil),                  // 10: jobs.JobOverrides
+	(*VolumeMount)(nil),                   // 11: jobs.VolumeMount
+	(*EnvVar)(nil),                        // 12: jobs.EnvVar
+	(*RunJobResponse)(nil),                // 13: jobs.RunJobResponse
+	(*DeleteJobRequest)(nil),              // 14: jobs.DeleteJobRequest
+	(*DeleteJobResponse)(nil),             // 15: jobs.DeleteJobResponse
+	(*CancelJobRequest)(nil),              // 16: jobs.CancelJobRequest
+	(*CancelJobResponse)(nil),             // 17: jobs.CancelJobResponse
+	(*UpdateScheduleRequest)(nil),         // 18: jobs.UpdateScheduleRequest
+	(*UpdateScheduleResponse)(nil),        // 19: jobs.UpdateScheduleResponse
+	(*PauseScheduleRequest)(nil),          // 20: jobs.PauseScheduleRequest
+	(*PauseScheduleResponse)(nil),         // 21: jobs.PauseScheduleResponse
+	(*ResumeScheduleRequest)(nil),         // 22: jobs.ResumeScheduleRequest
+	(*ResumeScheduleResponse)(nil),        // 23: jobs.ResumeScheduleResponse
+	(*ListSchedulesRequest)(nil),          // 24: jobs.ListSchedulesRequest
+	(*ScheduleItem)(nil),                  // 25: jobs.ScheduleItem
+	(*ListSchedulesResponse)(nil),         // 26: jobs.ListSchedulesResponse
+	(*RetryPolicy)(nil),                   // 27: jobs.RetryPolicy
+	(*GetEffectiveJobConfigRequest)(nil),  // 28: jobs.GetEffectiveJobConfigRequest
+	(*GetEffectiveJobConfigResponse)(nil), // 29: jobs.GetEffectiveJobConfigResponse
+	(*GetLogsRequest)(nil),                // 30: jobs.GetLogsRequest
+	(*GetLogsResponse)(nil),               // 31: jobs.GetLogsResponse
+	(*GetJobStatusRequest)(nil),           // 32: jobs.GetJobStatusRequest
+	(*GetJobStatusResponse)(nil),          // 33: jobs.GetJobStatusResponse
+	(*ListJobsRequest)(nil),               // 34: jobs.ListJobsRequest
+	(*JobInfo)(nil),                       // 35: jobs.JobInfo
+	(*ListJobsResponse)(nil),              // 36: jobs.ListJobsResponse
+	(*RenderCommandResponse)(nil),         // 37: jobs.RenderCommandResponse
+	(*RunNamedJobRequest)(nil),            // 38: jobs.RunNamedJobRequest
+	(*ReconcileSchedulesRequest)(nil),     // 39: jobs.ReconcileSchedulesRequest
+	(*ScheduleDrift)(nil),                 // 40: jobs.ScheduleDrift
+	(*ReconcileSchedulesResponse)(nil),    // 41: jobs.ReconcileSchedulesResponse
+	(*ListExecutionsRequest)(nil),         // 42: jobs.ListExecutionsRequest
+	(*ExecutionItem)(nil),                 // 43: jobs.ExecutionItem
+	(*ListExecutionsResponse)(nil),        // 44: jobs.ListExecutionsResponse
+	(*GetExecutionRequest)(nil),           // 45: jobs.GetExecutionRequest
+	(*CostReportRequest)(nil),             // 46: jobs.CostReportRequest
+	(*CostReportResponse)(nil),            // 47: jobs.CostReportResponse
+	(*ExportExecutionsRequest)(nil),       // 48: jobs.ExportExecutionsRequest
+	(*ExportExecutionsChunk)(nil),         // 49: jobs.ExportExecutionsChunk
+	(*StreamLogsRequest)(nil),             // 50: jobs.StreamLogsRequest
+	(*LogChunk)(nil),                      // 51: jobs.LogChunk
+	(*GetStatsRequest)(nil),               // 52: jobs.GetStatsRequest
+	(*RunnerStats)(nil),                   // 53: jobs.RunnerStats
+	(*GetStatsResponse)(nil),              // 54: jobs.GetStatsResponse
+	(*SetMaintenanceModeRequest)(nil),     // 55: jobs.SetMaintenanceModeRequest
+	(*SetMaintenanceModeResponse)(nil),    // 56: jobs.SetMaintenanceModeResponse
+	(*ListAuditEventsRequest)(nil),        // 57: jobs.ListAuditEventsRequest
+	(*AuditEvent)(nil),                    // 58: jobs.AuditEvent
+	(*ListAuditEventsResponse)(nil),       // 59: jobs.ListAuditEventsResponse
+	nil,                                   // 60: jobs.RunJobRequest.LabelsEntry
+	nil,                                   // 61: jobs.ScheduleItem.LabelsEntry
+	nil,                                   // 62: jobs.RunNamedJobRequest.ParamsEntry
+	nil,                                   // 63: jobs.ListExecutionsRequest.LabelsEntry
+	nil,                                   // 64: jobs.ExecutionItem.LabelsEntry
+	nil,                                   // 65: jobs.ExecutionItem.MetricsEntry
+	nil,                                   // 66: jobs.CostReportRequest.LabelsEntry
+	nil,                                   // 67: jobs.ExportExecutionsRequest.LabelsEntry
 }
 var file_jobs_proto_depIdxs = []int32{
-	1,  // 0: jobs.RunJobRequest.resources:type_name -> jobs.Resources
+	2,  // 0: jobs.RunJobRequest.resources:type_name -> jobs.Resources
 	0,  // 1: jobs.RunJobRequest.type:type_name -> jobs.JobType
-	3,  // 2: jobs.RunJobRequest.overrides:type_name -> jobs.JobOverrides
-	4,  // 3: jobs.JobOverrides.env:type_name -> jobs.EnvVar
-	1,  // 4: jobs.JobOverrides.resources:type_name -> jobs.Resources
-	1,  // 5: jobs.ScheduleItem.resources:type_name -> jobs.Resources
-	11, // 6: jobs.ListSchedulesResponse.items:type_name -> jobs.ScheduleItem
-	2,  // 7: jobs.JobsService.RunJob:input_type -> jobs.RunJobRequest
-	6,  // 8: jobs.JobsService.DeleteJob:input_type -> jobs.DeleteJobRequest
-	8,  // 9: jobs.JobsService.UpdateSchedule:input_type -> jobs.UpdateScheduleRequest
-	10, // 10: jobs.JobsService.ListSchedules:input_type -> jobs.ListSchedulesRequest
-	5,  // 11: jobs.JobsService.RunJob:output_type -> jobs.RunJobResponse
-	7,  // 12: jobs.JobsService.DeleteJob:output_type -> jobs.DeleteJobResponse
-	9,  // 13: jobs.JobsService.UpdateSchedule:output_type -> jobs.UpdateScheduleResponse
-	12, // 14: jobs.JobsService.ListSchedules:output_type -> jobs.ListSchedulesResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	10, // 2: jobs.RunJobRequest.overrides:type_name -> jobs.JobOverrides
+	60, // 3: jobs.RunJobRequest.labels:type_name -> jobs.RunJobRequest.LabelsEntry
+	4,  // 4: jobs.RunJobRequest.security:type_name -> jobs.Security
+	3,  // 5: jobs.RunJobsRequest.jobs:type_name -> jobs.RunJobRequest
+	6,  // 6: jobs.RunJobsResponse.results:type_name -> jobs.RunJobsResult
+	12, // 7: jobs.JobOverrides.env:type_name -> jobs.EnvVar
+	2,  // 8: jobs.JobOverrides.resources:type_name -> jobs.Resources
+	11, // 9: jobs.JobOverrides.volumes:type_name -> jobs.VolumeMount
+	2,  // 10: jobs.ScheduleItem.resources:type_name -> jobs.Resources
+	61, // 11: jobs.ScheduleItem.labels:type_name -> jobs.ScheduleItem.LabelsEntry
+	25, // 12: jobs.ListSchedulesResponse.items:type_name -> jobs.ScheduleItem
+	2,  // 13: jobs.GetEffectiveJobConfigResponse.resources:type_name -> jobs.Resources
+	12, // 14: jobs.GetEffectiveJobConfigResponse.env:type_name -> jobs.EnvVar
+	27, // 15: jobs.GetEffectiveJobConfigResponse.retry:type_name -> jobs.RetryPolicy
+	1,  // 16: jobs.GetJobStatusResponse.state:type_name -> jobs.JobState
+	1,  // 17: jobs.JobInfo.state:type_name -> jobs.JobState
+	35, // 18: jobs.ListJobsResponse.items:type_name -> jobs.JobInfo
+	62, // 19: jobs.RunNamedJobRequest.params:type_name -> jobs.RunNamedJobRequest.ParamsEntry
+	40, // 20: jobs.ReconcileSchedulesResponse.drifts:type_name -> jobs.ScheduleDrift
+	63, // 21: jobs.ListExecutionsRequest.labels:type_name -> jobs.ListExecutionsRequest.LabelsEntry
+	64, // 22: jobs.ExecutionItem.labels:type_name -> jobs.ExecutionItem.LabelsEntry
+	65, // 23: jobs.ExecutionItem.metrics:type_name -> jobs.ExecutionItem.MetricsEntry
+	43, // 24: jobs.ListExecutionsResponse.items:type_name -> jobs.ExecutionItem
+	66, // 25: jobs.CostReportRequest.labels:type_name -> jobs.CostReportRequest.LabelsEntry
+	67, // 26: jobs.ExportExecutionsRequest.labels:type_name -> jobs.ExportExecutionsRequest.LabelsEntry
+	53, // 27: jobs.GetStatsResponse.runners:type_name -> jobs.RunnerStats
+	58, // 28: jobs.ListAuditEventsResponse.items:type_name -> jobs.AuditEvent
+	3,  // 29: jobs.JobsService.RunJob:input_type -> jobs.RunJobRequest
+	5,  // 30: jobs.JobsService.RunJobs:input_type -> jobs.RunJobsRequest
+	8,  // 31: jobs.JobsService.GetBatchStatus:input_type -> jobs.GetBatchStatusRequest
+	14, // 32: jobs.JobsService.DeleteJob:input_type -> jobs.DeleteJobRequest
+	16, // 33: jobs.JobsService.CancelJob:input_type -> jobs.CancelJobRequest
+	18, // 34: jobs.JobsService.UpdateSchedule:input_type -> jobs.UpdateScheduleRequest
+	20, // 35: jobs.JobsService.PauseSchedule:input_type -> jobs.PauseScheduleRequest
+	22, // 36: jobs.JobsService.ResumeSchedule:input_type -> jobs.ResumeScheduleRequest
+	24, // 37: jobs.JobsService.ListSchedules:input_type -> jobs.ListSchedulesRequest
+	28, // 38: jobs.JobsService.GetEffectiveJobConfig:input_type -> jobs.GetEffectiveJobConfigRequest
+	30, // 39: jobs.JobsService.GetLogs:input_type -> jobs.GetLogsRequest
+	32, // 40: jobs.JobsService.GetJobStatus:input_type -> jobs.GetJobStatusRequest
+	34, // 41: jobs.JobsService.ListJobs:input_type -> jobs.ListJobsRequest
+	3,  // 42: jobs.JobsService.RenderCommand:input_type -> jobs.RunJobRequest
+	42, // 43: jobs.JobsService.ListExecutions:input_type -> jobs.ListExecutionsRequest
+	45, // 44: jobs.JobsService.GetExecution:input_type -> jobs.GetExecutionRequest
+	46, // 45: jobs.JobsService.CostReport:input_type -> jobs.CostReportRequest
+	38, // 46: jobs.JobsService.RunNamedJob:input_type -> jobs.RunNamedJobRequest
+	39, // 47: jobs.JobsService.ReconcileSchedules:input_type -> jobs.ReconcileSchedulesRequest
+	52, // 48: jobs.JobsService.GetStats:input_type -> jobs.GetStatsRequest
+	55, // 49: jobs.JobsService.SetMaintenanceMode:input_type -> jobs.SetMaintenanceModeRequest
+	57, // 50: jobs.JobsService.ListAuditEvents:input_type -> jobs.ListAuditEventsRequest
+	48, // 51: jobs.JobsService.ExportExecutions:input_type -> jobs.ExportExecutionsRequest
+	50, // 52: jobs.JobsService.StreamLogs:input_type -> jobs.StreamLogsRequest
+	13, // 53: jobs.JobsService.RunJob:output_type -> jobs.RunJobResponse
+	7,  // 54: jobs.JobsService.RunJobs:output_type -> jobs.RunJobsResponse
+	9,  // 55: jobs.JobsService.GetBatchStatus:output_type -> jobs.GetBatchStatusResponse
+	15, // 56: jobs.JobsService.DeleteJob:output_type -> jobs.DeleteJobResponse
+	17, // 57: jobs.JobsService.CancelJob:output_type -> jobs.CancelJobResponse
+	19, // 58: jobs.JobsService.UpdateSchedule:output_type -> jobs.UpdateScheduleResponse
+	21, // 59: jobs.JobsService.PauseSchedule:output_type -> jobs.PauseScheduleResponse
+	23, // 60: jobs.JobsService.ResumeSchedule:output_type -> jobs.ResumeScheduleResponse
+	26, // 61: jobs.JobsService.ListSchedules:output_type -> jobs.ListSchedulesResponse
+	29, // 62: jobs.JobsService.GetEffectiveJobConfig:output_type -> jobs.GetEffectiveJobConfigResponse
+	31, // 63: jobs.JobsService.GetLogs:output_type -> jobs.GetLogsResponse
+	33, // 64: jobs.JobsService.GetJobStatus:output_type -> jobs.GetJobStatusResponse
+	36, // 65: jobs.JobsService.ListJobs:output_type -> jobs.ListJobsResponse
+	37, // 66: jobs.JobsService.RenderCommand:output_type -> jobs.RenderCommandResponse
+	44, // 67: jobs.JobsService.ListExecutions:output_type -> jobs.ListExecutionsResponse
+	43, // 68: jobs.JobsService.GetExecution:output_type -> jobs.ExecutionItem
+	47, // 69: jobs.JobsService.CostReport:output_type -> jobs.CostReportResponse
+	13, // 70: jobs.JobsService.RunNamedJob:output_type -> jobs.RunJobResponse
+	41, // 71: jobs.JobsService.ReconcileSchedules:output_type -> jobs.ReconcileSchedulesResponse
+	54, // 72: jobs.JobsService.GetStats:output_type -> jobs.GetStatsResponse
+	56, // 73: jobs.JobsService.SetMaintenanceMode:output_type -> jobs.SetMaintenanceModeResponse
+	59, // 74: jobs.JobsService.ListAuditEvents:output_type -> jobs.ListAuditEventsResponse
+	49, // 75: jobs.JobsService.ExportExecutions:output_type -> jobs.ExportExecutionsChunk
+	51, // 76: jobs.JobsService.StreamLogs:output_type -> jobs.LogChunk
+	53, // [53:77] is the sub-list for method output_type
+	29, // [29:53] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_jobs_proto_init() }
@@ -830,13 +4284,15 @@ func file_jobs_proto_init() {
 	if File_jobs_proto != nil {
 		return
 	}
+	file_jobs_proto_msgTypes[1].OneofWrappers = []any{}
+	file_jobs_proto_msgTypes[28].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jobs_proto_rawDesc), len(file_jobs_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   66,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,78 @@
+package runner
+
+import (
+	"context"
+
+	batch "cloud.google.com/go/batch/apiv1"
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BatchClient is the subset of the Cloud Batch API BatchRunner relies on. It
+// exists so tests can substitute a fake for the real client.
+type BatchClient interface {
+	CreateJob(ctx context.Context, req *batchpb.CreateJobRequest) (*batchpb.Job, error)
+	GetJob(ctx context.Context, name string) (*batchpb.Job, error)
+	// DeleteJob deletes a job and waits for the deletion to finish. Deleting a
+	// job that does not exist is not an error.
+	DeleteJob(ctx context.Context, name string) error
+	// ListJobs returns every job under parent.
+	ListJobs(ctx context.Context, parent string) ([]*batchpb.Job, error)
+	Close() error
+}
+
+type gcpBatchClient struct {
+	client *batch.Client
+}
+
+func (g *gcpBatchClient) CreateJob(ctx context.Context, req *batchpb.CreateJobRequest) (*batchpb.Job, error) {
+	return g.client.CreateJob(ctx, req)
+}
+
+func (g *gcpBatchClient) GetJob(ctx context.Context, name string) (*batchpb.Job, error) {
+	return g.client.GetJob(ctx, &batchpb.GetJobRequest{Name: name})
+}
+
+func (g *gcpBatchClient) DeleteJob(ctx context.Context, name string) error {
+	op, err := g.client.DeleteJob(ctx, &batchpb.DeleteJobRequest{Name: name})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return err
+	}
+	return op.Wait(ctx)
+}
+
+func (g *gcpBatchClient) ListJobs(ctx context.Context, parent string) ([]*batchpb.Job, error) {
+	var jobs []*batchpb.Job
+	it := g.client.ListJobs(ctx, &batchpb.ListJobsRequest{Parent: parent})
+	for {
+		job, err := it.Next()
+		if err == iterator.Done {
+			return jobs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+}
+
+func (g *gcpBatchClient) Close() error {
+	return g.client.Close()
+}
+
+// client returns the Batch client to use, honouring the NewClient override.
+func (b *BatchRunner) client(ctx context.Context) (BatchClient, error) {
+	if b.NewClient != nil {
+		return b.NewClient(ctx)
+	}
+	c, err := batch.NewClient(ctx, b.ClientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &gcpBatchClient{client: c}, nil
+}
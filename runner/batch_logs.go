@@ -0,0 +1,137 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/logging/logadmin"
+	"google.golang.org/api/iterator"
+)
+
+// LogClient is the subset of the Cloud Logging API BatchRunner reads job
+// output with. It exists so tests can substitute a fake for the real client.
+type LogClient interface {
+	// Entries returns the text of the entries matching filter, oldest first
+	// or, with newestFirst, newest first. limit > 0 stops after that many.
+	Entries(ctx context.Context, filter string, newestFirst bool, limit int) ([]string, error)
+	Close() error
+}
+
+// logPageSize is how many entries each Cloud Logging request fetches.
+const logPageSize = 1000
+
+type gcpLogClient struct {
+	client *logadmin.Client
+}
+
+func (g *gcpLogClient) Entries(ctx context.Context, filter string, newestFirst bool, limit int) ([]string, error) {
+	opts := []logadmin.EntriesOption{logadmin.Filter(filter)}
+	if newestFirst {
+		opts = append(opts, logadmin.NewestFirst())
+	}
+	it := g.client.Entries(ctx, opts...)
+	it.PageInfo().MaxSize = logPageSize
+	if limit > 0 {
+		it.PageInfo().MaxSize = min(limit, logPageSize)
+	}
+	var lines []string
+	for limit <= 0 || len(lines) < limit {
+		// the iterator requests the next page as the current one runs out
+		entry, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, fmt.Sprint(entry.Payload))
+	}
+	return lines, nil
+}
+
+func (g *gcpLogClient) Close() error {
+	return g.client.Close()
+}
+
+// logClient returns the Cloud Logging client to use, honouring the
+// NewLogClient override.
+func (b *BatchRunner) logClient(ctx context.Context) (LogClient, error) {
+	if b.NewLogClient != nil {
+		return b.NewLogClient(ctx)
+	}
+	c, err := logadmin.NewClient(ctx, b.ProjectID, b.ClientOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return &gcpLogClient{client: c}, nil
+}
+
+// batchLogFilter selects the Cloud Logging entries Batch writes for a job,
+// optionally narrowed to one task. Batch labels each entry with the job UID
+// and a task ID of the form "task/<uid>-group0-<index>/<retry>/<attempt>".
+func (b *BatchRunner) batchLogFilter(jobUID string, since time.Time, taskIndex int32) string {
+	filter := fmt.Sprintf(`logName="projects/%s/logs/batch_task_logs" AND labels.job_uid="%s" AND timestamp>="%s"`,
+		b.ProjectID, jobUID, since.UTC().Format(time.RFC3339))
+	if taskIndex != AllTasks {
+		filter += fmt.Sprintf(` AND labels.task_id:"-group0-%d/"`, taskIndex)
+	}
+	return filter
+}
+
+// ReadLogs fetches a Batch job's task output from Cloud Logging, where the
+// job's LogsPolicy sends it. maxLines > 0 keeps only the last maxLines lines.
+// Cloud Logging may lag a little behind the job.
+func (b *BatchRunner) ReadLogs(ctx context.Context, name string, taskIndex int32, maxLines int) (string, error) {
+	client, err := b.client(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	if !strings.HasPrefix(name, "projects/") {
+		name = b.jobName(name)
+	}
+	job, err := client.GetJob(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	logs, err := b.logClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer logs.Close()
+
+	since := job.GetCreateTime().AsTime()
+	// the last lines are read newest first, so only they are fetched
+	newestFirst := maxLines > 0
+	lines, err := logs.Entries(ctx, b.batchLogFilter(job.GetUid(), since, taskIndex), newestFirst, maxLines)
+	if err != nil {
+		return "", err
+	}
+	if newestFirst {
+		slices.Reverse(lines)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// waitLogLines caps the output a waited run's result carries.
+const waitLogLines = 1000
+
+// withLogs appends the last lines of the Batch job name's output to the
+// summary of a waited run. Output that cannot be read is left out.
+func (b *BatchRunner) withLogs(ctx context.Context, name, summary string) string {
+	logs, err := b.ReadLogs(ctx, name, AllTasks, waitLogLines)
+	if err != nil {
+		log.Printf("failed to read the logs of %s: %v", name, err)
+		return summary
+	}
+	if logs == "" {
+		return summary
+	}
+	return summary + "\n\n" + logs
+}
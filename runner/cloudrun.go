@@ -2,11 +2,20 @@ package runner
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
-	batch "cloud.google.com/go/batch/apiv1"
 	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
 	scheduler "cloud.google.com/go/scheduler/apiv1"
 	spb "cloud.google.com/go/scheduler/apiv1/schedulerpb"
@@ -14,6 +23,7 @@ import (
 	"google.golang.org/api/option"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -23,15 +33,95 @@ type BatchRunner struct {
 	Image     string
 	// Optional additional client options (e.g., custom credentials)
 	ClientOptions []option.ClientOption
+	// Optional Batch client factory, used instead of ClientOptions (e.g., fakes in tests)
+	NewClient func(ctx context.Context) (BatchClient, error)
+	// Optional Cloud Logging client factory, used instead of ClientOptions
+	NewLogClient func(ctx context.Context) (LogClient, error)
 	// Optional service account email for Cloud Scheduler HTTP OAuth
 	ServiceAccountEmail string
 	Secrets             []models.Secret
+	// SecretRefs maps env var names to the Secret Manager secret versions
+	// Batch injects them from, e.g. projects/p/secrets/db-password/versions/latest,
+	// so their values stay out of the job's metadata and logs. They replace
+	// the plaintext Secrets of the same name.
+	SecretRefs map[string]string
 	// Storage configuration
 	PersistentDiskName string
 	PersistentDiskSize int64
 	PersistentDiskType string
+	// Quota handling: how many times a submission rejected for quota is retried
+	// under a fresh job ID, and how long to wait between attempts
+	QuotaRetries    int
+	QuotaRetryDelay time.Duration
+	// Network tags applied to the job's VMs so firewall rules can target them
+	NetworkTags []string
+	// Zones of Region the job's VMs may be placed in, e.g. "us-central1-a",
+	// to steer jobs to zones with capacity for their machine type or GPUs
+	// (default: any zone Batch picks)
+	Zones []string
+	// Prices used to estimate the cost of finished jobs
+	Prices []MachinePrice
+	// TriggerURL, when set, is the base URL of Apollo's HTTP endpoint. Cloud
+	// Scheduler then calls Apollo to start scheduled runs, so they are
+	// recorded like any other run, instead of calling the Batch API directly.
+	TriggerURL string
+	// RegistryClient is used by CheckImage to query the image's registry
+	// (default: http.DefaultClient)
+	RegistryClient *http.Client
+	// MaxOutstandingJobs is a soft cap on submitted jobs that have not
+	// finished yet, kept under the project's Batch quota. Submissions beyond
+	// it wait, polling the outstanding jobs every OutstandingPollInterval,
+	// instead of being rejected by Batch (default: no cap). With a
+	// SubmittedLister the jobs the store records as submitted count too.
+	MaxOutstandingJobs      int
+	OutstandingPollInterval time.Duration
+	// MachineType is the Compute Engine machine type jobs run on unless the
+	// request names one (default: the smallest that fits the job's resources)
+	MachineType string
+	// Security hardens the containers of jobs whose request sets none,
+	// through the docker options Batch runs them with
+	Security *Security
+	// StopSignal is set as the stop signal of the containers of jobs whose
+	// request names none (default: SIGTERM). Batch only sends it if it stops
+	// a container itself; deleting a job tears down its VMs without one.
+	StopSignal string
+	// WaitPollInterval is how often RunJob polls a job it waits for
+	// (default: 10s)
+	WaitPollInterval time.Duration
+
+	quotaMu       sync.Mutex
+	outstanding   map[string]struct{} // jobs this process submitted
+	reserved      int
+	listSubmitted func(ctx context.Context, prefix string) ([]string, error)
+	finished      map[string]struct{} // listed jobs seen to finish
+
+	runsMu sync.Mutex
+	runs   map[string]batchRun
+
+	secretsMu sync.RWMutex // guards Secrets once jobs run
 }
 
+// SetSecrets replaces the secrets later jobs are given.
+func (b *BatchRunner) SetSecrets(secrets []models.Secret) {
+	b.secretsMu.Lock()
+	defer b.secretsMu.Unlock()
+	b.Secrets = secrets
+}
+
+func (b *BatchRunner) secrets() []models.Secret {
+	b.secretsMu.RLock()
+	defer b.secretsMu.RUnlock()
+	return b.Secrets
+}
+
+// Task limits used when a request sets none
+const (
+	defaultMaxRunDuration = 24 * time.Hour
+	defaultMaxRetryCount  = 3
+)
+
+const defaultWaitPollInterval = 10 * time.Second
+
 func NewBatchRunner(projectID, region, image string, secrets []models.Secret) *BatchRunner {
 	return &BatchRunner{
 		ProjectID:          projectID,
@@ -40,6 +130,11 @@ func NewBatchRunner(projectID, region, image string, secrets []models.Secret) *B
 		Secrets:            secrets,
 		PersistentDiskSize: 64,            // Default 64GB
 		PersistentDiskType: "pd-balanced", // Default balanced disk
+		QuotaRetries:       3,
+		QuotaRetryDelay:    10 * time.Second,
+
+		OutstandingPollInterval: 30 * time.Second,
+		WaitPollInterval:        defaultWaitPollInterval,
 	}
 }
 
@@ -51,41 +146,212 @@ func (b *BatchRunner) parent() string {
 	return fmt.Sprintf("projects/%s/locations/%s", b.ProjectID, b.Region)
 }
 
+func (b *BatchRunner) Location() (string, string) { return "cloudrun", b.Region }
+
+// Submits reports that RunJob returns once the Batch job is created.
+func (b *BatchRunner) Submits() bool { return true }
+
+// RunJob submits req as a Batch job and returns the job's name once it is
+// created. With req.Wait it returns once the job has finished instead, with
+// the job's name, final state and task counts on separate lines followed by
+// the tail of its output; a failed job returns them with an error.
 func (b *BatchRunner) RunJob(ctx context.Context, cmd string, req JobRequest) (string, error) {
-	client, err := batch.NewClient(ctx, b.ClientOptions...)
+	if req.DryRun {
+		return b.RenderCommand(ctx, cmd, req)
+	}
+	job, err := b.buildJob(cmd, req)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := b.client(ctx)
 	if err != nil {
 		return "", err
 	}
 	defer client.Close()
 
+	// each run needs its own Batch job ID; scheduled runs share a name
+	jobID := req.JobID
+	if jobID == "" {
+		jobID = req.Name
+	}
+	if err := b.waitForSlot(ctx, client); err != nil {
+		return "", err
+	}
+	name, err := b.createJob(ctx, client, jobID, job)
+	b.submitted(name, err)
+	if err != nil {
+		return name, err
+	}
+	b.track(name, req.Name, jobID)
+	if !req.Wait {
+		return name, nil
+	}
+	return b.wait(ctx, client, name)
+}
+
+// RenderCommand returns the Batch job RunJob would submit for req as JSON.
+// Plaintext secret values are replaced by a placeholder so their presence is
+// visible without leaking them.
+func (b *BatchRunner) RenderCommand(ctx context.Context, cmd string, req JobRequest) (string, error) {
+	// redact the secrets from before and after building, in case they were
+	// swapped in between
+	secrets := b.secrets()
+	job, err := b.buildJob(cmd, req)
+	if err != nil {
+		return "", err
+	}
+	secrets = append(slices.Clip(secrets), b.secrets()...)
+	for _, group := range job.GetTaskGroups() {
+		for _, runnable := range group.GetTaskSpec().GetRunnables() {
+			vars := runnable.GetEnvironment().GetVariables()
+			for _, secret := range secrets {
+				if v, ok := vars[secret.SecretKey]; ok && v == secret.SecretValue {
+					vars[secret.SecretKey] = "<redacted>"
+				}
+			}
+		}
+	}
+	out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// wait polls the Batch job name until it finishes and summarizes how it
+// ended. Cancelling ctx stops the wait with ErrStoppedWaiting but leaves the
+// job running.
+func (b *BatchRunner) wait(ctx context.Context, client BatchClient, name string) (string, error) {
+	interval := b.WaitPollInterval
+	if interval <= 0 {
+		interval = defaultWaitPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := client.GetJob(ctx, name)
+		if err != nil && ctx.Err() != nil {
+			return name, fmt.Errorf("wait for %s: %w: %w", name, ErrStoppedWaiting, ctx.Err())
+		}
+		if err != nil {
+			return name, fmt.Errorf("wait for %s: %w", name, err)
+		}
+		if state := batchState(job.GetStatus().GetState()); state.Terminal() {
+			b.forget(name)
+			summary := b.withLogs(ctx, name, name+"\nstate: "+job.GetStatus().GetState().String()+"\ntasks: "+taskCounts(job))
+			if state == JobStateFailed {
+				return summary, fmt.Errorf("batch job %s failed", name)
+			}
+			return summary, nil
+		}
+		select {
+		case <-ctx.Done():
+			return name, fmt.Errorf("wait for %s: %w: %w", name, ErrStoppedWaiting, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// taskCounts lists how many of job's tasks ended in each state, e.g.
+// "FAILED=1 SUCCEEDED=3", summed over its task groups.
+func taskCounts(job *batchpb.Job) string {
+	counts := map[string]int64{}
+	for _, group := range job.GetStatus().GetTaskGroups() {
+		for state, n := range group.GetCounts() {
+			counts[state] += n
+		}
+	}
+	out := make([]string, 0, len(counts))
+	for _, state := range slices.Sorted(maps.Keys(counts)) {
+		out = append(out, fmt.Sprintf("%s=%d", state, counts[state]))
+	}
+	return strings.Join(out, " ")
+}
+
+// buildJob builds the Batch job that runs req, shared by RunJob and the
+// schedules ScheduleJob creates so both run the same spec.
+func (b *BatchRunner) buildJob(cmd string, req JobRequest) (*batchpb.Job, error) {
+	if err := validateNetworkTags(b.NetworkTags); err != nil {
+		return nil, err
+	}
+	allowedLocations, err := zoneLocations(b.Region, b.Zones)
+	if err != nil {
+		return nil, err
+	}
+	if req.HealthCheck != nil {
+		return nil, status.Error(codes.FailedPrecondition, "health checks are not supported by the Batch runner")
+	}
+
 	// Build environment variables as a map[string]string
 	envMap := make(map[string]string)
-	// Add Infisical secrets
-	for _, secret := range b.Secrets {
-		envMap[secret.SecretKey] = secret.SecretValue
+	// Add Infisical secrets, except those Batch reads from Secret Manager
+	selected := selectSecrets(req, b.secrets())
+	names := make([]string, 0, len(selected)+len(b.SecretRefs))
+	for _, secret := range selected {
+		names = append(names, secret.SecretKey)
+	}
+	for name := range b.SecretRefs {
+		if selectsSecret(req, selected, name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if err := checkEnv(req, names); err != nil {
+		return nil, err
+	}
+	for _, secret := range selected {
+		if _, ok := b.SecretRefs[secret.SecretKey]; !ok {
+			envMap[secret.SecretKey] = secret.SecretValue
+		}
+	}
+	secretVars := make(map[string]string, len(b.SecretRefs))
+	for name, ref := range b.SecretRefs {
+		if !selectsSecret(req, selected, name) {
+			continue
+		}
+		if !secretVersionPattern.MatchString(ref) {
+			return nil, status.Errorf(codes.InvalidArgument, "secret %s: invalid Secret Manager reference %q, want projects/*/secrets/*/versions/*", name, ref)
+		}
+		secretVars[name] = ref
 	}
 	// Add client-provided environment variables
 	if req.Overrides != nil && len(req.Overrides.Env) > 0 {
 		for _, envVar := range req.Overrides.Env {
 			envMap[envVar.Name] = envVar.Value
+			delete(secretVars, envVar.Name)
 		}
 	}
 
-	// Define the runnable (script or container)
-	var containerArgs []string
-	if req.Overrides != nil && len(req.Overrides.Args) > 0 {
-		containerArgs = req.Overrides.Args
+	security, err := securityFor(req, b.Security).dockerArgs()
+	if err != nil {
+		return nil, err
 	}
+	signal, err := stopSignalFor(req, b.StopSignal)
+	if err != nil {
+		return nil, err
+	}
+	options := security
+	if signal != "" {
+		options = append(options, "--stop-signal", signal)
+	}
+	volumeMounts, err := volumeSpecs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Define the runnable (script or container)
 	runnable := &batchpb.Runnable{
 		Executable: &batchpb.Runnable_Container_{
 			Container: &batchpb.Runnable_Container{
-				ImageUri: b.Image,
-				Commands: []string{cmd},
-				Options:  strings.Join(containerArgs, " "),
+				ImageUri: imageOf(req, b.Image),
+				Commands: containerCommands(cmd, req),
+				Options:  strings.Join(options, " "),
+				Volumes:  volumeMounts,
 			},
 		},
 		Environment: &batchpb.Environment{
-			Variables: envMap,
+			Variables:       envMap,
+			SecretVariables: secretVars,
 		},
 	}
 
@@ -117,14 +383,43 @@ func (b *BatchRunner) RunJob(ctx context.Context, cmd string, req JobRequest) (s
 		attachedDisks = append(attachedDisks, attachedDisk)
 	}
 
+	// Resolve resources, which may be a percentage of the machine
+	machineType, err := b.machineTypeFor(req)
+	if err != nil {
+		return nil, err
+	}
+	cpuMilli, err := resolveCPU(req.Resources.CPU, machineType)
+	if err != nil {
+		return nil, err
+	}
+	memoryMib, err := resolveMemory(req.Resources.Memory, machineType)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGPU(req.Resources, machineType); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// tell the job what it got; set last so overrides cannot misreport it
+	envMap[cpuLimitEnv] = formatCPULimit(cpuMilli)
+	envMap[memoryLimitEnv] = formatMemoryLimit(memoryMib)
+
+	maxRunDuration := defaultMaxRunDuration
+	if req.Timeout > 0 {
+		maxRunDuration = req.Timeout
+	}
+	maxRetryCount := int32(defaultMaxRetryCount)
+	if req.MaxRetries != nil {
+		maxRetryCount = *req.MaxRetries
+	}
+
 	// Define task specification
 	taskSpec := &batchpb.TaskSpec{
 		ComputeResource: &batchpb.ComputeResource{
-			CpuMilli:  parseCPU(req.Resources.CPU),
-			MemoryMib: parseMemory(req.Resources.Memory),
+			CpuMilli:  cpuMilli,
+			MemoryMib: memoryMib,
 		},
-		MaxRunDuration: &durationpb.Duration{Seconds: 24 * 60 * 60}, // 24 hours
-		MaxRetryCount:  3,
+		MaxRunDuration: durationpb.New(maxRunDuration),
+		MaxRetryCount:  maxRetryCount,
 		Runnables:      []*batchpb.Runnable{runnable},
 		Volumes:        volumes,
 	}
@@ -142,19 +437,29 @@ func (b *BatchRunner) RunJob(ctx context.Context, cmd string, req JobRequest) (s
 
 	// Define allocation policy
 	instancePolicy := &batchpb.AllocationPolicy_InstancePolicy{
-		MachineType: "n1-standard-1", // Default machine type
+		MachineType: machineType,
 		Disks:       attachedDisks,
 	}
+	if req.Resources.GPUCount > 0 {
+		instancePolicy.Accelerators = []*batchpb.AllocationPolicy_Accelerator{{
+			Type:  req.Resources.GPUType,
+			Count: int64(req.Resources.GPUCount),
+		}}
+	}
 
 	allocationPolicy := &batchpb.AllocationPolicy{
 		Instances: []*batchpb.AllocationPolicy_InstancePolicyOrTemplate{{
 			PolicyTemplate: &batchpb.AllocationPolicy_InstancePolicyOrTemplate_Policy{
 				Policy: instancePolicy,
 			},
+			InstallGpuDrivers: req.Resources.GPUCount > 0,
 		}},
+		Tags: b.NetworkTags,
+	}
+	if len(allowedLocations) > 0 {
+		allocationPolicy.Location = &batchpb.AllocationPolicy_LocationPolicy{AllowedLocations: allowedLocations}
 	}
 
-	// Create and submit the job
 	job := &batchpb.Job{
 		TaskGroups:       []*batchpb.TaskGroup{taskGroup},
 		AllocationPolicy: allocationPolicy,
@@ -163,42 +468,137 @@ func (b *BatchRunner) RunJob(ctx context.Context, cmd string, req JobRequest) (s
 			Destination: batchpb.LogsPolicy_CLOUD_LOGGING,
 		},
 	}
+	if labelValuePattern.MatchString(req.Name) {
+		job.Labels[jobNameLabel] = req.Name
+	}
+	return job, nil
+}
 
-	createReq := &batchpb.CreateJobRequest{
-		Parent: b.parent(),
-		JobId:  req.Name,
-		Job:    job,
+// containerCommands builds the container argv the same way LocalRunner does:
+// each argument stays a distinct token, so values containing spaces or quotes
+// reach the job unchanged without any shell quoting.
+func containerCommands(cmd string, req JobRequest) []string {
+	if cmd == "" && req.Command == "" {
+		// a schedule updated by name alone runs the image's entrypoint
+		return nil
+	}
+	commands := []string{cmd, req.Command}
+	if req.ArgsJSONBase64 != "" {
+		commands = append(commands, req.ArgsJSONBase64)
 	}
+	if req.Overrides != nil && len(req.Overrides.Args) > 0 {
+		commands = append(commands, req.Overrides.Args...)
+	}
+	return commands
+}
 
-	op, err := client.CreateJob(ctx, createReq)
-	if err != nil {
-		return "", err
+// createJob submits job under jobID. When Batch rejects the submission for
+// quota, the first attempt may still have partially created the job, so a
+// retry with the same ID could fail with AlreadyExists. Each retry therefore
+// cleans up the previous ID and resubmits under a freshly generated one. The
+// returned name is that of the job that was finally created.
+func (b *BatchRunner) createJob(ctx context.Context, client BatchClient, jobID string, job *batchpb.Job) (string, error) {
+	for attempt := 0; ; attempt++ {
+		created, err := client.CreateJob(ctx, &batchpb.CreateJobRequest{
+			Parent: b.parent(),
+			JobId:  jobID,
+			Job:    job,
+		})
+		if err == nil {
+			if attempt > 0 {
+				log.Printf("batch job submitted as %s after %d quota retries", jobID, attempt)
+			}
+			return created.GetName(), nil
+		}
+
+		code := status.Code(err)
+		// AlreadyExists on the first attempt is a genuine name clash, not a
+		// leftover from an earlier quota failure
+		retryable := code == codes.ResourceExhausted || (code == codes.AlreadyExists && attempt > 0)
+		if !retryable || attempt >= b.QuotaRetries {
+			return "", err
+		}
+
+		if _, getErr := client.GetJob(ctx, b.jobName(jobID)); getErr == nil {
+			log.Printf("cleaning up partially created batch job %s", jobID)
+			if delErr := client.DeleteJob(ctx, b.jobName(jobID)); delErr != nil {
+				log.Printf("failed to clean up batch job %s: %v", jobID, delErr)
+			}
+		}
+
+		next := freshJobID(jobID)
+		log.Printf("batch quota exhausted for %s, retrying as %s: %v", jobID, next, err)
+		jobID = next
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(b.QuotaRetryDelay):
+		}
 	}
+}
 
-	return op.GetName(), nil
+// freshJobID derives a new unique Batch job ID from base. IDs must be at most
+// 63 characters, so the base is trimmed to leave room for the suffix.
+func freshJobID(base string) string {
+	if i := strings.LastIndex(base, "-r"); i > 0 && len(base)-i == 8 {
+		base = base[:i]
+	}
+	if len(base) > 55 {
+		base = strings.TrimRight(base[:55], "-")
+	}
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-r%s", base, hex.EncodeToString(suffix))
 }
 
-func (b *BatchRunner) DeleteJob(ctx context.Context, name string) error {
-	client, err := batch.NewClient(ctx, b.ClientOptions...)
+// UpdateSchedule points the Cloud Scheduler job name at spec. Without the
+// job's request it submits the image with default resources; the server uses
+// ScheduleJob when it knows the request.
+func (b *BatchRunner) UpdateSchedule(ctx context.Context, name string, spec string) error {
+	return b.ScheduleJob(ctx, "", JobRequest{Name: name, ScheduleSpec: spec})
+}
+
+// ScheduleBody returns the JSON Batch job a schedule of req submits. Cloud
+// Scheduler stores the body for anyone who can view the schedule to read, so
+// it may only name secrets Batch reads from Secret Manager when the job runs;
+// a job receiving other secrets fails with codes.FailedPrecondition and has
+// to be triggered through Apollo, which resolves them when it fires.
+func (b *BatchRunner) ScheduleBody(cmd string, req JobRequest) ([]byte, error) {
+	var plaintext []string
+	for _, secret := range selectSecrets(req, b.secrets()) {
+		if _, ok := b.SecretRefs[secret.SecretKey]; !ok {
+			plaintext = append(plaintext, secret.SecretKey)
+		}
+	}
+	if len(plaintext) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "schedule %s would store secrets %s in plaintext in Cloud Scheduler: give them a secret_manager_ref, or set SCHEDULER_TRIGGER_URL so Apollo resolves them when the schedule fires", req.Name, strings.Join(plaintext, ", "))
+	}
+	job, err := b.buildJob(cmd, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer client.Close()
+	return protojson.Marshal(job)
+}
 
-	op, err := client.DeleteJob(ctx, &batchpb.DeleteJobRequest{
-		Name: b.jobName(name),
-	})
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return nil
-		}
+// ScheduleJob creates or updates the Cloud Scheduler job req.Name so it
+// submits, on req.ScheduleSpec in req.TimeZone, the same Batch job RunJob
+// would submit for req, short of plaintext secrets (see ScheduleBody). Batch
+// generates each scheduled run's job ID. With TriggerURL set the schedule
+// carries no job at all, only its name.
+func (b *BatchRunner) ScheduleJob(ctx context.Context, cmd string, req JobRequest) error {
+	name, spec := req.Name, ZonedSpec(req.ScheduleSpec, req.TimeZone)
+	if _, err := MinuteCron(spec); err != nil {
 		return err
 	}
-	err = op.Wait(ctx)
-	return err
-}
+	var body []byte
+	if b.TriggerURL == "" {
+		var err error
+		if body, err = b.ScheduleBody(cmd, req); err != nil {
+			return err
+		}
+	}
 
-func (b *BatchRunner) UpdateSchedule(ctx context.Context, name string, spec string) error {
 	sched, err := scheduler.NewCloudSchedulerClient(ctx, b.ClientOptions...)
 	if err != nil {
 		return err
@@ -208,60 +608,34 @@ func (b *BatchRunner) UpdateSchedule(ctx context.Context, name string, spec stri
 	parent := fmt.Sprintf("projects/%s/locations/%s", b.ProjectID, b.Region)
 	jobName := fmt.Sprintf("%s/jobs/%s", parent, name)
 
-	// Convert cron spec to 5-field format
-	cronSpec := toFiveFieldCron(spec)
-
-	// Target: HTTP call to Batch API
-	url := fmt.Sprintf("https://batch.googleapis.com/v1/projects/%s/locations/%s/jobs", b.ProjectID, b.Region)
-
-	// Create the job configuration as JSON body
-	jobConfig := fmt.Sprintf(`{
-        "job_id": "%s",
-        "job": {
-            "task_groups": [
-                {
-                    "task_count": 1,
-                    "task_spec": {
-                        "runnables": [
-                            {
-                                "container": {
-                                    "image_uri": "%s"
-                                }
-                            }
-                        ]
-                    }
-                }
-            ],
-            "allocation_policy": {
-                "instances": [
-                    {
-                        "policy": {
-                            "machine_type": "n1-standard-1"
-                        }
-                    }
-                ]
-            }
-        }
-    }`, name, b.Image)
-
+	want := b.DesiredSchedule(name, spec)
 	httpTarget := &spb.HttpTarget{
 		HttpMethod: spb.HttpMethod_POST,
-		Uri:        url,
+		Uri:        want.Target,
 		AuthorizationHeader: &spb.HttpTarget_OidcToken{
 			OidcToken: &spb.OidcToken{
 				ServiceAccountEmail: b.ServiceAccountEmail,
 			},
 		},
-		Body: []byte(jobConfig),
+		Body: body,
 		Headers: map[string]string{
 			"Content-Type": "application/json",
 		},
 	}
+	if b.TriggerURL != "" {
+		// Apollo builds the job itself from the stored schedule
+		httpTarget.AuthorizationHeader = &spb.HttpTarget_OidcToken{
+			OidcToken: &spb.OidcToken{
+				ServiceAccountEmail: b.ServiceAccountEmail,
+				Audience:            b.TriggerURL,
+			},
+		}
+	}
 
 	desired := &spb.Job{
 		Name:        jobName,
-		Schedule:    cronSpec,
-		TimeZone:    "UTC",
+		Schedule:    want.Spec,
+		TimeZone:    want.TimeZone,
 		Target:      &spb.Job_HttpTarget{HttpTarget: httpTarget},
 		Description: "Run Batch Job",
 	}
@@ -280,6 +654,74 @@ func (b *BatchRunner) UpdateSchedule(ctx context.Context, name string, spec stri
 	return err
 }
 
+// DesiredSchedule returns the Cloud Scheduler schedule UpdateSchedule sets
+// for spec: a 5-field cron in the time zone of spec's CRON_TZ prefix, else
+// UTC, that posts to the Batch API, or to Apollo's trigger endpoint when
+// TriggerURL is set.
+func (b *BatchRunner) DesiredSchedule(name, spec string) ScheduleInfo {
+	target := fmt.Sprintf("https://batch.googleapis.com/v1/projects/%s/locations/%s/jobs", b.ProjectID, b.Region)
+	if b.TriggerURL != "" {
+		target = TriggerEndpoint(b.TriggerURL, name)
+	}
+	tz, spec := SplitTimeZone(spec)
+	if tz == "" {
+		tz = "UTC"
+	}
+	return ScheduleInfo{
+		Spec:     toFiveFieldCron(spec),
+		TimeZone: tz,
+		Target:   target,
+	}
+}
+
+// TriggerEndpoint is the URL under baseURL that starts a run of the named
+// schedule.
+func TriggerEndpoint(baseURL, name string) string {
+	return strings.TrimRight(baseURL, "/") + "/schedules/" + url.PathEscape(name) + "/trigger"
+}
+
+// GetSchedule reads the Cloud Scheduler job behind a schedule.
+func (b *BatchRunner) GetSchedule(ctx context.Context, name string) (ScheduleInfo, error) {
+	sched, err := scheduler.NewCloudSchedulerClient(ctx, b.ClientOptions...)
+	if err != nil {
+		return ScheduleInfo{}, err
+	}
+	defer sched.Close()
+
+	job, err := sched.GetJob(ctx, &spb.GetJobRequest{Name: fmt.Sprintf("%s/jobs/%s", b.parent(), name)})
+	if err != nil {
+		return ScheduleInfo{}, err
+	}
+	return ScheduleInfo{
+		Spec:     job.GetSchedule(),
+		TimeZone: job.GetTimeZone(),
+		Target:   job.GetHttpTarget().GetUri(),
+	}, nil
+}
+
+// PauseSchedule pauses the Cloud Scheduler job behind a schedule, keeping
+// its definition.
+func (b *BatchRunner) PauseSchedule(ctx context.Context, name string) error {
+	sched, err := scheduler.NewCloudSchedulerClient(ctx, b.ClientOptions...)
+	if err != nil {
+		return err
+	}
+	defer sched.Close()
+	_, err = sched.PauseJob(ctx, &spb.PauseJobRequest{Name: fmt.Sprintf("%s/jobs/%s", b.parent(), name)})
+	return err
+}
+
+// ResumeSchedule resumes a Cloud Scheduler job PauseSchedule paused.
+func (b *BatchRunner) ResumeSchedule(ctx context.Context, name string) error {
+	sched, err := scheduler.NewCloudSchedulerClient(ctx, b.ClientOptions...)
+	if err != nil {
+		return err
+	}
+	defer sched.Close()
+	_, err = sched.ResumeJob(ctx, &spb.ResumeJobRequest{Name: fmt.Sprintf("%s/jobs/%s", b.parent(), name)})
+	return err
+}
+
 // Helper functions
 func parseCPU(cpu string) int64 {
 	// Convert CPU string (e.g., "1000m" or "1") to milliseconds
@@ -313,10 +755,81 @@ func parseMemory(memory string) int64 {
 	return 512 // Default to 512 MiB
 }
 
+var secretVersionPattern = regexp.MustCompile(`^projects/[^/]+/secrets/[^/]+/versions/[^/]+$`)
+
+var networkTagPattern = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// validateNetworkTags enforces GCP's network tag format: 1-63 characters of
+// lowercase letters, digits and hyphens, starting with a letter and not
+// ending with a hyphen.
+func validateNetworkTags(tags []string) error {
+	for _, tag := range tags {
+		if !networkTagPattern.MatchString(tag) {
+			return fmt.Errorf("invalid network tag %q: must be 1-63 lowercase letters, digits or hyphens, start with a letter and not end with a hyphen", tag)
+		}
+	}
+	return nil
+}
+
+// zoneLocations converts zones to the "zones/<zone>" locations Batch
+// allows, rejecting any zone outside region.
+func zoneLocations(region string, zones []string) ([]string, error) {
+	var locations []string
+	for _, zone := range zones {
+		name := strings.TrimPrefix(zone, "zones/")
+		suffix, ok := strings.CutPrefix(name, region+"-")
+		if !ok || len(suffix) != 1 || suffix[0] < 'a' || suffix[0] > 'z' {
+			return nil, fmt.Errorf("invalid zone %q: must be a zone of region %s, e.g. %s-a", zone, region, region)
+		}
+		locations = append(locations, "zones/"+name)
+	}
+	return locations, nil
+}
+
+// toFiveFieldCron drops the seconds field of a 6-field spec, keeping any
+// CRON_TZ or TZ prefix out of the field count.
 func toFiveFieldCron(in string) string {
-	fields := strings.Fields(in)
+	tz, bare := SplitTimeZone(in)
+	fields := strings.Fields(bare)
 	if len(fields) == 6 {
-		return strings.Join(fields[1:], " ")
+		return ZonedSpec(strings.Join(fields[1:], " "), tz)
 	}
 	return in
 }
+
+// MinuteCron returns spec as the 5-field cron Cloud Scheduler runs. Cloud
+// Scheduler fires at most once a minute, so a 6-field spec converts only when
+// its seconds field is 0; anything finer, such as "*/10 * * * * *", is
+// rejected rather than silently run once a minute. "@every" specs, which Cloud
+// Scheduler has no form of, are rejected too. The local provider has neither
+// limit: its in-process scheduler resolves seconds and intervals. Any CRON_TZ
+// prefix is kept.
+func MinuteCron(spec string) (string, error) {
+	tz, spec := SplitTimeZone(spec)
+	if err := checkTimeZone(tz); err != nil {
+		return "", err
+	}
+	minute, err := minuteCron(spec)
+	if err != nil {
+		return "", err
+	}
+	return ZonedSpec(minute, tz), nil
+}
+
+func minuteCron(spec string) (string, error) {
+	if strings.HasPrefix(strings.TrimSpace(spec), "@every") {
+		return "", status.Errorf(codes.InvalidArgument, "schedule %q is an interval, which Cloud Scheduler does not run; use a cron expression such as \"*/5 * * * *\"", spec)
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 6 {
+		return spec, nil
+	}
+	if sec, err := strconv.Atoi(fields[0]); err != nil || sec != 0 {
+		return "", subMinuteError(spec)
+	}
+	return strings.Join(fields[1:], " "), nil
+}
+
+func subMinuteError(spec string) error {
+	return status.Errorf(codes.InvalidArgument, "schedule %q needs sub-minute resolution, but Cloud Scheduler runs schedules at most once a minute; use a 5-field cron or set the seconds field to 0", spec)
+}
@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+)
+
+// MachinePrice is the hourly price of a machine type. An empty Region applies
+// to every region without a more specific entry.
+type MachinePrice struct {
+	MachineType string
+	Region      string
+	Hourly      float64 // standard provisioning
+	SpotHourly  float64 // spot/preemptible provisioning; falls back to Hourly
+}
+
+// CostEstimator is implemented by runners that can price a job after it ran.
+// name is the value RunJob returned for the job.
+type CostEstimator interface {
+	// EstimateCost reports done=false while the job has not finished yet.
+	EstimateCost(ctx context.Context, name string) (cost float64, done bool, err error)
+}
+
+// price returns the hourly price of machineType in region for the given
+// provisioning model.
+func (b *BatchRunner) price(machineType string, spot bool) (float64, bool) {
+	var match *MachinePrice
+	for i, p := range b.Prices {
+		if p.MachineType != machineType {
+			continue
+		}
+		if p.Region == b.Region {
+			match = &b.Prices[i]
+			break
+		}
+		if p.Region == "" && match == nil {
+			match = &b.Prices[i]
+		}
+	}
+	if match == nil {
+		return 0, false
+	}
+	if spot && match.SpotHourly > 0 {
+		return match.SpotHourly, true
+	}
+	return match.Hourly, true
+}
+
+// EstimateCost prices a finished Batch job from its machine type,
+// provisioning model and run duration, assuming one VM per task.
+func (b *BatchRunner) EstimateCost(ctx context.Context, name string) (float64, bool, error) {
+	client, err := b.client(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer client.Close()
+
+	if !strings.HasPrefix(name, "projects/") {
+		name = b.jobName(name)
+	}
+	job, err := client.GetJob(ctx, name)
+	if err != nil {
+		return 0, false, err
+	}
+	switch job.GetStatus().GetState() {
+	case batchpb.JobStatus_SUCCEEDED, batchpb.JobStatus_FAILED:
+	default:
+		return 0, false, nil
+	}
+
+	machineType := defaultMachineType
+	spot := false
+	if instances := job.GetAllocationPolicy().GetInstances(); len(instances) > 0 {
+		policy := instances[0].GetPolicy()
+		if policy.GetMachineType() != "" {
+			machineType = policy.GetMachineType()
+		}
+		switch policy.GetProvisioningModel() {
+		case batchpb.AllocationPolicy_SPOT, batchpb.AllocationPolicy_PREEMPTIBLE:
+			spot = true
+		}
+	}
+	hourly, ok := b.price(machineType, spot)
+	if !ok {
+		return 0, true, fmt.Errorf("no price configured for machine type %s in %s", machineType, b.Region)
+	}
+
+	vms := int64(0)
+	for _, group := range job.GetTaskGroups() {
+		vms += max(group.GetTaskCount(), 1)
+	}
+	hours := job.GetStatus().GetRunDuration().AsDuration().Hours()
+	return hourly * hours * float64(max(vms, 1)), true, nil
+}
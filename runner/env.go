@@ -0,0 +1,40 @@
+package runner
+
+import (
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	_secrets "github.com/SyneHQ/apollo/secrets"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkEnv is the preflight of a run's environment: the secrets named
+// secretNames and the overrides of req must all have names a shell accepts,
+// or the run is rejected with codes.InvalidArgument listing the bad ones.
+// Overrides replacing a secret are logged, without their values.
+func checkEnv(req JobRequest, secretNames []string) error {
+	var overrides []EnvVar
+	if req.Overrides != nil {
+		overrides = req.Overrides.Env
+	}
+	var bad []string
+	for _, name := range secretNames {
+		if !_secrets.ValidEnvName(name) {
+			bad = append(bad, "secret "+strconv.Quote(name))
+		}
+	}
+	for _, v := range overrides {
+		if !_secrets.ValidEnvName(v.Name) {
+			bad = append(bad, "override "+strconv.Quote(v.Name))
+		} else if slices.Contains(secretNames, v.Name) {
+			log.Printf("job %s: override of %s replaces the secret of that name", jobID(req), v.Name)
+		}
+	}
+	if len(bad) > 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid env var names, want letters, digits and underscores not starting with a digit: %s", strings.Join(bad, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"time"
+)
+
+// HealthCheck probes a freshly started container until it reports healthy.
+// Exactly one of Command and HTTPURL should be set.
+type HealthCheck struct {
+	Command  []string      // run inside the container; healthy on exit 0
+	HTTPURL  string        // healthy on a 2xx response
+	Interval time.Duration // between probes (default: 2s)
+	Timeout  time.Duration // overall budget to become healthy (default: 60s)
+}
+
+func (h HealthCheck) interval() time.Duration {
+	if h.Interval > 0 {
+		return h.Interval
+	}
+	return 2 * time.Second
+}
+
+func (h HealthCheck) timeout() time.Duration {
+	if h.Timeout > 0 {
+		return h.Timeout
+	}
+	return time.Minute
+}
+
+// runWithHealthCheck starts the container and polls its health check while it
+// runs. OnStarted fires once the check passes; a container that never becomes
+// healthy within the timeout is removed and the run fails.
+func (l *LocalRunner) runWithHealthCheck(ctx context.Context, cmd *exec.Cmd, req JobRequest, container string, stream io.Writer) (string, error) {
+	out := &output{stream: stream}
+	if err := out.start(cmd); err != nil {
+		return "", fmt.Errorf("local run failed: %w", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- out.wait(cmd) }()
+
+	probeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	healthy := make(chan error, 1)
+	go func() { healthy <- l.waitHealthy(probeCtx, container, *req.HealthCheck) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case probeErr := <-healthy:
+		if probeErr != nil {
+			rmCtx, rmCancel := context.WithTimeout(context.Background(), 30*time.Second)
+			_ = l.command(rmCtx, "rm", "-f", container).Run()
+			rmCancel()
+			<-done
+			return out.String(), fmt.Errorf("container %s never became healthy: %w", container, probeErr)
+		}
+		if req.OnStarted != nil {
+			req.OnStarted(time.Now())
+		}
+		err = <-done
+	}
+	if err != nil {
+		return out.String(), out.failed(err)
+	}
+	return out.String(), nil
+}
+
+// waitHealthy polls the health check until it passes, the timeout elapses or
+// ctx is done.
+func (l *LocalRunner) waitHealthy(ctx context.Context, container string, h HealthCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout())
+	defer cancel()
+	var last error
+	for {
+		if last = l.probe(ctx, container, h); last == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no healthy probe within %s: %w", h.timeout(), last)
+		case <-time.After(h.interval()):
+		}
+	}
+}
+
+func (l *LocalRunner) probe(ctx context.Context, container string, h HealthCheck) error {
+	if h.HTTPURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.HTTPURL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("%s answered %s", h.HTTPURL, resp.Status)
+		}
+		return nil
+	}
+	args := append([]string{"exec", container}, h.Command...)
+	if out, err := l.command(ctx, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%v: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
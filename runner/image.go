@@ -0,0 +1,136 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImageChecker is implemented by runners that can verify their image exists
+// before a job is launched, so a mistyped tag fails up front instead of as a
+// pull error inside the run.
+type ImageChecker interface {
+	// CheckImage checks image, or the runner's own when it is empty.
+	CheckImage(ctx context.Context, image string) error
+}
+
+// imageOf returns the image req runs: its own, else def.
+func imageOf(req JobRequest, def string) string {
+	if req.Image != "" {
+		return req.Image
+	}
+	return def
+}
+
+// Pull policies of LocalRunner: when `docker run` pulls the image.
+const (
+	PullAlways       = "always"         // before every run, so tags such as :latest stay current
+	PullNever        = "never"          // never; the image must be present
+	PullIfNotPresent = "if-not-present" // only when no image of that tag is cached
+)
+
+// pullArgs returns the `docker run` options applying policy, rejecting
+// unknown policies. The default, if-not-present, is docker's own.
+func pullArgs(policy string) ([]string, error) {
+	switch policy {
+	case "", PullIfNotPresent:
+		return nil, nil
+	case PullAlways, PullNever:
+		return []string{"--pull", policy}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "unknown pull policy %q, want %q, %q or %q", policy, PullAlways, PullNever, PullIfNotPresent)
+}
+
+// CheckImage looks for the image locally, then in its registry.
+func (l *LocalRunner) CheckImage(ctx context.Context, image string) error {
+	if image == "" {
+		image = l.Image
+	}
+	if err := l.command(ctx, "image", "inspect", image).Run(); err == nil {
+		return nil
+	}
+	if err := l.login(ctx, image); err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	out, err := l.command(ctx, "manifest", "inspect", image).CombinedOutput()
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "image %s not found locally or in its registry: %s", image, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// CheckImage asks the image's registry whether the tag exists, authenticating
+// to Google registries with the application default credentials. The check
+// is best effort: only a definite "not found" fails it.
+func (b *BatchRunner) CheckImage(ctx context.Context, image string) error {
+	if image == "" {
+		image = b.Image
+	}
+	host, repo, ref := parseImage(image)
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repo, ref)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", strings.Join([]string{
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.oci.image.manifest.v1+json",
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+	}, ", "))
+	if isGoogleRegistry(host) {
+		ts, err := google.DefaultTokenSource(ctx, "https://www.googleapis.com/auth/cloud-platform")
+		if err == nil {
+			if tok, err := ts.Token(); err == nil {
+				req.SetBasicAuth("oauth2accesstoken", tok.AccessToken)
+			}
+		}
+	}
+
+	client := b.RegistryClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Printf("could not verify image %s: %v", image, err)
+		return nil
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return status.Errorf(codes.FailedPrecondition, "image %s not found in its registry", image)
+	case resp.StatusCode >= 300:
+		log.Printf("could not verify image %s: registry answered %s", image, resp.Status)
+	}
+	return nil
+}
+
+// parseImage splits an image reference into its registry host, repository
+// and tag or digest, applying Docker Hub's defaults.
+func parseImage(image string) (host, repo, ref string) {
+	name, ref := image, "latest"
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, ref = name[:i], name[i+1:]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref = name[:i], name[i+1:]
+	}
+	host, repo = "registry-1.docker.io", name
+	if first, rest, ok := strings.Cut(name, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		host, repo = first, rest
+	}
+	if host == "registry-1.docker.io" && !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return host, repo, ref
+}
+
+func isGoogleRegistry(host string) bool {
+	return host == "gcr.io" || strings.HasSuffix(host, ".gcr.io") || strings.HasSuffix(host, "-docker.pkg.dev")
+}
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Environment variables telling a job the resources it was given, so it can
+// size worker pools and caches to them instead of guessing.
+const (
+	cpuLimitEnv    = "APOLLO_CPU_LIMIT"    // cores, e.g. "0.5"
+	memoryLimitEnv = "APOLLO_MEMORY_LIMIT" // MiB, e.g. "2048"
+)
+
+func formatCPULimit(cpuMilli int64) string {
+	return strconv.FormatFloat(float64(cpuMilli)/1000, 'f', -1, 64)
+}
+
+func formatMemoryLimit(memoryMib int64) string {
+	return strconv.FormatInt(memoryMib, 10)
+}
+
+// dockerCPUMilli converts a --cpus value, given in cores ("1.5") or
+// millicores ("500m"), to millicores.
+func dockerCPUMilli(cpu string) (int64, bool) {
+	cpu = strings.TrimSpace(cpu)
+	if milli, ok := strings.CutSuffix(cpu, "m"); ok {
+		v, err := strconv.ParseInt(milli, 10, 64)
+		return v, err == nil && v > 0
+	}
+	v, err := strconv.ParseFloat(cpu, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return int64(v * 1000), true
+}
+
+// dockerMemoryMib converts a --memory value to MiB. It accepts docker's
+// b/k/m/g suffixes as well as the Ki/Mi/Gi spelling jobs.yml uses.
+func dockerMemoryMib(memory string) (int64, bool) {
+	memory = strings.ToLower(strings.TrimSpace(memory))
+	memory = strings.TrimSuffix(memory, "i")
+	memory = strings.TrimSuffix(memory, "b")
+	scale := map[byte]float64{'k': 1.0 / 1024, 'm': 1, 'g': 1024}
+	factor := 1.0 / (1024 * 1024) // plain bytes
+	if n := len(memory); n > 0 {
+		if f, ok := scale[memory[n-1]]; ok {
+			factor, memory = f, memory[:n-1]
+		}
+	}
+	v, err := strconv.ParseFloat(memory, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	mib := int64(v * factor)
+	return mib, mib > 0
+}
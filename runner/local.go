@@ -2,50 +2,330 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/infisical/go-sdk/packages/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type LocalRunner struct {
 	Image   string
 	Secrets []models.Secret
+	// TransientRetries is how often a `docker run` failing for a transient
+	// docker error is retried, waiting TransientRetryDelay before the first
+	// retry and doubling it for each further one
+	TransientRetries    int
+	TransientRetryDelay time.Duration
+	// Security hardens the containers of jobs whose request sets none
+	Security *Security
+	// StopSignal is the signal cancelled jobs whose request names none
+	// receive (default: SIGTERM); StopTimeout is how long they get to exit
+	// before they are killed (default: docker's, 10s)
+	StopSignal  string
+	StopTimeout time.Duration
+	// RegistryAuth, when set, logs docker in to the registry of a run's
+	// image before the first run from it, and again when it refuses a pull
+	RegistryAuth *RegistryAuth
+	// PullPolicy is when runs pull the image: PullAlways, PullNever or
+	// PullIfNotPresent (default)
+	PullPolicy string
+	// Runtime is the container CLI jobs are run with: "docker" (default),
+	// "podman" or "nerdctl", which accept the same run, ps, inspect, stop
+	// and login flags. CheckImage needs `manifest inspect`, which nerdctl
+	// lacks before 2.1.
+	Runtime string
+	// Network is the docker network containers join, e.g. "host", "bridge"
+	// or a named network, unless the request's overrides name one
+	// (default: docker's)
+	Network string
+	// AllowedNetworks are the networks besides Network a request's
+	// overrides may name; any other is refused
+	AllowedNetworks []string
+
+	mu         sync.Mutex
+	jobs       map[string]*JobStatus     // jobs started by this runner, by job ID
+	containers map[string]localContainer // running containers, by container name
+
+	secretsMu sync.RWMutex // guards Secrets once jobs run
+
+	loginMu  sync.Mutex
+	loggedIn map[string]bool // registries logged in to, "" for Docker Hub
+}
+
+// SetSecrets replaces the secrets later jobs are given.
+func (l *LocalRunner) SetSecrets(secrets []models.Secret) {
+	l.secretsMu.Lock()
+	defer l.secretsMu.Unlock()
+	l.Secrets = secrets
+}
+
+func (l *LocalRunner) secrets() []models.Secret {
+	l.secretsMu.RLock()
+	defer l.secretsMu.RUnlock()
+	return l.Secrets
+}
+
+// localContainer is a container RunJob started and has not seen exit.
+type localContainer struct {
+	jobID string
+	name  string // job name
+}
+
+// containerName is the name of the container that runs the job with ID id.
+func containerName(id string) string {
+	return "apollo-" + id
+}
+
+// runtime is the container CLI the runner invokes.
+func (l *LocalRunner) runtime() string {
+	if l.Runtime == "" {
+		return "docker"
+	}
+	return l.Runtime
+}
+
+// command prepares an invocation of the runner's container CLI.
+func (l *LocalRunner) command(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, l.runtime(), args...)
+}
+
+// jobID is the ID a run is tracked under: its job ID, else its name.
+func jobID(req JobRequest) string {
+	if req.JobID != "" {
+		return req.JobID
+	}
+	return req.Name
 }
 
 func NewLocalRunner(image string, secrets []models.Secret) *LocalRunner {
-	return &LocalRunner{Image: image, Secrets: secrets}
+	return &LocalRunner{Image: image, Secrets: secrets, TransientRetries: 2, TransientRetryDelay: 2 * time.Second, PullPolicy: PullIfNotPresent}
 }
 
+func (l *LocalRunner) Location() (string, string) { return "local", "" }
+
 func (l *LocalRunner) RunJob(ctx context.Context, _cmd string, req JobRequest) (string, error) {
+	return l.RunJobStream(ctx, _cmd, req, io.Discard)
+}
+
+// RunJobStream runs the job like RunJob, copying each line the container
+// prints to out as it is printed. The full output is still returned. out is
+// not written to concurrently.
+func (l *LocalRunner) RunJobStream(ctx context.Context, _cmd string, req JobRequest, out io.Writer) (string, error) {
+	if req.DryRun {
+		return l.RenderCommand(ctx, _cmd, req)
+	}
+	args, err := l.BuildArgs(ctx, _cmd, req)
+	if err != nil {
+		return "", err
+	}
+	image := imageOf(req, l.Image)
+	if err := l.login(ctx, image); err != nil {
+		return "", err
+	}
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	id := jobID(req)
+	container := containerName(id)
+	l.mu.Lock()
+	if l.containers == nil {
+		l.containers = map[string]localContainer{}
+	}
+	l.containers[container] = localContainer{jobID: id, name: req.Name}
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		delete(l.containers, container)
+		l.mu.Unlock()
+	}()
+
+	if req.HealthCheck != nil {
+		onStarted := req.OnStarted
+		req.OnStarted = func(at time.Time) {
+			l.started(id, at)
+			if onStarted != nil {
+				onStarted(at)
+			}
+		}
+	}
+
+	var result string
+	relogged := false
+	for attempt := 0; ; attempt++ {
+		result, err = l.runContainer(ctx, args, req, container, out)
+		if err != nil && !relogged && l.RegistryAuth != nil && ctx.Err() == nil && authDockerError(err) {
+			// the login may have expired or been replaced; log in again once
+			relogged = true
+			l.forgetLogin(image)
+			if err = l.login(ctx, image); err != nil {
+				break
+			}
+			log.Printf("%s run of %s was refused by the registry, retrying after logging in again", l.runtime(), id)
+			attempt--
+			continue
+		}
+		if err == nil || attempt >= l.TransientRetries || ctx.Err() != nil || !transientDockerError(err) {
+			break
+		}
+		delay := l.TransientRetryDelay << attempt
+		log.Printf("%s run of %s failed transiently, retrying in %s (%d/%d): %v", l.runtime(), id, delay, attempt+1, l.TransientRetries, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+	}
+	if err != nil && req.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("job %s exceeded its %s timeout: %w: %w", id, req.Timeout, context.DeadlineExceeded, err)
+	}
+	l.finished(id, err)
+	return result, err
+}
+
+// runContainer makes a single `docker run` attempt.
+func (l *LocalRunner) runContainer(ctx context.Context, args []string, req JobRequest, container string, out io.Writer) (string, error) {
+	id := jobID(req)
+	cmd := l.command(ctx, args...)
+	// Killing the docker client leaves the container running, so stop the
+	// container itself when the context is cancelled or times out: docker
+	// sends its stop signal and kills it once the stop timeout has passed.
+	cmd.Cancel = func() error {
+		rmCtx, cancel := context.WithTimeout(context.Background(), l.StopTimeout+30*time.Second)
+		defer cancel()
+		_ = l.command(rmCtx, "stop", container).Run()
+		_ = l.command(rmCtx, "rm", "-f", container).Run()
+		return cmd.Process.Kill()
+	}
+
+	if req.HealthCheck != nil {
+		l.track(id, func(st *JobStatus) { *st = JobStatus{State: JobStatePending} })
+		return l.runWithHealthCheck(ctx, cmd, req, container, out)
+	}
+	output := &output{stream: out}
+	l.track(id, func(st *JobStatus) { *st = JobStatus{State: JobStateRunning, StartedAt: time.Now()} })
+	if err := output.run(cmd); err != nil {
+		return output.String(), output.failed(err)
+	}
+	return output.String(), nil
+}
+
+// dockerErrorExitCode is the exit code of `docker run` when the docker
+// client or daemon failed, as opposed to the container's own exit code.
+// Podman and nerdctl use the same.
+const dockerErrorExitCode = 125
+
+// transientDockerErrors are the docker errors worth retrying: the daemon
+// briefly unreachable or an image pull timing out.
+var transientDockerErrors = []string{
+	"Cannot connect to the Docker daemon",
+	"Cannot connect to Podman",
+	"connection refused",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"Client.Timeout exceeded",
+	"net/http: request canceled",
+}
+
+// transientDockerError reports whether a failed run was docker itself failing
+// for a passing reason. A container that exits non-zero never is, whatever it
+// printed.
+func transientDockerError(err error) bool {
+	if code, ok := ExitCode(err); !ok || code != dockerErrorExitCode {
+		return false
+	}
+	stderr, _ := Stderr(err)
+	for _, msg := range transientDockerErrors {
+		if strings.Contains(stderr, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkPattern matches docker network names and modes such as
+// "container:<name>".
+var networkPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.:-]*$`)
+
+// BuildArgs assembles the `docker` arguments RunJob executes for req.
+func (l *LocalRunner) BuildArgs(ctx context.Context, _cmd string, req JobRequest) ([]string, error) {
 	// Run container using docker with bun command inside image
 	// Example: docker run --rm <image> rover <command> <argsBase64>
 	args := []string{"run", "--rm"}
 
-	args = append(args, "--name", req.Name)
+	args = append(args, "--name", containerName(jobID(req)), "--label", jobIDLabel+"="+jobID(req))
 
 	args, err := l.AppendSecrets(ctx, req, args)
 	if err != nil {
 		fmt.Printf("Error appending secrets: %v\n", err)
-		return "", err
+		return nil, err
 	}
 
 	args, err = l.AppendOverrides(ctx, req, args)
 	if err != nil {
 		fmt.Printf("Error appending overrides: %v\n", err)
-		return "", err
+		return nil, err
 	}
 
-	args = append(args, l.Image, _cmd, req.Command)
+	// docker run options must precede the image; anything after it is
+	// passed to the container's command
+	args, err = l.LimitResources(ctx, req, args)
+	if err != nil {
+		fmt.Printf("Error limiting resources: %v\n", err)
+		return nil, err
+	}
 
-	if req.ArgsJSONBase64 != "" {
-		args = append(args, req.ArgsJSONBase64)
+	security, err := securityFor(req, l.Security).dockerArgs()
+	if err != nil {
+		return nil, err
 	}
+	args = append(args, security...)
 
-	args, err = l.LimitResources(ctx, req, args)
+	signal, err := stopSignalFor(req, l.StopSignal)
 	if err != nil {
-		fmt.Printf("Error limiting resources: %v\n", err)
-		return "", err
+		return nil, err
+	}
+	if signal != "" {
+		args = append(args, "--stop-signal", signal)
+	}
+	if l.StopTimeout > 0 {
+		args = append(args, "--stop-timeout", strconv.Itoa(int(l.StopTimeout.Round(time.Second)/time.Second)))
+	}
+	pull, err := pullArgs(l.PullPolicy)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, pull...)
+	network := l.Network
+	if req.Overrides != nil && req.Overrides.Network != "" {
+		network = req.Overrides.Network
+	}
+	if network != "" {
+		if !networkPattern.MatchString(network) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid docker network %q", network)
+		}
+		if network != l.Network && !slices.Contains(l.AllowedNetworks, network) {
+			return nil, status.Errorf(codes.PermissionDenied, "docker network %q is not allowed", network)
+		}
+		args = append(args, "--network", network)
+	}
+
+	args = append(args, imageOf(req, l.Image), _cmd, req.Command)
+
+	if req.ArgsJSONBase64 != "" {
+		args = append(args, req.ArgsJSONBase64)
 	}
 
 	// Use overrides if provided, otherwise use default args
@@ -53,18 +333,48 @@ func (l *LocalRunner) RunJob(ctx context.Context, _cmd string, req JobRequest) (
 		args = append(args, req.Overrides.Args...)
 	}
 
-	cmd := exec.CommandContext(ctx, "docker", args...)
+	return args, nil
+}
 
-	out, err := cmd.CombinedOutput()
+// RenderCommand returns the docker command line RunJob would execute for req,
+// shell-quoted for copy-paste. Secret values are replaced by a placeholder so
+// their presence is visible without leaking them.
+func (l *LocalRunner) RenderCommand(ctx context.Context, _cmd string, req JobRequest) (string, error) {
+	// redact the secrets from before and after building, in case they were
+	// swapped in between
+	secrets := l.secrets()
+	args, err := l.BuildArgs(ctx, _cmd, req)
 	if err != nil {
-		return "", fmt.Errorf("local run failed: %w: %s", err, string(out))
+		return "", err
 	}
-	return string(out), nil
+	secrets = append(slices.Clip(secrets), l.secrets()...)
+	secretEnv := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		secretEnv[secret.SecretKey+"="+secret.SecretValue] = secret.SecretKey + "=<redacted>"
+	}
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, l.runtime())
+	for i, arg := range args {
+		if redacted, ok := secretEnv[arg]; ok && i > 0 && args[i-1] == "-e" {
+			arg = redacted
+		}
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return strings.Join(quoted, " "), nil
 }
 
 func (l *LocalRunner) AppendSecrets(ctx context.Context, req JobRequest, args []string) ([]string, error) {
+	secrets := selectSecrets(req, l.secrets())
+	names := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		names = append(names, secret.SecretKey)
+	}
+	// overrides are appended later and win, as docker keeps the last -e
+	if err := checkEnv(req, names); err != nil {
+		return nil, err
+	}
 	// Inject Infisical secrets as environment variables
-	for _, secret := range l.Secrets {
+	for _, secret := range secrets {
 		args = append(args, "-e", secret.SecretKey+"="+secret.SecretValue)
 	}
 	return args, nil
@@ -77,8 +387,22 @@ func (l *LocalRunner) LimitResources(ctx context.Context, req JobRequest, args [
 		resources = *req.Overrides.Resources
 	}
 
+	// percentages are of a Batch machine type; docker only takes amounts
+	for _, value := range []string{resources.CPU, resources.Memory} {
+		if _, ok, _ := parsePercent(value); ok {
+			return nil, status.Errorf(codes.InvalidArgument, "cpu or memory %q is a percentage, which only the Batch runner resolves; set an amount to run locally", value)
+		}
+	}
+
 	// we need to read memory and cpu limits and apply those limits
 	args = append(args, "--memory", resources.Memory, "--cpus", resources.CPU)
+	// and tell the job what it got; set last so overrides cannot misreport it
+	if cpuMilli, ok := dockerCPUMilli(resources.CPU); ok {
+		args = append(args, "-e", cpuLimitEnv+"="+formatCPULimit(cpuMilli))
+	}
+	if memoryMib, ok := dockerMemoryMib(resources.Memory); ok {
+		args = append(args, "-e", memoryLimitEnv+"="+formatMemoryLimit(memoryMib))
+	}
 	return args, nil
 }
 
@@ -90,21 +414,79 @@ func (l *LocalRunner) AppendOverrides(ctx context.Context, req JobRequest, args
 			args = append(args, "-e", envVar.Name+"="+envVar.Value)
 		}
 	}
+	volumes, err := volumeSpecs(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		args = append(args, "-v", v)
+	}
 	return args, nil
 }
 
+// DeleteJob stops the running containers of a job, given its job ID or its
+// name, sending each its stop signal first. A job this runner is not running,
+// e.g. one started before a restart, is looked up by its container name; a
+// job with no container is not an error.
 func (l *LocalRunner) DeleteJob(ctx context.Context, name string) error {
-	// local one-off containers are ephemeral; nothing to delete
-	// cancel the container if it's running
-	cmd := exec.CommandContext(ctx, "docker", "rm", "-f", name)
+	for _, container := range l.containersOf(name) {
+		// the container is started with --rm, so stopping it usually removes it
+		_ = l.command(ctx, "stop", container).Run()
+		out, err := l.command(ctx, "rm", "-f", container).CombinedOutput()
+		// docker says "No such container", podman "no such container"
+		if err != nil && !strings.Contains(strings.ToLower(string(out)), "no such container") {
+			return fmt.Errorf("failed to delete container %s: %w: %s", container, err, string(out))
+		}
+	}
+	return nil
+}
+
+// containersOf returns the running containers of the job with the given ID
+// or name, or the container its ID would name when there are none.
+func (l *LocalRunner) containersOf(name string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var containers []string
+	for container, c := range l.containers {
+		if c.jobID == name || c.name == name {
+			containers = append(containers, container)
+		}
+	}
+	if len(containers) == 0 {
+		containers = append(containers, containerName(name))
+	}
+	return containers
+}
+
+// ReadLogs returns the output of a running container. Local jobs always run as
+// a single container, so only task 0 (or all tasks) exists.
+func (l *LocalRunner) ReadLogs(ctx context.Context, name string, taskIndex int32, maxLines int) (string, error) {
+	if taskIndex != AllTasks && taskIndex != 0 {
+		return "", fmt.Errorf("local job %s has no task %d", name, taskIndex)
+	}
+	args := []string{"logs"}
+	if maxLines > 0 {
+		args = append(args, "--tail", strconv.Itoa(maxLines))
+	}
+	cmd := l.command(ctx, append(args, l.containersOf(name)[0])...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("failed to delete container: %w: %s", err, string(out))
+		return "", fmt.Errorf("failed to read container logs: %w: %s", err, string(out))
 	}
-	return nil
+	return string(out), nil
 }
 
 func (l *LocalRunner) UpdateSchedule(ctx context.Context, name string, spec string) error {
 	// scheduling is handled by the in-memory scheduler in the server for local provider
 	return nil
 }
+
+// shellQuote quotes arg for a POSIX shell, leaving plain words untouched.
+func shellQuote(arg string) string {
+	if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@%+,", r))
+	}) < 0 {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,204 @@
+package runner
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMachineType is the Compute Engine machine type Batch jobs whose
+// resources are percentages run on, as those are relative to the machine.
+const defaultMachineType = "n1-standard-1"
+
+// MachineCapacity is the vCPU and memory a Compute Engine machine type offers.
+// Accelerator-optimized types come with GPUs GPUs of GPUType; other types
+// have none and take GPUs attached in any number.
+type MachineCapacity struct {
+	CPUMilli  int64
+	MemoryMib int64
+	GPUs      int32
+	GPUType   string
+}
+
+// machineCapacities lists the machine types percentage resources can be
+// resolved against.
+var machineCapacities = map[string]MachineCapacity{
+	"n1-standard-1":  {CPUMilli: 1000, MemoryMib: 3840},
+	"n1-standard-2":  {CPUMilli: 2000, MemoryMib: 7680},
+	"n1-standard-4":  {CPUMilli: 4000, MemoryMib: 15360},
+	"n1-standard-8":  {CPUMilli: 8000, MemoryMib: 30720},
+	"n1-standard-16": {CPUMilli: 16000, MemoryMib: 61440},
+	"n1-highmem-2":   {CPUMilli: 2000, MemoryMib: 13312},
+	"n1-highmem-4":   {CPUMilli: 4000, MemoryMib: 26624},
+	"n1-highmem-8":   {CPUMilli: 8000, MemoryMib: 53248},
+	"e2-standard-2":  {CPUMilli: 2000, MemoryMib: 8192},
+	"e2-standard-4":  {CPUMilli: 4000, MemoryMib: 16384},
+	"e2-standard-8":  {CPUMilli: 8000, MemoryMib: 32768},
+	"e2-standard-16": {CPUMilli: 16000, MemoryMib: 65536},
+	"e2-highmem-2":   {CPUMilli: 2000, MemoryMib: 16384},
+	"e2-highmem-4":   {CPUMilli: 4000, MemoryMib: 32768},
+	"e2-highmem-8":   {CPUMilli: 8000, MemoryMib: 65536},
+	"n2-standard-2":  {CPUMilli: 2000, MemoryMib: 8192},
+	"n2-standard-4":  {CPUMilli: 4000, MemoryMib: 16384},
+	"n2-standard-8":  {CPUMilli: 8000, MemoryMib: 32768},
+	"n2-standard-16": {CPUMilli: 16000, MemoryMib: 65536},
+	"n2-highmem-2":   {CPUMilli: 2000, MemoryMib: 16384},
+	"n2-highmem-4":   {CPUMilli: 4000, MemoryMib: 32768},
+	"n2-highmem-8":   {CPUMilli: 8000, MemoryMib: 65536},
+	"a2-highgpu-1g":  {CPUMilli: 12000, MemoryMib: 87040, GPUs: 1, GPUType: "nvidia-tesla-a100"},
+	"a2-highgpu-2g":  {CPUMilli: 24000, MemoryMib: 174080, GPUs: 2, GPUType: "nvidia-tesla-a100"},
+	"a2-highgpu-4g":  {CPUMilli: 48000, MemoryMib: 348160, GPUs: 4, GPUType: "nvidia-tesla-a100"},
+	"a2-highgpu-8g":  {CPUMilli: 96000, MemoryMib: 696320, GPUs: 8, GPUType: "nvidia-tesla-a100"},
+	"a2-megagpu-16g": {CPUMilli: 96000, MemoryMib: 1392640, GPUs: 16, GPUType: "nvidia-tesla-a100"},
+	"a2-ultragpu-1g": {CPUMilli: 12000, MemoryMib: 174080, GPUs: 1, GPUType: "nvidia-a100-80gb"},
+	"a2-ultragpu-2g": {CPUMilli: 24000, MemoryMib: 348160, GPUs: 2, GPUType: "nvidia-a100-80gb"},
+	"a2-ultragpu-4g": {CPUMilli: 48000, MemoryMib: 696320, GPUs: 4, GPUType: "nvidia-a100-80gb"},
+	"a2-ultragpu-8g": {CPUMilli: 96000, MemoryMib: 1392640, GPUs: 8, GPUType: "nvidia-a100-80gb"},
+	"g2-standard-4":  {CPUMilli: 4000, MemoryMib: 16384, GPUs: 1, GPUType: "nvidia-l4"},
+	"g2-standard-8":  {CPUMilli: 8000, MemoryMib: 32768, GPUs: 1, GPUType: "nvidia-l4"},
+	"g2-standard-12": {CPUMilli: 12000, MemoryMib: 49152, GPUs: 1, GPUType: "nvidia-l4"},
+	"g2-standard-16": {CPUMilli: 16000, MemoryMib: 65536, GPUs: 1, GPUType: "nvidia-l4"},
+	"g2-standard-24": {CPUMilli: 24000, MemoryMib: 98304, GPUs: 2, GPUType: "nvidia-l4"},
+	"g2-standard-32": {CPUMilli: 32000, MemoryMib: 131072, GPUs: 1, GPUType: "nvidia-l4"},
+	"g2-standard-48": {CPUMilli: 48000, MemoryMib: 196608, GPUs: 4, GPUType: "nvidia-l4"},
+	"g2-standard-96": {CPUMilli: 96000, MemoryMib: 393216, GPUs: 8, GPUType: "nvidia-l4"},
+}
+
+// gpuMachineFamilies maps the GPU types Batch jobs can request to the
+// machine family they can be attached to.
+var gpuMachineFamilies = map[string]string{
+	"nvidia-tesla-t4":   "n1",
+	"nvidia-tesla-p4":   "n1",
+	"nvidia-tesla-p100": "n1",
+	"nvidia-tesla-v100": "n1",
+	"nvidia-tesla-a100": "a2",
+	"nvidia-a100-80gb":  "a2",
+	"nvidia-l4":         "g2",
+}
+
+// validateGPU checks that the GPUs res asks for can be attached to
+// machineType.
+func validateGPU(res Resources, machineType string) error {
+	if res.GPUCount == 0 && res.GPUType == "" {
+		return nil
+	}
+	if res.GPUCount <= 0 || res.GPUType == "" {
+		return fmt.Errorf("gpu count and gpu type must be set together, got %d of %q", res.GPUCount, res.GPUType)
+	}
+	family, known := gpuMachineFamilies[res.GPUType]
+	if !known {
+		return fmt.Errorf("unknown gpu type %q", res.GPUType)
+	}
+	if !strings.HasPrefix(machineType, family+"-") {
+		return fmt.Errorf("gpu type %q can only be attached to %s-* machine types, but jobs run on %s", res.GPUType, family, machineType)
+	}
+	return nil
+}
+
+// machineTypeFor picks the machine type a job runs on: the request's, else
+// the runner's, else the smallest known machine type that fits the requested
+// CPU, memory and GPUs.
+func (b *BatchRunner) machineTypeFor(req JobRequest) (string, error) {
+	if req.MachineType != "" {
+		return req.MachineType, nil
+	}
+	if b.MachineType != "" {
+		return b.MachineType, nil
+	}
+	return fitMachineType(req.Resources)
+}
+
+// fitMachineType returns the smallest known machine type, by vCPUs then
+// memory, offering res. GPUs narrow the choice to the family they attach to.
+func fitMachineType(res Resources) (string, error) {
+	_, cpuPercent, _ := parsePercent(res.CPU)
+	_, memoryPercent, _ := parsePercent(res.Memory)
+	if cpuPercent || memoryPercent {
+		return defaultMachineType, nil
+	}
+	cpuMilli, memoryMib := parseCPU(res.CPU), parseMemory(res.Memory)
+	family := gpuMachineFamilies[res.GPUType]
+
+	best := ""
+	for _, name := range slices.Sorted(maps.Keys(machineCapacities)) {
+		capacity := machineCapacities[name]
+		if family != "" && !strings.HasPrefix(name, family+"-") {
+			continue
+		}
+		if capacity.GPUs != 0 && (capacity.GPUs != res.GPUCount || capacity.GPUType != res.GPUType) {
+			continue
+		}
+		if capacity.CPUMilli < cpuMilli || capacity.MemoryMib < memoryMib {
+			continue
+		}
+		if best != "" {
+			chosen := machineCapacities[best]
+			if capacity.CPUMilli > chosen.CPUMilli || (capacity.CPUMilli == chosen.CPUMilli && capacity.MemoryMib >= chosen.MemoryMib) {
+				continue
+			}
+		}
+		best = name
+	}
+	if best == "" {
+		return "", status.Errorf(codes.InvalidArgument,
+			"no known machine type offers %dm cpu and %dMi memory%s; set a machine type explicitly", cpuMilli, memoryMib, gpuSuffix(res))
+	}
+	return best, nil
+}
+
+func gpuSuffix(res Resources) string {
+	if res.GPUType == "" {
+		return ""
+	}
+	return " with " + res.GPUType + " gpus"
+}
+
+// parsePercent reports whether value is a percentage such as "50%" and
+// returns it as a fraction in (0, 1].
+func parsePercent(value string) (float64, bool, error) {
+	value = strings.TrimSpace(value)
+	if !strings.HasSuffix(value, "%") {
+		return 0, false, nil
+	}
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, true, fmt.Errorf("invalid percentage %q", value)
+	}
+	if pct <= 0 || pct > 100 {
+		return 0, true, fmt.Errorf("percentage %q must be in (0,100]", value)
+	}
+	return pct / 100, true, nil
+}
+
+// resolveCPU converts a CPU spec to millicores, resolving percentages against
+// the machine type's vCPUs.
+func resolveCPU(cpu, machineType string) (int64, error) {
+	frac, ok, err := parsePercent(cpu)
+	if err != nil || !ok {
+		return parseCPU(cpu), err
+	}
+	capacity, known := machineCapacities[machineType]
+	if !known {
+		return 0, fmt.Errorf("cannot resolve cpu %q: unknown machine type %q", cpu, machineType)
+	}
+	return int64(float64(capacity.CPUMilli) * frac), nil
+}
+
+// resolveMemory converts a memory spec to MiB, resolving percentages against
+// the machine type's memory.
+func resolveMemory(memory, machineType string) (int64, error) {
+	frac, ok, err := parsePercent(memory)
+	if err != nil || !ok {
+		return parseMemory(memory), err
+	}
+	capacity, known := machineCapacities[machineType]
+	if !known {
+		return 0, fmt.Errorf("cannot resolve memory %q: unknown machine type %q", memory, machineType)
+	}
+	return int64(float64(capacity.MemoryMib) * frac), nil
+}
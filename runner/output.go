@@ -0,0 +1,119 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+)
+
+// RunError is a failed run whose standard error was captured separately from
+// its combined output.
+type RunError struct {
+	Err    error
+	Stderr string
+}
+
+func (e *RunError) Error() string { return e.Err.Error() }
+
+func (e *RunError) Unwrap() error { return e.Err }
+
+// Stderr returns the standard error captured for a failed RunJob, reporting
+// false when the runner did not capture it (e.g. cloud runners).
+func Stderr(err error) (string, bool) {
+	var runErr *RunError
+	if errors.As(err, &runErr) {
+		return runErr.Stderr, true
+	}
+	return "", false
+}
+
+// output collects a command's interleaved stdout and stderr line by line,
+// keeping a separate copy of stderr and passing every line on to stream as
+// soon as it is written.
+type output struct {
+	stream io.Writer // may be nil
+
+	mu       sync.Mutex
+	combined bytes.Buffer
+	stderr   bytes.Buffer
+	copying  sync.WaitGroup
+}
+
+// start starts cmd with its output piped into o.
+func (o *output) start(cmd *exec.Cmd) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	o.copying.Add(2)
+	go o.copy(stdout, false)
+	go o.copy(stderr, true)
+	return nil
+}
+
+// wait waits for cmd, started with start, once its output is drained.
+func (o *output) wait(cmd *exec.Cmd) error {
+	o.copying.Wait()
+	return cmd.Wait()
+}
+
+// run starts cmd and waits for it.
+func (o *output) run(cmd *exec.Cmd) error {
+	if err := o.start(cmd); err != nil {
+		return err
+	}
+	return o.wait(cmd)
+}
+
+func (o *output) copy(r io.Reader, stderr bool) {
+	defer o.copying.Done()
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			o.write(line, stderr)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (o *output) write(line []byte, stderr bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.combined.Write(line)
+	if stderr {
+		o.stderr.Write(line)
+	}
+	if o.stream != nil {
+		_, _ = o.stream.Write(line)
+	}
+}
+
+func (o *output) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.combined.String()
+}
+
+// failed wraps the error of a finished command with its captured output.
+func (o *output) failed(err error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return &RunError{
+		Err:    fmt.Errorf("local run failed: %w: %s", err, o.combined.String()),
+		Stderr: o.stderr.String(),
+	}
+}
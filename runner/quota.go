@@ -0,0 +1,170 @@
+package runner
+
+import (
+	"context"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OutstandingReporter is implemented by runners that cap how many of their
+// jobs may be outstanding with the provider at once.
+type OutstandingReporter interface {
+	// OutstandingJobs returns how many submitted jobs have not finished yet,
+	// and the cap (0 when there is none).
+	OutstandingJobs(ctx context.Context) (count, limit int)
+}
+
+// SubmittedLister is implemented by runners that count, towards their cap,
+// the jobs a store shared by every replica records as submitted, so the cap
+// holds across restarts and replicas. list returns the provider names of
+// those jobs that start with prefix.
+type SubmittedLister interface {
+	SetSubmittedLister(list func(ctx context.Context, prefix string) ([]string, error))
+}
+
+// SetSubmittedLister makes the runner count the jobs list returns as
+// outstanding until it sees them finish.
+func (b *BatchRunner) SetSubmittedLister(list func(ctx context.Context, prefix string) ([]string, error)) {
+	b.quotaMu.Lock()
+	defer b.quotaMu.Unlock()
+	b.listSubmitted = list
+}
+
+// OutstandingJobs counts the jobs recorded as submitted to this runner, and
+// those this process submitted, that have not been seen to finish, including
+// submissions in flight.
+func (b *BatchRunner) OutstandingJobs(ctx context.Context) (count, limit int) {
+	names := b.outstandingNames(ctx)
+	b.quotaMu.Lock()
+	defer b.quotaMu.Unlock()
+	return len(names) + b.reserved, b.MaxOutstandingJobs
+}
+
+// outstandingNames lists the jobs counted towards the cap: those this process
+// submitted and those the store records as submitted, less those seen to
+// finish. When the store cannot be read only the former are counted.
+func (b *BatchRunner) outstandingNames(ctx context.Context) []string {
+	b.quotaMu.Lock()
+	list := b.listSubmitted
+	names := make(map[string]struct{}, len(b.outstanding))
+	for name := range b.outstanding {
+		names[name] = struct{}{}
+	}
+	b.quotaMu.Unlock()
+
+	if list != nil {
+		stored, err := list(ctx, b.parent()+"/jobs/")
+		if err != nil {
+			log.Printf("failed to count submitted batch jobs in the store: %v", err)
+		}
+		b.quotaMu.Lock()
+		if err == nil {
+			// jobs no longer recorded as submitted need not be remembered
+			kept := make(map[string]struct{}, len(b.finished))
+			for _, name := range stored {
+				if _, ok := b.finished[name]; ok {
+					kept[name] = struct{}{}
+				}
+			}
+			b.finished = kept
+		}
+		for _, name := range stored {
+			if _, ok := b.finished[name]; !ok {
+				names[name] = struct{}{}
+			}
+		}
+		b.quotaMu.Unlock()
+	}
+	return slices.Collect(maps.Keys(names))
+}
+
+// waitForSlot blocks until fewer than MaxOutstandingJobs jobs are
+// outstanding and reserves a slot for the next submission, which must be
+// handed back with submitted. While the cap is reached the outstanding jobs
+// are polled for their status, so slots free up as jobs finish.
+func (b *BatchRunner) waitForSlot(ctx context.Context, client BatchClient) error {
+	if b.MaxOutstandingJobs <= 0 {
+		return nil
+	}
+	for queued := false; ; queued = true {
+		names := b.outstandingNames(ctx)
+		if b.reserveSlot(len(names)) {
+			return nil
+		}
+		if b.reserveSlot(len(names) - b.refreshOutstanding(ctx, client, names)) {
+			return nil
+		}
+		if !queued {
+			log.Printf("%d batch jobs outstanding, queueing submission", b.MaxOutstandingJobs)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(b.OutstandingPollInterval):
+		}
+	}
+}
+
+// reserveSlot reserves a slot when outstanding jobs and the submissions in
+// flight leave one free.
+func (b *BatchRunner) reserveSlot(outstanding int) bool {
+	b.quotaMu.Lock()
+	defer b.quotaMu.Unlock()
+	if outstanding+b.reserved >= b.MaxOutstandingJobs {
+		return false
+	}
+	b.reserved++
+	return true
+}
+
+// submitted releases the slot reserved by waitForSlot, tracking the job when
+// it was created.
+func (b *BatchRunner) submitted(name string, err error) {
+	if b.MaxOutstandingJobs <= 0 {
+		return
+	}
+	b.quotaMu.Lock()
+	defer b.quotaMu.Unlock()
+	b.reserved--
+	if err != nil {
+		return
+	}
+	if b.outstanding == nil {
+		b.outstanding = map[string]struct{}{}
+	}
+	b.outstanding[name] = struct{}{}
+}
+
+// refreshOutstanding polls the named jobs and stops counting those that
+// finished or no longer exist, returning how many it found. Jobs whose status
+// cannot be read stay counted.
+func (b *BatchRunner) refreshOutstanding(ctx context.Context, client BatchClient, names []string) int {
+	done := 0
+	for _, name := range names {
+		job, err := client.GetJob(ctx, name)
+		switch {
+		case status.Code(err) == codes.NotFound:
+		case err != nil:
+			continue
+		case !batchState(job.GetStatus().GetState()).Terminal():
+			continue
+		}
+		done++
+		b.quotaMu.Lock()
+		delete(b.outstanding, name)
+		if b.listSubmitted != nil {
+			if b.finished == nil {
+				b.finished = map[string]struct{}{}
+			}
+			b.finished[name] = struct{}{}
+		}
+		b.quotaMu.Unlock()
+		b.forget(name)
+	}
+	return done
+}
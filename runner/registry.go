@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// RegistryAuth are the credentials LocalRunner logs in to a private registry
+// with before it first pulls an image from it.
+type RegistryAuth struct {
+	// Server is the registry host, e.g. "ghcr.io"; images from other
+	// registries are pulled without logging in (default: every image's)
+	Server   string
+	Username string
+	// Password is the password or access token; it is handed to docker on
+	// stdin and never logged
+	Password string
+}
+
+// server is the registry a login with a is for when running image: the
+// image's registry, "" for Docker Hub. ok is false when a.Server names
+// another registry, whose credentials image's registry is not given.
+func (a *RegistryAuth) server(image string) (server string, ok bool) {
+	host, _, _ := parseImage(image)
+	server = registryHost(host)
+	return server, a.Server == "" || registryHost(a.Server) == server
+}
+
+// registryHost is how docker login names a registry: "" for Docker Hub,
+// else its host.
+func registryHost(host string) string {
+	switch host {
+	case "registry-1.docker.io", "docker.io", "index.docker.io":
+		return ""
+	default:
+		return host
+	}
+}
+
+// mask replaces the password in s, e.g. docker's output.
+func (a *RegistryAuth) mask(s string) string {
+	if a.Password == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, a.Password, "<redacted>")
+}
+
+// login runs `docker login` with the runner's RegistryAuth the first time a
+// run needs image's registry, and again after a failed attempt or once
+// forgetLogin dropped it. It does nothing without credentials for that
+// registry.
+func (l *LocalRunner) login(ctx context.Context, image string) error {
+	auth := l.RegistryAuth
+	if auth == nil {
+		return nil
+	}
+	server, ok := auth.server(image)
+	if !ok {
+		return nil
+	}
+	l.loginMu.Lock()
+	defer l.loginMu.Unlock()
+	if l.loggedIn[server] {
+		return nil
+	}
+	args := []string{"login", "--username", auth.Username, "--password-stdin"}
+	if server != "" {
+		args = append(args, server)
+	}
+	cmd := l.command(ctx, args...)
+	cmd.Stdin = strings.NewReader(auth.Password)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s login to %s as %s failed: %w: %s", l.runtime(), registryName(server), auth.Username, err, strings.TrimSpace(auth.mask(string(out))))
+	}
+	log.Printf("logged in to %s as %s", registryName(server), auth.Username)
+	if l.loggedIn == nil {
+		l.loggedIn = map[string]bool{}
+	}
+	l.loggedIn[server] = true
+	return nil
+}
+
+// forgetLogin makes the next login to image's registry run `docker login`
+// again, e.g. once the registry rejected the stored credentials.
+func (l *LocalRunner) forgetLogin(image string) {
+	if l.RegistryAuth == nil {
+		return
+	}
+	server, _ := l.RegistryAuth.server(image)
+	l.loginMu.Lock()
+	defer l.loginMu.Unlock()
+	delete(l.loggedIn, server)
+}
+
+// authDockerErrors are what docker prints when a registry refuses a pull for
+// want of valid credentials.
+var authDockerErrors = []string{
+	"unauthorized",
+	"authentication required",
+	"no basic auth credentials",
+	"denied",
+}
+
+// authDockerError reports whether a failed run was docker failing to pull its
+// image for want of valid credentials, e.g. an expired login.
+func authDockerError(err error) bool {
+	if code, ok := ExitCode(err); !ok || code != dockerErrorExitCode {
+		return false
+	}
+	stderr, _ := Stderr(err)
+	stderr = strings.ToLower(stderr)
+	for _, msg := range authDockerErrors {
+		if strings.Contains(stderr, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func registryName(server string) string {
+	if server == "" {
+		return "Docker Hub"
+	}
+	return server
+}
@@ -1,6 +1,16 @@
 package runner
 
-import "context"
+import (
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"slices"
+	"time"
+
+	_secrets "github.com/SyneHQ/apollo/secrets"
+	"github.com/infisical/go-sdk/packages/models"
+)
 
 type JobType string
 
@@ -17,7 +27,65 @@ type JobRequest struct {
 	Resources      Resources
 	Type           JobType
 	ScheduleSpec   string        // cron spec if repeatable
+	TimeZone       string        // IANA time zone ScheduleSpec runs in (default: UTC on Cloud Scheduler, the server's locally)
 	Overrides      *JobOverrides // Optional runtime overrides
+	// HealthCheck, when set, must pass before the run counts as started
+	HealthCheck *HealthCheck
+	// OnStarted is called once the job has started and, with a health
+	// check, become healthy
+	OnStarted func(at time.Time)
+	// Labels tag the execution record of the run
+	Labels map[string]string
+	// BatchID groups the execution record with those of a bulk submission
+	BatchID string
+	// Timeout bounds the run: Batch's MaxRunDuration (default 24h), a
+	// deadline on the container locally (default none)
+	Timeout time.Duration
+	// MaxRetries is how often Batch retries a failed task; nil for the
+	// default of 3, 0 for none
+	MaxRetries *int32
+	// MachineType is the Compute Engine machine type a Batch job runs on
+	// (default: the runner's, else the smallest that fits Resources)
+	MachineType string
+	// Security hardens the job's container (default: the runner's)
+	Security *Security
+	// Secrets narrows the runner's secrets the job receives (default: all)
+	Secrets *SecretSelection
+	// Wait makes a runner that only submits jobs, such as Batch, return once
+	// the job has finished instead of once it was created
+	Wait bool
+	// DryRun makes a runner that renders its commands return what it would
+	// run, with secret values redacted, instead of running it
+	DryRun bool
+	// StopSignal is the signal the job's container receives when the run is
+	// cancelled, e.g. "SIGUSR1", before it is killed (default: the runner's,
+	// else SIGTERM)
+	StopSignal string
+	// Image is the container image the job runs (default: the runner's)
+	Image string
+}
+
+// SecretSelection narrows a runner's secrets to those carrying one of Tags,
+// plus those listed by name in Names.
+type SecretSelection struct {
+	Tags  []string
+	Names []string
+}
+
+// selectSecrets returns the secrets of all that req receives.
+func selectSecrets(req JobRequest, all []models.Secret) []models.Secret {
+	if req.Secrets == nil {
+		return all
+	}
+	return _secrets.SelectSecrets(all, req.Secrets.Tags, req.Secrets.Names)
+}
+
+// selectsSecret reports whether req receives the secret name, given the
+// secrets selectSecrets selected for it. Secrets it holds no value for, e.g.
+// those read from Secret Manager, are only selected by name.
+func selectsSecret(req JobRequest, selected []models.Secret, name string) bool {
+	return req.Secrets == nil || slices.Contains(req.Secrets.Names, name) ||
+		slices.ContainsFunc(selected, func(s models.Secret) bool { return s.SecretKey == name })
 }
 
 type JobOverrides struct {
@@ -25,6 +93,11 @@ type JobOverrides struct {
 	Env       []EnvVar   // Override environment variables
 	Resources *Resources // Override resource limits
 	TaskCount int32      // Override task count for parallel execution
+	// Volumes bind mount host paths into the container
+	Volumes []VolumeMount
+	// Network is the docker network the container joins, e.g. "host"
+	// (local runner only; default: the runner's)
+	Network string
 }
 
 type EnvVar struct {
@@ -35,10 +108,117 @@ type EnvVar struct {
 type Resources struct {
 	CPU    string
 	Memory string
+	// GPUCount GPUs of GPUType, e.g. "nvidia-tesla-t4", are attached to the
+	// job's VM (Batch only; default none)
+	GPUCount int32
+	GPUType  string
 }
 
 type Runner interface {
 	RunJob(ctx context.Context, prefix string, req JobRequest) (string, error)
 	DeleteJob(ctx context.Context, name string) error
+	// UpdateSchedule points the schedule name at spec, which may carry a
+	// CRON_TZ prefix naming its time zone.
 	UpdateSchedule(ctx context.Context, name string, spec string) error
+	// GetJobStatus reports on a job RunJob started. name is the request's
+	// JobID, or its Name when no JobID was set. Unknown jobs fail with
+	// codes.NotFound.
+	GetJobStatus(ctx context.Context, name string) (JobStatus, error)
+	// ListJobs lists the jobs the provider currently holds, whether or not
+	// Apollo knows about them.
+	ListJobs(ctx context.Context) ([]JobInfo, error)
+}
+
+// AllTasks selects the output of every task of a job when reading logs.
+const AllTasks int32 = -1
+
+// LogReader is implemented by runners that can fetch a job's output after it
+// was started. taskIndex narrows the output to a single task of a parallel
+// job; pass AllTasks for everything. maxLines > 0 keeps only the last
+// maxLines lines.
+type LogReader interface {
+	ReadLogs(ctx context.Context, name string, taskIndex int32, maxLines int) (string, error)
+}
+
+// OutputStreamer is implemented by runners that can copy a job's output to
+// out as it is printed, so it can be followed while the job runs.
+type OutputStreamer interface {
+	RunJobStream(ctx context.Context, prefix string, req JobRequest, out io.Writer) (string, error)
+}
+
+// Submitter is implemented by runners whose RunJob returns as soon as the job
+// is created at the provider, before it has run, unless the request sets
+// Wait. The job's outcome is read later with GetJobStatus.
+type Submitter interface {
+	Submits() bool
+}
+
+// ErrStoppedWaiting is returned by a Submitter's RunJob with Wait when its
+// context ended before the job did; the job is left running at the provider.
+var ErrStoppedWaiting = errors.New("stopped waiting for the job")
+
+// CommandRenderer is implemented by runners whose invocation can be shown as a
+// command line, or the spec submitted to the provider, e.g. to reproduce a
+// job outside Apollo. Such runners return the rendering from RunJob instead
+// of running a request with DryRun.
+type CommandRenderer interface {
+	RenderCommand(ctx context.Context, prefix string, req JobRequest) (string, error)
+}
+
+// ScheduleInfo is a schedule as the provider holds it.
+type ScheduleInfo struct {
+	Spec     string
+	TimeZone string
+	Target   string
+}
+
+// ScheduleInspector is implemented by runners whose schedules live with the
+// provider, so they can be compared against the store and repaired with
+// UpdateSchedule when they drift.
+type ScheduleInspector interface {
+	// GetSchedule returns the provider's current schedule for name.
+	GetSchedule(ctx context.Context, name string) (ScheduleInfo, error)
+	// DesiredSchedule returns what UpdateSchedule would set for spec, which
+	// may carry a CRON_TZ prefix.
+	DesiredSchedule(name, spec string) ScheduleInfo
+}
+
+// SchedulePauser is implemented by runners whose schedules live with the
+// provider and can be paused there without being deleted.
+type SchedulePauser interface {
+	PauseSchedule(ctx context.Context, name string) error
+	ResumeSchedule(ctx context.Context, name string) error
+}
+
+// JobScheduler is implemented by runners whose provider schedules submit the
+// job spec themselves, so a schedule can run exactly what RunJob would.
+type JobScheduler interface {
+	// ScheduleJob creates or updates the schedule req.Name to run req on
+	// req.ScheduleSpec in req.TimeZone.
+	ScheduleJob(ctx context.Context, prefix string, req JobRequest) error
+}
+
+// Locator is implemented by runners that can report where their jobs run, so
+// jobs can be told their execution context.
+type Locator interface {
+	// Location returns the runner's provider, e.g. "local" or "cloudrun",
+	// and its region, empty when it has none.
+	Location() (provider, region string)
+}
+
+// SecretSwapper is implemented by runners whose secrets can be replaced while
+// they serve jobs, so rotated secrets reach later runs without a restart.
+// Runs already started keep the secrets they were given.
+type SecretSwapper interface {
+	SetSecrets(secrets []models.Secret)
+}
+
+// ExitCode extracts the container exit code from a RunJob error, reporting
+// false when the failure was not a process exit (e.g. docker unavailable).
+func ExitCode(err error) (int, bool) {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), true
+	}
+	return 0, false
 }
@@ -0,0 +1,103 @@
+package runner
+
+import (
+	"context"
+	"path"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// jobNameLabel labels Batch jobs with the name of the Apollo job they run,
+// so DeleteJob finds the runs of a name it did not submit itself, e.g. those
+// of an earlier process or of a Cloud Scheduler schedule.
+const jobNameLabel = "apollo-job"
+
+// labelValuePattern matches the values Batch accepts for labels. Names
+// outside it are left unlabelled rather than mangled into another's.
+var labelValuePattern = regexp.MustCompile(`^[a-z0-9_-]{1,63}$`)
+
+// batchRun is a job this runner submitted, keyed by its Batch resource name.
+// Runs of a name get their own job IDs, so DeleteJob needs these to find every
+// run of a name, or a single run by the ID it was requested under.
+type batchRun struct {
+	name  string
+	jobID string
+}
+
+func (b *BatchRunner) track(resource, name, jobID string) {
+	b.runsMu.Lock()
+	defer b.runsMu.Unlock()
+	if b.runs == nil {
+		b.runs = map[string]batchRun{}
+	}
+	b.runs[resource] = batchRun{name: name, jobID: jobID}
+}
+
+func (b *BatchRunner) forget(resource string) {
+	b.runsMu.Lock()
+	defer b.runsMu.Unlock()
+	delete(b.runs, resource)
+}
+
+// runsOf returns the resource names of the tracked runs with the given job
+// ID or name. A job ID that was replaced after a quota retry still matches.
+func (b *BatchRunner) runsOf(name string) []string {
+	b.runsMu.Lock()
+	defer b.runsMu.Unlock()
+	var resources []string
+	for resource, run := range b.runs {
+		if run.name == name || run.jobID == name || path.Base(resource) == name {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// DeleteJob stops the unfinished runs of a job, given the job ID of a single
+// run or the job's name for all of them. Finished runs are kept so their
+// history stays in Batch. Runs this runner did not submit, e.g. those created
+// by Cloud Scheduler or before a restart, are found by their job name label,
+// else deleted by job ID.
+func (b *BatchRunner) DeleteJob(ctx context.Context, name string) error {
+	client, err := b.client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	resources := b.runsOf(name)
+	if len(resources) == 0 && labelValuePattern.MatchString(name) {
+		jobs, err := client.ListJobs(ctx, b.parent())
+		if err != nil {
+			return err
+		}
+		for _, job := range jobs {
+			if job.GetLabels()[jobNameLabel] == name {
+				resources = append(resources, job.GetName())
+			}
+		}
+	}
+	if len(resources) == 0 {
+		return client.DeleteJob(ctx, b.jobName(name))
+	}
+	for _, resource := range resources {
+		job, err := client.GetJob(ctx, resource)
+		switch {
+		case status.Code(err) == codes.NotFound:
+			b.forget(resource)
+			continue
+		case err != nil:
+			return err
+		case batchState(job.GetStatus().GetState()).Terminal():
+			b.forget(resource)
+			continue
+		}
+		if err := client.DeleteJob(ctx, resource); err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+		b.forget(resource)
+	}
+	return nil
+}
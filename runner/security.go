@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Security hardens a job's container. The zero value keeps docker's defaults.
+type Security struct {
+	// CapDrop and CapAdd drop and add back Linux capabilities, e.g. drop
+	// "ALL" and add "NET_BIND_SERVICE". The CAP_ prefix is optional.
+	CapDrop []string
+	CapAdd  []string
+	// NoNewPrivileges stops the job's processes from gaining privileges, e.g.
+	// through setuid binaries.
+	NoNewPrivileges bool
+}
+
+// securityFor is the security a run of req gets: the request's, else the
+// runner's default.
+func securityFor(req JobRequest, def *Security) *Security {
+	if req.Security != nil {
+		return req.Security
+	}
+	return def
+}
+
+// TightenSecurity returns the security of floor, the operator's, tightened
+// by req, a caller's: capabilities either drops are dropped, including those
+// floor adds back, and privileges are fixed if either says so. req may not add
+// back a capability floor does not, which fails with codes.InvalidArgument. A
+// nil req keeps floor.
+func TightenSecurity(floor, req *Security) (*Security, error) {
+	if req == nil {
+		return floor, nil
+	}
+	if floor == nil {
+		floor = &Security{}
+	}
+	allowed := map[string]bool{}
+	for _, name := range floor.CapAdd {
+		allowed[capabilityName(name)] = true
+	}
+	for _, name := range req.CapAdd {
+		if !allowed[capabilityName(name)] {
+			return nil, status.Errorf(codes.InvalidArgument, "security: cap_add %s is not allowed; requests may only drop capabilities", name)
+		}
+	}
+	out := &Security{
+		CapDrop:         slices.Clone(floor.CapDrop),
+		NoNewPrivileges: floor.NoNewPrivileges || req.NoNewPrivileges,
+	}
+	reqDrops := map[string]bool{}
+	for _, name := range req.CapDrop {
+		c := capabilityName(name)
+		if !reqDrops[c] && !slices.ContainsFunc(floor.CapDrop, func(d string) bool { return capabilityName(d) == c }) {
+			out.CapDrop = append(out.CapDrop, name)
+		}
+		reqDrops[c] = true
+	}
+	for _, name := range floor.CapAdd {
+		if !reqDrops["ALL"] && !reqDrops[capabilityName(name)] {
+			out.CapAdd = append(out.CapAdd, name)
+		}
+	}
+	return out, nil
+}
+
+// capabilityName normalizes a capability name to docker's, e.g. "cap_chown"
+// to "CHOWN".
+func capabilityName(name string) string {
+	return strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "CAP_")
+}
+
+// capabilities are the Linux capability names docker accepts, without the
+// CAP_ prefix.
+var capabilities = map[string]bool{
+	"ALL": true, "AUDIT_CONTROL": true, "AUDIT_READ": true, "AUDIT_WRITE": true,
+	"BLOCK_SUSPEND": true, "BPF": true, "CHECKPOINT_RESTORE": true, "CHOWN": true,
+	"DAC_OVERRIDE": true, "DAC_READ_SEARCH": true, "FOWNER": true, "FSETID": true,
+	"IPC_LOCK": true, "IPC_OWNER": true, "KILL": true, "LEASE": true,
+	"LINUX_IMMUTABLE": true, "MAC_ADMIN": true, "MAC_OVERRIDE": true, "MKNOD": true,
+	"NET_ADMIN": true, "NET_BIND_SERVICE": true, "NET_BROADCAST": true, "NET_RAW": true,
+	"PERFMON": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true,
+	"SETUID": true, "SYS_ADMIN": true, "SYS_BOOT": true, "SYS_CHROOT": true,
+	"SYS_MODULE": true, "SYS_NICE": true, "SYS_PACCT": true, "SYS_PTRACE": true,
+	"SYS_RAWIO": true, "SYS_RESOURCE": true, "SYS_TIME": true, "SYS_TTY_CONFIG": true,
+	"SYSLOG": true, "WAKE_ALARM": true,
+}
+
+// dockerArgs returns the `docker run` options applying s, rejecting unknown
+// capability names with codes.InvalidArgument. A nil s adds none.
+func (s *Security) dockerArgs() ([]string, error) {
+	if s == nil {
+		return nil, nil
+	}
+	var args []string
+	for _, c := range []struct {
+		flag  string
+		names []string
+	}{{"--cap-drop", s.CapDrop}, {"--cap-add", s.CapAdd}} {
+		for _, name := range c.names {
+			capability := capabilityName(name)
+			if !capabilities[capability] {
+				return nil, status.Errorf(codes.InvalidArgument, "%s: unknown Linux capability %q", c.flag, name)
+			}
+			args = append(args, c.flag, capability)
+		}
+	}
+	if s.NoNewPrivileges {
+		args = append(args, "--security-opt", "no-new-privileges")
+	}
+	return args, nil
+}
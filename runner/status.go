@@ -0,0 +1,238 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// JobState is the provider-reported lifecycle state of a job.
+type JobState string
+
+const (
+	JobStatePending   JobState = "pending"
+	JobStateRunning   JobState = "running"
+	JobStateSucceeded JobState = "succeeded"
+	JobStateFailed    JobState = "failed"
+)
+
+// Terminal reports whether the job has finished.
+func (s JobState) Terminal() bool {
+	return s == JobStateSucceeded || s == JobStateFailed
+}
+
+// JobStatus is a job's state as the provider reports it.
+type JobStatus struct {
+	State      JobState
+	ExitCode   int32
+	StartedAt  time.Time
+	FinishedAt time.Time // zero until the job is terminal
+}
+
+// JobInfo is a job as the provider lists it.
+type JobInfo struct {
+	Name      string // the job ID GetJobStatus accepts
+	State     JobState
+	CreatedAt time.Time
+}
+
+// ListJobs lists every Batch job in the runner's project and region,
+// including those Apollo did not submit.
+func (b *BatchRunner) ListJobs(ctx context.Context) ([]JobInfo, error) {
+	client, err := b.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	jobs, err := client.ListJobs(ctx, b.parent())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]JobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		info := JobInfo{Name: path.Base(job.GetName()), State: batchState(job.GetStatus().GetState())}
+		if job.GetCreateTime() != nil {
+			info.CreatedAt = job.GetCreateTime().AsTime()
+		}
+		out = append(out, info)
+	}
+	return out, nil
+}
+
+// GetJobStatus reads a Batch job's state. Batch reports no job-level exit
+// code, so ExitCode is that of the last task execution it recorded.
+func (b *BatchRunner) GetJobStatus(ctx context.Context, name string) (JobStatus, error) {
+	client, err := b.client(ctx)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	defer client.Close()
+
+	if !strings.HasPrefix(name, "projects/") {
+		name = b.jobName(name)
+	}
+	job, err := client.GetJob(ctx, name)
+	if err != nil {
+		return JobStatus{}, err
+	}
+
+	st := JobStatus{State: batchState(job.GetStatus().GetState())}
+	if st.State.Terminal() {
+		// nothing left to stop
+		b.forget(name)
+	}
+	if job.GetCreateTime() != nil {
+		st.StartedAt = job.GetCreateTime().AsTime()
+	}
+	if st.State.Terminal() && job.GetUpdateTime() != nil {
+		st.FinishedAt = job.GetUpdateTime().AsTime()
+	}
+	for _, ev := range job.GetStatus().GetStatusEvents() {
+		if ev.GetTaskExecution() != nil {
+			st.ExitCode = ev.GetTaskExecution().GetExitCode()
+		}
+	}
+	return st, nil
+}
+
+func batchState(s batchpb.JobStatus_State) JobState {
+	switch s {
+	case batchpb.JobStatus_RUNNING, batchpb.JobStatus_DELETION_IN_PROGRESS:
+		return JobStateRunning
+	case batchpb.JobStatus_SUCCEEDED:
+		return JobStateSucceeded
+	case batchpb.JobStatus_FAILED:
+		return JobStateFailed
+	default:
+		return JobStatePending
+	}
+}
+
+// localStatusRetention is how long LocalRunner remembers finished jobs.
+const localStatusRetention = time.Hour
+
+// track records the status of a job this runner started, forgetting jobs
+// that finished long ago.
+func (l *LocalRunner) track(id string, update func(*JobStatus)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.jobs == nil {
+		l.jobs = map[string]*JobStatus{}
+	}
+	st, ok := l.jobs[id]
+	if !ok {
+		st = &JobStatus{State: JobStatePending}
+		l.jobs[id] = st
+	}
+	update(st)
+	for name, other := range l.jobs {
+		if other.State.Terminal() && time.Since(other.FinishedAt) > localStatusRetention {
+			delete(l.jobs, name)
+		}
+	}
+}
+
+func (l *LocalRunner) started(id string, at time.Time) {
+	l.track(id, func(st *JobStatus) { st.State, st.StartedAt = JobStateRunning, at })
+}
+
+func (l *LocalRunner) finished(id string, runErr error) {
+	l.track(id, func(st *JobStatus) {
+		st.State, st.FinishedAt = JobStateSucceeded, time.Now()
+		if runErr != nil {
+			st.State = JobStateFailed
+			code, _ := ExitCode(runErr)
+			st.ExitCode = int32(code)
+		}
+	})
+}
+
+// GetJobStatus reports on a job this runner started. Jobs it does not
+// remember, e.g. after a restart, are looked up by their container name;
+// containers are removed when they exit, so those jobs are only found while
+// running.
+func (l *LocalRunner) GetJobStatus(ctx context.Context, name string) (JobStatus, error) {
+	l.mu.Lock()
+	st, ok := l.jobs[name]
+	l.mu.Unlock()
+	if ok {
+		return *st, nil
+	}
+
+	out, err := l.command(ctx, "inspect", "--format",
+		"{{.State.Status}}|{{.State.ExitCode}}|{{.State.StartedAt}}|{{.State.FinishedAt}}", containerName(name)).CombinedOutput()
+	if err != nil {
+		// docker says "No such object", podman "no such object"
+		if strings.Contains(strings.ToLower(string(out)), "no such object") {
+			return JobStatus{}, status.Errorf(codes.NotFound, "job %s not found", name)
+		}
+		return JobStatus{}, fmt.Errorf("%s inspect %s: %w: %s", l.runtime(), name, err, out)
+	}
+	return parseContainerState(strings.TrimSpace(string(out)))
+}
+
+// jobIDLabel labels the containers LocalRunner starts with their job ID, so
+// ListJobs can find them.
+const jobIDLabel = "apollo.job-id"
+
+// ListJobs lists the running containers of Apollo jobs. Containers are
+// started with --rm, so finished jobs are gone.
+func (l *LocalRunner) ListJobs(ctx context.Context) ([]JobInfo, error) {
+	out, err := l.command(ctx, "ps", "--no-trunc",
+		"--filter", "label="+jobIDLabel,
+		"--format", `{{.Label "`+jobIDLabel+`"}}|{{.CreatedAt}}`).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s ps: %w: %s", l.runtime(), err, out)
+	}
+	var jobs []JobInfo
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		id, created, ok := strings.Cut(line, "|")
+		if !ok {
+			continue
+		}
+		info := JobInfo{Name: id, State: JobStateRunning}
+		// e.g. "2024-05-01 12:00:00 +0000 UTC"
+		info.CreatedAt, _ = time.Parse("2006-01-02 15:04:05 -0700 MST", created)
+		jobs = append(jobs, info)
+	}
+	return jobs, nil
+}
+
+// parseContainerState maps the `docker inspect` state fields GetJobStatus
+// asks for onto a JobStatus.
+func parseContainerState(s string) (JobStatus, error) {
+	fields := strings.Split(s, "|")
+	if len(fields) != 4 {
+		return JobStatus{}, fmt.Errorf("unexpected container state %q", s)
+	}
+	code, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return JobStatus{}, fmt.Errorf("unexpected container exit code %q", fields[1])
+	}
+	var st JobStatus
+	switch fields[0] {
+	case "created":
+		st.State = JobStatePending
+	case "exited", "dead":
+		st.State, st.ExitCode = JobStateSucceeded, int32(code)
+		if code != 0 {
+			st.State = JobStateFailed
+		}
+	default:
+		st.State = JobStateRunning
+	}
+	// docker reports "0001-01-01T00:00:00Z" for times that did not happen yet
+	st.StartedAt, _ = time.Parse(time.RFC3339Nano, fields[2])
+	if st.State.Terminal() {
+		st.FinishedAt, _ = time.Parse(time.RFC3339Nano, fields[3])
+	}
+	return st, nil
+}
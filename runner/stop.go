@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stopSignals are the signal names docker accepts, without the SIG prefix.
+var stopSignals = map[string]bool{
+	"ABRT": true, "ALRM": true, "BUS": true, "CHLD": true, "CONT": true,
+	"FPE": true, "HUP": true, "ILL": true, "INT": true, "IO": true,
+	"IOT": true, "KILL": true, "PIPE": true, "POLL": true, "PROF": true,
+	"PWR": true, "QUIT": true, "SEGV": true, "STKFLT": true, "STOP": true,
+	"SYS": true, "TERM": true, "TRAP": true, "TSTP": true, "TTIN": true,
+	"TTOU": true, "URG": true, "USR1": true, "USR2": true, "VTALRM": true,
+	"WINCH": true, "XCPU": true, "XFSZ": true,
+}
+
+// stopSignalFor is the signal that stops a run of req: the request's, else
+// the runner's default, else "" to keep the image's, which is SIGTERM unless
+// it sets STOPSIGNAL. Names are normalised to their SIG form, e.g. "usr1" to
+// "SIGUSR1"; unknown ones are rejected with codes.InvalidArgument.
+func stopSignalFor(req JobRequest, def string) (string, error) {
+	signal := req.StopSignal
+	if signal == "" {
+		signal = def
+	}
+	if signal == "" {
+		return "", nil
+	}
+	name := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(signal)), "SIG")
+	if stopSignals[name] {
+		return "SIG" + name, nil
+	}
+	// real-time signals, e.g. SIGRTMIN+3
+	if n, ok := strings.CutPrefix(name, "RTMIN+"); ok {
+		if i, err := strconv.Atoi(n); err == nil && i >= 0 && i <= 30 {
+			return "SIG" + name, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown stop signal %q", signal)
+}
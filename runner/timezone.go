@@ -0,0 +1,45 @@
+package runner
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SplitTimeZone splits a "CRON_TZ=" or "TZ=" prefix, as the cron parser
+// accepts it, off spec, returning the time zone and the bare spec. tz is
+// empty when spec has no prefix.
+func SplitTimeZone(spec string) (tz, bare string) {
+	spec = strings.TrimSpace(spec)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if rest, ok := strings.CutPrefix(spec, prefix); ok {
+			tz, bare, _ = strings.Cut(rest, " ")
+			return tz, strings.TrimSpace(bare)
+		}
+	}
+	return "", spec
+}
+
+// ZonedSpec prefixes spec with CRON_TZ=tz, the form UpdateSchedule and
+// DesiredSchedule take a schedule's time zone in. An empty tz leaves spec
+// unchanged.
+func ZonedSpec(spec, tz string) string {
+	if tz == "" {
+		return spec
+	}
+	return "CRON_TZ=" + tz + " " + spec
+}
+
+// checkTimeZone rejects a time zone the time zone database does not know,
+// before it reaches a provider that would fail on it less clearly.
+func checkTimeZone(tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+		return status.Errorf(codes.InvalidArgument, "unknown time zone %q: want an IANA time zone such as \"America/New_York\"", tz)
+	}
+	return nil
+}
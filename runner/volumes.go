@@ -0,0 +1,42 @@
+package runner
+
+import (
+	"path"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VolumeMount bind mounts a host directory or file into the job's container,
+// e.g. input data or a directory to collect output files in. On Batch the
+// host is the job's VM.
+type VolumeMount struct {
+	HostPath      string
+	ContainerPath string
+	ReadOnly      bool
+}
+
+// volumeSpecs returns the `docker run --volume` specs of req's override
+// volumes, host:container[:ro]. Paths must be absolute and free of the ":"
+// and "," docker splits specs on; others are rejected with
+// codes.InvalidArgument.
+func volumeSpecs(req JobRequest) ([]string, error) {
+	if req.Overrides == nil {
+		return nil, nil
+	}
+	var specs []string
+	for _, v := range req.Overrides.Volumes {
+		for _, p := range []string{v.HostPath, v.ContainerPath} {
+			if !path.IsAbs(p) || strings.ContainsAny(p, ":,") {
+				return nil, status.Errorf(codes.InvalidArgument, "volume %s:%s: paths must be absolute and must not contain ':' or ','", v.HostPath, v.ContainerPath)
+			}
+		}
+		spec := path.Clean(v.HostPath) + ":" + path.Clean(v.ContainerPath)
+		if v.ReadOnly {
+			spec += ":ro"
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
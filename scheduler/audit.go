@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+)
+
+// AuditEvent records one call of a mutating RPC.
+type AuditEvent struct {
+	// ID sorts in the order events were recorded
+	ID     string
+	At     int64  // unix seconds
+	Method string // full gRPC method, e.g. /jobs.JobsService/RunJob
+	Caller string
+	// Args is the request as JSON, with secret values redacted
+	Args string
+	// Outcome is the gRPC status code the call returned, e.g. "OK"
+	Outcome string
+	Error   string
+}
+
+// AuditFilter narrows ListAuditEvents. Zero values match everything.
+type AuditFilter struct {
+	Method string
+	Caller string
+	Since  int64 // at >= Since (unix seconds)
+	Until  int64 // at < Until (unix seconds)
+	Limit  int
+	Offset int
+}
+
+// AddAuditEvent appends an event to the audit log.
+func (s *SQLStore) AddAuditEvent(ctx context.Context, e AuditEvent) error {
+	query := `INSERT INTO apollo_audit_events (id, at, method, caller, args, outcome, error)
+        VALUES (?, ?, ?, ?, ?, ?, ?)`
+	if s.IsPostgres() {
+		query = `INSERT INTO apollo_audit_events (id, at, method, caller, args, outcome, error)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	}
+	_, err := s.db.ExecContext(ctx, query, e.ID, e.At, e.Method, e.Caller, e.Args, e.Outcome, e.Error)
+	return err
+}
+
+// ListAuditEvents returns the audit events matching f, most recent first.
+func (s *SQLStore) ListAuditEvents(ctx context.Context, f AuditFilter) ([]AuditEvent, error) {
+	var where []string
+	var args []any
+	arg := s.argFunc(&args)
+	if f.Method != "" {
+		where = append(where, "method = "+arg(f.Method))
+	}
+	if f.Caller != "" {
+		where = append(where, "caller = "+arg(f.Caller))
+	}
+	if f.Since > 0 {
+		where = append(where, "at >= "+arg(f.Since))
+	}
+	if f.Until > 0 {
+		where = append(where, "at < "+arg(f.Until))
+	}
+	query := `SELECT id, at, method, caller, args, outcome, error FROM apollo_audit_events`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY at DESC, id DESC" + s.pageClause(f.Limit, f.Offset, arg)
+
+	rows, err := s.reader(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AuditEvent
+	for rows.Next() {
+		var e AuditEvent
+		if err := rows.Scan(&e.ID, &e.At, &e.Method, &e.Caller, &e.Args, &e.Outcome, &e.Error); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
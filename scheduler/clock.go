@@ -0,0 +1,20 @@
+package scheduler
+
+import "time"
+
+// Clock tells the time. Scheduling, retention and timestamping code reads
+// the time through a Clock so tests can substitute a fake one; cron ticks
+// themselves always follow the system clock.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the real clock.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
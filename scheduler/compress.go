@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+)
+
+// encodeResult gzips and base64-encodes a result when compression is enabled,
+// reporting whether the stored value is compressed. Base64 keeps the value
+// valid in TEXT columns on every driver.
+func (s *SQLStore) encodeResult(result string) (string, bool, error) {
+	if !s.opts.CompressResults || result == "" {
+		return result, false, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(result)); err != nil {
+		return "", false, err
+	}
+	if err := zw.Close(); err != nil {
+		return "", false, err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), true, nil
+}
+
+// decodeResult reverses encodeResult. Rows written without compression are
+// returned unchanged, so existing history stays readable.
+func decodeResult(stored string, compressed bool) (string, error) {
+	if !compressed {
+		return stored, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return "", err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// encodeLabels stores a label map in a text column; no labels is "".
+func encodeLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(labels)
+	return string(b)
+}
+
+func decodeLabels(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(s), &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// setExecutionLabels writes an execution's labels to the label table, where
+// they can be filtered on.
+func (s *SQLStore) setExecutionLabels(ctx context.Context, id string, labels map[string]string) error {
+	query := `INSERT INTO apollo_execution_labels (execution_id, key, value) VALUES (?, ?, ?)
+        ON CONFLICT (execution_id, key) DO UPDATE SET value = EXCLUDED.value`
+	if s.IsPostgres() {
+		query = `INSERT INTO apollo_execution_labels (execution_id, key, value) VALUES ($1, $2, $3)
+        ON CONFLICT (execution_id, key) DO UPDATE SET value = EXCLUDED.value`
+	}
+	if s.IsMySQL() {
+		query = "INSERT INTO apollo_execution_labels (execution_id, `key`, value) VALUES (?, ?, ?)\n" +
+			`        ON DUPLICATE KEY UPDATE value = VALUES(value)`
+	}
+	for k, v := range labels {
+		if _, err := s.db.ExecContext(ctx, query, id, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelConditions returns a WHERE condition per label an execution must
+// carry, with arg adding the bind parameters.
+func labelConditions(labels map[string]string, arg func(any) string) []string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var where []string
+	for _, k := range keys {
+		where = append(where, fmt.Sprintf(`EXISTS (SELECT 1 FROM apollo_execution_labels l
+            WHERE l.execution_id = apollo_executions.id AND l.key = %s AND l.value = %s)`, arg(k), arg(labels[k])))
+	}
+	return where
+}
+
+// loadExecutionLabels fills in the labels of the given executions.
+func (s *SQLStore) loadExecutionLabels(ctx context.Context, execs []ExecutionRecord) error {
+	return inBatches(execs, func(batch []ExecutionRecord) error {
+		return s.loadExecutionLabelsBatch(ctx, batch)
+	})
+}
+
+func (s *SQLStore) loadExecutionLabelsBatch(ctx context.Context, execs []ExecutionRecord) error {
+	index := make(map[string]int, len(execs))
+	placeholders := make([]string, 0, len(execs))
+	args := make([]any, 0, len(execs))
+	arg := s.argFunc(&args)
+	for i, e := range execs {
+		index[e.ID] = i
+		placeholders = append(placeholders, arg(e.ID))
+	}
+	// l.key rather than key, which MySQL reserves
+	rows, err := s.db.QueryContext(ctx, `SELECT l.execution_id, l.key, l.value FROM apollo_execution_labels l
+        WHERE l.execution_id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, k, v string
+		if err := rows.Scan(&id, &k, &v); err != nil {
+			return err
+		}
+		e := &execs[index[id]]
+		if e.Labels == nil {
+			e.Labels = map[string]string{}
+		}
+		e.Labels[k] = v
+	}
+	return rows.Err()
+}
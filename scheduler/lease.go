@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// AcquireLease claims key for holder until ttl elapses. It reports false when
+// another holder already owns an unexpired lease on key, which lets replicas
+// sharing a store agree on who runs a given tick.
+func (s *SQLStore) AcquireLease(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	now := s.opts.Clock.Now()
+	cleanup := `DELETE FROM apollo_leases WHERE expires_at < ?`
+	insert := `INSERT INTO apollo_leases (key, holder, expires_at) VALUES (?, ?, ?)
+        ON CONFLICT(key) DO NOTHING`
+	if s.IsPostgres() {
+		cleanup = `DELETE FROM apollo_leases WHERE expires_at < $1`
+		insert = `INSERT INTO apollo_leases (key, holder, expires_at) VALUES ($1, $2, $3)
+        ON CONFLICT(key) DO NOTHING`
+	}
+	if s.IsMySQL() {
+		// a no-op update affects no rows, like DO NOTHING
+		insert = "INSERT INTO apollo_leases (`key`, holder, expires_at) VALUES (?, ?, ?)\n" +
+			"        ON DUPLICATE KEY UPDATE `key` = `key`"
+	}
+	if _, err := s.db.ExecContext(ctx, cleanup, now.Unix()); err != nil {
+		return false, err
+	}
+	res, err := s.db.ExecContext(ctx, insert, key, holder, now.Add(ttl).Unix())
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
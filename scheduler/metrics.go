@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MetricFilter matches executions whose metric Key compares to Value with
+// Op, one of =, !=, <, <=, > and >=.
+type MetricFilter struct {
+	Key   string
+	Op    string
+	Value float64
+}
+
+// metricOps are the comparisons a MetricFilter may use, longest first so
+// ParseMetricFilter prefers <= over <.
+var metricOps = []string{"!=", "<=", ">=", "=", "<", ">"}
+
+// ParseMetricFilter parses a filter such as "rows>=1000".
+func ParseMetricFilter(s string) (MetricFilter, error) {
+	i := strings.IndexAny(s, "!=<>")
+	if i <= 0 {
+		return MetricFilter{}, fmt.Errorf("invalid metric filter %q: want a name, a comparison and a number, e.g. rows>=1000", s)
+	}
+	f := MetricFilter{Key: strings.TrimSpace(s[:i])}
+	for _, op := range metricOps {
+		if strings.HasPrefix(s[i:], op) {
+			f.Op = op
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(s[i+len(f.Op):]), 64)
+	if f.Key == "" || f.Op == "" || err != nil {
+		return MetricFilter{}, fmt.Errorf("invalid metric filter %q: want a name, a comparison and a number, e.g. rows>=1000", s)
+	}
+	f.Value = value
+	return f, nil
+}
+
+// setExecutionMetrics writes an execution's metrics to the metric table,
+// where they can be filtered on.
+func (s *SQLStore) setExecutionMetrics(ctx context.Context, id string, metrics map[string]float64) error {
+	query := `INSERT INTO apollo_execution_metrics (execution_id, key, value) VALUES (?, ?, ?)
+        ON CONFLICT (execution_id, key) DO UPDATE SET value = EXCLUDED.value`
+	if s.IsPostgres() {
+		query = `INSERT INTO apollo_execution_metrics (execution_id, key, value) VALUES ($1, $2, $3)
+        ON CONFLICT (execution_id, key) DO UPDATE SET value = EXCLUDED.value`
+	}
+	if s.IsMySQL() {
+		query = "INSERT INTO apollo_execution_metrics (execution_id, `key`, value) VALUES (?, ?, ?)\n" +
+			`        ON DUPLICATE KEY UPDATE value = VALUES(value)`
+	}
+	for k, v := range metrics {
+		if _, err := s.db.ExecContext(ctx, query, id, k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metricConditions returns a WHERE condition per metric filter an execution
+// must satisfy, with arg adding the bind parameters. Unknown comparisons are
+// rejected, as they are spliced into the query.
+func metricConditions(filters []MetricFilter, arg func(any) string) ([]string, error) {
+	var where []string
+	for _, f := range filters {
+		if !slices.Contains(metricOps, f.Op) {
+			return nil, fmt.Errorf("invalid metric comparison %q", f.Op)
+		}
+		op := f.Op
+		if op == "!=" {
+			op = "<>"
+		}
+		where = append(where, fmt.Sprintf(`EXISTS (SELECT 1 FROM apollo_execution_metrics m
+            WHERE m.execution_id = apollo_executions.id AND m.key = %s AND m.value %s %s)`, arg(f.Key), op, arg(f.Value)))
+	}
+	return where, nil
+}
+
+// loadExecutionMetrics fills in the metrics of the given executions.
+func (s *SQLStore) loadExecutionMetrics(ctx context.Context, execs []ExecutionRecord) error {
+	return inBatches(execs, func(batch []ExecutionRecord) error {
+		return s.loadExecutionMetricsBatch(ctx, batch)
+	})
+}
+
+func (s *SQLStore) loadExecutionMetricsBatch(ctx context.Context, execs []ExecutionRecord) error {
+	index := make(map[string]int, len(execs))
+	placeholders := make([]string, 0, len(execs))
+	args := make([]any, 0, len(execs))
+	arg := s.argFunc(&args)
+	for i, e := range execs {
+		index[e.ID] = i
+		placeholders = append(placeholders, arg(e.ID))
+	}
+	// m.key rather than key, which MySQL reserves
+	rows, err := s.db.QueryContext(ctx, `SELECT m.execution_id, m.key, m.value FROM apollo_execution_metrics m
+        WHERE m.execution_id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, k string
+		var v float64
+		if err := rows.Scan(&id, &k, &v); err != nil {
+			return err
+		}
+		e := &execs[index[id]]
+		if e.Metrics == nil {
+			e.Metrics = map[string]float64{}
+		}
+		e.Metrics[k] = v
+	}
+	return rows.Err()
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// mysqlTable holds the table options of every MySQL table: a binary
+// collation, so names and IDs compare case-sensitively as they do in sqlite
+// and postgres.
+const mysqlTable = ` DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin`
+
+// createMySQLTables is createTables for MySQL, which needs bounded VARCHARs
+// for keys and indexed columns, 64-bit timestamps, a quoted key column and
+// its indexes declared with the table.
+func createMySQLTables(db *sql.DB) error {
+	for _, ddl := range []string{
+		`CREATE TABLE IF NOT EXISTS apollo_jobs (
+        name VARCHAR(255) PRIMARY KEY,
+        command TEXT NOT NULL,
+        args_base64 TEXT,
+        cron_spec TEXT NOT NULL,
+        cpu TEXT,
+        memory TEXT
+    )` + mysqlTable,
+		`CREATE TABLE IF NOT EXISTS apollo_executions (
+        id VARCHAR(255) PRIMARY KEY,
+        name VARCHAR(255) NOT NULL,
+        command TEXT NOT NULL,
+        args_base64 TEXT,
+        cpu TEXT,
+        memory TEXT,
+        status TEXT,
+        error TEXT,
+        result LONGTEXT,
+        started_at BIGINT,
+        finished_at BIGINT,
+        INDEX idx_apollo_executions_name_started (name, started_at)
+    )` + mysqlTable,
+		`CREATE TABLE IF NOT EXISTS apollo_leases (
+        ` + "`key`" + ` VARCHAR(255) PRIMARY KEY,
+        holder TEXT NOT NULL,
+        expires_at BIGINT NOT NULL
+    )` + mysqlTable,
+		`CREATE TABLE IF NOT EXISTS apollo_audit_events (
+        id VARCHAR(255) PRIMARY KEY,
+        at BIGINT NOT NULL,
+        method VARCHAR(255) NOT NULL,
+        caller VARCHAR(255) NOT NULL,
+        args LONGTEXT NOT NULL,
+        outcome TEXT NOT NULL,
+        error TEXT NOT NULL,
+        INDEX idx_apollo_audit_events_at (at)
+    )` + mysqlTable,
+		`CREATE TABLE IF NOT EXISTS apollo_settings (
+        ` + "`key`" + ` VARCHAR(255) PRIMARY KEY,
+        value TEXT NOT NULL
+    )` + mysqlTable,
+		`CREATE TABLE IF NOT EXISTS apollo_execution_labels (
+        execution_id VARCHAR(255) NOT NULL,
+        ` + "`key`" + ` VARCHAR(255) NOT NULL,
+        value VARCHAR(255) NOT NULL,
+        PRIMARY KEY (execution_id, ` + "`key`" + `),
+        INDEX idx_apollo_execution_labels_key_value (` + "`key`" + `, value)
+    )` + mysqlTable,
+		`CREATE TABLE IF NOT EXISTS apollo_execution_metrics (
+        execution_id VARCHAR(255) NOT NULL,
+        ` + "`key`" + ` VARCHAR(255) NOT NULL,
+        value DOUBLE NOT NULL,
+        PRIMARY KEY (execution_id, ` + "`key`" + `),
+        INDEX idx_apollo_execution_metrics_key_value (` + "`key`" + `, value)
+    )` + mysqlTable,
+	} {
+		if _, err := db.Exec(ddl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMySQLColumn is addColumn for MySQL, which has no ADD COLUMN IF NOT
+// EXISTS. It converts the column type to MySQL's: timestamps need BIGINT, and
+// text defaults must be expressions.
+func addMySQLColumn(db *sql.DB, table, column, ddl string) error {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.columns
+        WHERE table_schema = DATABASE() AND table_name = ? AND column_name = ?`, table, column).Scan(&n); err != nil || n > 0 {
+		return err
+	}
+	ddl = strings.Replace(ddl, "INTEGER", "BIGINT", 1)
+	ddl = strings.Replace(ddl, "TEXT NOT NULL DEFAULT ''", "TEXT NOT NULL DEFAULT ('')", 1)
+	_, err := db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, ddl))
+	return err
+}
+
+// createMySQLIndex is createIndex for MySQL, which has no CREATE INDEX IF
+// NOT EXISTS.
+func createMySQLIndex(db *sql.DB, name, table, columns string) error {
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.statistics
+        WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?`, table, name).Scan(&n); err != nil || n > 0 {
+		return err
+	}
+	_, err := db.Exec(fmt.Sprintf(`CREATE INDEX %s ON %s(%s)`, name, table, columns))
+	return err
+}
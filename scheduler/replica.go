@@ -0,0 +1,24 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+)
+
+type replicaKey struct{}
+
+// WithReplica marks the reads made with ctx as tolerant of replication lag,
+// so a store with a read replica serves them from it. Reporting reads opt in;
+// reads that must see a write just made, or that decide what the server does
+// next, keep reading the primary.
+func WithReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaKey{}, true)
+}
+
+// reader is the pool that serves a read made with ctx.
+func (s *SQLStore) reader(ctx context.Context) *sql.DB {
+	if s.replica != nil && ctx.Value(replicaKey{}) == true {
+		return s.replica
+	}
+	return s.db
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeExecutions deletes the executions that finished before olderThan,
+// along with their labels and metrics, and returns how many were removed.
+// Executions still running are kept however old they are.
+func (s *SQLStore) PurgeExecutions(ctx context.Context, olderThan time.Time) (int64, error) {
+	old := `SELECT id FROM apollo_executions WHERE finished_at > 0 AND finished_at < ?`
+	purge := `DELETE FROM apollo_executions WHERE finished_at > 0 AND finished_at < ?`
+	if s.IsPostgres() {
+		old = `SELECT id FROM apollo_executions WHERE finished_at > 0 AND finished_at < $1`
+		purge = `DELETE FROM apollo_executions WHERE finished_at > 0 AND finished_at < $1`
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	cutoff := olderThan.Unix()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM apollo_execution_labels WHERE execution_id IN (`+old+`)`, cutoff); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM apollo_execution_metrics WHERE execution_id IN (`+old+`)`, cutoff); err != nil {
+		return 0, err
+	}
+	res, err := tx.ExecContext(ctx, purge, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return n, tx.Commit()
+}
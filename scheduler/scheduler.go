@@ -2,46 +2,253 @@ package scheduler
 
 import (
 	"context"
+	"log"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	cron "github.com/robfig/cron/v3"
 )
 
 type JobFunc func(context.Context)
 
+// specParser matches the parser installed by cron.WithSeconds in New.
+var specParser = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 type Scheduler struct {
 	mu      sync.Mutex
 	cron    *cron.Cron
-	entries map[string]cron.EntryID
+	entries map[string]*entry
+	// running marks the SkipIfRunning entries with an invocation under way,
+	// by name, so re-registering an entry does not lose track of its run
+	running map[string]*atomic.Bool
+}
+
+// entry is a named schedule. A paused entry keeps its schedule and job but
+// has no cron entry.
+type entry struct {
+	id       cron.EntryID
+	schedule cron.Schedule
+	job      cron.Job
+	paused   bool
 }
 
 func New() *Scheduler {
 	c := cron.New(cron.WithSeconds())
 	c.Start()
-	return &Scheduler{cron: c, entries: map[string]cron.EntryID{}}
+	return &Scheduler{cron: c, entries: map[string]*entry{}, running: map[string]*atomic.Bool{}}
 }
 
-// Schedule uses standard cron syntax (with seconds): "* * * * * *"
-func (s *Scheduler) Schedule(name string, spec string, fn JobFunc) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	if id, ok := s.entries[name]; ok {
-		s.cron.Remove(id)
-		delete(s.entries, name)
+// Option tunes how a scheduled entry is invoked.
+type Option func(*entryOptions)
+
+type entryOptions struct {
+	timeout       time.Duration
+	skipIfRunning bool
+	onSkip        func()
+	timeZone      string
+	paused        bool
+}
+
+// WithTimeout bounds every invocation of the entry with a context deadline.
+// A zero duration leaves invocations unbounded.
+func WithTimeout(d time.Duration) Option {
+	return func(o *entryOptions) { o.timeout = d }
+}
+
+// SkipIfRunning skips a tick while the entry's previous invocation is still
+// running, so a job slower than its schedule does not pile up. onSkip, when
+// not nil, is called for every skipped tick.
+func SkipIfRunning(onSkip func()) Option {
+	return func(o *entryOptions) { o.skipIfRunning, o.onSkip = true, onSkip }
+}
+
+// WithTimeZone evaluates the entry's spec in the IANA time zone tz, e.g.
+// "Asia/Kolkata", instead of the process's local time. An empty tz keeps local
+// time.
+func WithTimeZone(tz string) Option {
+	return func(o *entryOptions) { o.timeZone = tz }
+}
+
+// StartPaused registers the entry paused; it does not fire until Resume.
+func StartPaused() Option {
+	return func(o *entryOptions) { o.paused = true }
+}
+
+// Schedule uses standard cron syntax (with seconds): "* * * * * *". A
+// "CRON_TZ=<zone>" prefix, or WithTimeZone, sets the time zone it runs in.
+func (s *Scheduler) Schedule(name string, spec string, fn JobFunc, opts ...Option) error {
+	var o entryOptions
+	for _, opt := range opts {
+		opt(&o)
 	}
-	id, err := s.cron.AddFunc(spec, func() { fn(context.Background()) })
+	sched, err := specParser.Parse(zonedSpec(spec, o.timeZone))
 	if err != nil {
 		return err
 	}
-	s.entries[name] = id
+	job := func() { invoke(fn, o) }
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o.skipIfRunning {
+		running, ok := s.running[name]
+		if !ok {
+			running = new(atomic.Bool)
+			s.running[name] = running
+		}
+		job = skipIfRunning(name, running, job, o.onSkip)
+	}
+	s.remove(name)
+	s.add(name, &entry{schedule: sched, job: cron.FuncJob(job), paused: o.paused})
 	return nil
 }
 
-func (s *Scheduler) Delete(name string) {
+// ScheduleAt runs fn once at the given time. An entry whose time has already
+// passed never fires; run it directly instead.
+func (s *Scheduler) ScheduleAt(name string, at time.Time, fn JobFunc, opts ...Option) {
+	var o entryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if id, ok := s.entries[name]; ok {
-		s.cron.Remove(id)
+	s.remove(name)
+	s.add(name, &entry{schedule: onceAt(at), job: cron.FuncJob(func() { invoke(fn, o) }), paused: o.paused})
+}
+
+// add tracks e under name, starting it unless it is paused. s.mu must be held.
+func (s *Scheduler) add(name string, e *entry) {
+	if !e.paused {
+		e.id = s.cron.Schedule(e.schedule, e.job)
+	}
+	s.entries[name] = e
+}
+
+// remove stops and forgets the entry name. s.mu must be held.
+func (s *Scheduler) remove(name string) {
+	if e, ok := s.entries[name]; ok {
+		if !e.paused {
+			s.cron.Remove(e.id)
+		}
 		delete(s.entries, name)
 	}
 }
+
+// Pause stops the named entry from firing while keeping it, so Resume can
+// restart it unchanged. A running invocation is not interrupted. Pause reports
+// whether name is scheduled.
+func (s *Scheduler) Pause(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return false
+	}
+	if !e.paused {
+		s.cron.Remove(e.id)
+		e.paused = true
+	}
+	return true
+}
+
+// Resume restarts a paused entry from the current time; ticks that fell while
+// it was paused are not run. Resume reports whether name is scheduled.
+func (s *Scheduler) Resume(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[name]
+	if !ok {
+		return false
+	}
+	if e.paused {
+		e.paused = false
+		e.id = s.cron.Schedule(e.schedule, e.job)
+	}
+	return true
+}
+
+// Paused reports whether the named entry is paused.
+func (s *Scheduler) Paused(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[name]
+	return ok && e.paused
+}
+
+// onceAt is a cron.Schedule that fires a single time. cron never runs an
+// entry whose next activation is the zero time.
+type onceAt time.Time
+
+func (o onceAt) Next(t time.Time) time.Time {
+	if at := time.Time(o); t.Before(at) {
+		return at
+	}
+	return time.Time{}
+}
+
+// skipIfRunning wraps job so a call made while a previous call is still
+// running, as marked by running, returns at once.
+func skipIfRunning(name string, running *atomic.Bool, job func(), onSkip func()) func() {
+	return func() {
+		if !running.CompareAndSwap(false, true) {
+			log.Printf("skipping %s: previous run is still running", name)
+			if onSkip != nil {
+				onSkip()
+			}
+			return
+		}
+		defer running.Store(false)
+		job()
+	}
+}
+
+func invoke(fn JobFunc, o entryOptions) {
+	ctx := context.Background()
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+	fn(ctx)
+}
+
+func (s *Scheduler) Delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(name)
+}
+
+// MissedRuns counts the ticks of spec that fell strictly after since and at or
+// before now, stopping once limit is reached (limit <= 0 means no limit). A
+// CRON_TZ prefix on spec sets the time zone its ticks fall in.
+func MissedRuns(spec string, since, now time.Time, limit int) (int, error) {
+	sched, err := specParser.Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for t := sched.Next(since); !t.After(now); t = sched.Next(t) {
+		n++
+		if limit > 0 && n >= limit {
+			break
+		}
+	}
+	return n, nil
+}
+
+// Stop halts the cron loop. The returned context is done once running entries
+// have returned.
+func (s *Scheduler) Stop() context.Context {
+	return s.cron.Stop()
+}
+
+// Next returns when the named entry fires next, or the zero time if it is not
+// scheduled or paused.
+func (s *Scheduler) Next(name string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[name]
+	if !ok || e.paused {
+		return time.Time{}
+	}
+	return s.cron.Entry(e.id).Next
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// maintenanceKey is the apollo_settings row holding maintenance mode.
+const maintenanceKey = "maintenance_mode"
+
+// SetMaintenance records whether maintenance mode is on.
+func (s *SQLStore) SetMaintenance(ctx context.Context, on bool) error {
+	query := `INSERT INTO apollo_settings (key, value) VALUES (?, ?)
+        ON CONFLICT(key) DO UPDATE SET value = excluded.value`
+	if s.IsPostgres() {
+		query = `INSERT INTO apollo_settings (key, value) VALUES ($1, $2)
+        ON CONFLICT(key) DO UPDATE SET value = excluded.value`
+	}
+	if s.IsMySQL() {
+		query = "INSERT INTO apollo_settings (`key`, value) VALUES (?, ?)\n" +
+			`        ON DUPLICATE KEY UPDATE value = VALUES(value)`
+	}
+	_, err := s.db.ExecContext(ctx, query, maintenanceKey, strconv.FormatBool(on))
+	return err
+}
+
+// Maintenance reports whether maintenance mode was last recorded as on. A
+// store that never recorded it is not in maintenance.
+func (s *SQLStore) Maintenance(ctx context.Context) (bool, error) {
+	query := `SELECT value FROM apollo_settings WHERE key = ?`
+	if s.IsPostgres() {
+		query = `SELECT value FROM apollo_settings WHERE key = $1`
+	}
+	if s.IsMySQL() {
+		query = "SELECT value FROM apollo_settings WHERE `key` = ?"
+	}
+	var value string
+	err := s.db.QueryRowContext(ctx, query, maintenanceKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+
+	cron "github.com/robfig/cron/v3"
+)
+
+// SpecParser parses schedule specs.
+type SpecParser = cron.ScheduleParser
+
+var (
+	// SecondsParser accepts the specs the in-process scheduler runs:
+	// six-field cron with seconds, or descriptors such as "@every 5m".
+	SecondsParser SpecParser = specParser
+	// StandardParser accepts five-field unix cron, as Cloud Scheduler does.
+	StandardParser SpecParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	// anySpecParser accepts what either of them does; stores validate with it
+	// unless told which scheduler runs their specs.
+	anySpecParser SpecParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+// SpecError is a stored schedule whose spec does not parse.
+type SpecError struct {
+	Name string
+	Spec string
+	Err  error
+}
+
+// ValidateStored checks the spec of every schedule in st with parser, so
+// records that would fail on reload can be found and fixed.
+func ValidateStored(ctx context.Context, st Store, parser SpecParser) ([]SpecError, error) {
+	recs, err := st.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var invalid []SpecError
+	for _, r := range recs {
+		if r.CronSpec == "" {
+			continue
+		}
+		if _, err := parser.Parse(zonedSpec(r.CronSpec, r.TimeZone)); err != nil {
+			invalid = append(invalid, SpecError{Name: r.Name, Spec: r.CronSpec, Err: err})
+		}
+	}
+	return invalid, nil
+}
+
+// zonedSpec prefixes spec with CRON_TZ=tz so the cron parser evaluates it in
+// tz. An empty tz leaves spec in the parser's default location.
+func zonedSpec(spec, tz string) string {
+	if tz == "" {
+		return spec
+	}
+	return "CRON_TZ=" + tz + " " + spec
+}
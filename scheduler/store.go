@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq" // PostgreSQL
+	_ "github.com/go-sql-driver/mysql" // MySQL
+	_ "github.com/lib/pq"              // PostgreSQL
 	_ "modernc.org/sqlite"
 )
 
@@ -17,6 +20,39 @@ type JobRecord struct {
 	CronSpec   string
 	Cpu        string
 	Memory     string
+	// CoalesceMissed collapses every tick missed while the server was down
+	// into a single catch-up run on restart.
+	CoalesceMissed bool
+	// MaxCatchup caps how many missed ticks are replayed on restart when
+	// CoalesceMissed is false.
+	MaxCatchup  int
+	LastFiredAt int64
+	// Runner is the runner profile the schedule runs on; empty for the primary
+	Runner string
+	// Singleton schedules run each tick on only one replica, guarded by a lease
+	Singleton bool
+	// SkipIfRunning skips ticks that fire while the previous run is still going
+	SkipIfRunning bool
+	// TimeZone is the IANA time zone CronSpec runs in; empty for the
+	// scheduler's default
+	TimeZone string
+	// Paused schedules are kept but do not fire until resumed
+	Paused bool
+	// RunAt marks a one-time job deferred to this unix time; CronSpec is empty
+	RunAt int64
+	// RunIfMissed runs a deferred job on restart when RunAt passed while the
+	// server was down, instead of dropping it.
+	RunIfMissed bool
+	// JobName is the job a deferred run runs; its record is keyed by its own
+	// ID so it replaces neither the job's schedule nor other deferred runs
+	JobName string
+	// Labels are copied onto the record of every run of the job
+	Labels map[string]string
+	// Timeout bounds every run of the job; 0 leaves it to the runner
+	Timeout time.Duration
+	// MaxRetries is how often Batch retries a failed task of the job; nil
+	// for the runner's default
+	MaxRetries *int32
 }
 
 type ExecutionRecord struct {
@@ -31,14 +67,190 @@ type ExecutionRecord struct {
 	Result     string
 	StartedAt  int64
 	FinishedAt int64
+	// EstimatedCost is filled in once a cloud run has finished and been priced
+	EstimatedCost float64
+	// RetryDecision explains whether a failed scheduled run was retried
+	RetryDecision string
+	// Progress is the last percentage (0-100) the job reported while running,
+	// with its optional message and the unix time it was reported
+	Progress          float64
+	ProgressMessage   string
+	ProgressUpdatedAt int64
+	// Labels tag the execution for filtering, e.g. cluster or environment
+	Labels map[string]string
+	// BatchID groups the executions of one bulk submission
+	BatchID string
+	// ResultHash is the hex SHA-256 of Result, for jobs configured with
+	// hash_result; ResultUnchanged is set when it equals that of the previous
+	// successful run of the same name
+	ResultHash      string
+	ResultUnchanged bool
+	// Metrics are the numbers extracted from the run's output for jobs
+	// configured with metrics, e.g. rows processed
+	Metrics map[string]float64
 }
 
-type Store struct {
+// ExecutionFilter narrows ListExecutions. Zero values match everything.
+type ExecutionFilter struct {
+	ID      string
+	BatchID string
+	Name    string
+	Status  string
+	Since   int64 // started_at >= Since (unix seconds)
+	Until   int64 // started_at < Until (unix seconds)
+	Limit   int
+	Offset  int // skips this many of the matching executions
+	// Labels matches executions carrying every one of these labels
+	Labels map[string]string
+	// Metrics matches executions whose metrics satisfy every one of these
+	Metrics []MetricFilter
+	// OmitResult leaves Result empty, sparing the largest column
+	OmitResult bool
+}
+
+// ErrExecutionNotFound is returned when an execution id is unknown.
+var ErrExecutionNotFound = errors.New("execution not found")
+
+// Store persists schedules and execution history. JobsServer only depends on
+// this interface so alternative backends can be plugged in.
+type Store interface {
+	Upsert(ctx context.Context, r JobRecord) error
+	Delete(ctx context.Context, name string) error
+	List(ctx context.Context) ([]JobRecord, error)
+	// CountSchedules counts the stored schedules and reports whether one of
+	// them is named name.
+	CountSchedules(ctx context.Context, name string) (count int, exists bool, err error)
+	// ListPage lists up to limit schedules (all when 0) after skipping
+	// offset, in the order List returns them.
+	ListPage(ctx context.Context, limit, offset int) ([]JobRecord, error)
+	MarkFired(ctx context.Context, name string, at int64) error
+	SetPaused(ctx context.Context, name string, paused bool) error
+	// Paused reads the state SetPaused recorded, so every replica sees it.
+	Paused(ctx context.Context, name string) (bool, error)
+	AcquireLease(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+	// SetMaintenance and Maintenance persist whether maintenance mode is on,
+	// so it survives restarts.
+	SetMaintenance(ctx context.Context, on bool) error
+	Maintenance(ctx context.Context) (bool, error)
+	AddAuditEvent(ctx context.Context, e AuditEvent) error
+	ListAuditEvents(ctx context.Context, f AuditFilter) ([]AuditEvent, error)
+	AddExecution(ctx context.Context, e ExecutionRecord) error
+	SetExecutionCost(ctx context.Context, id string, cost float64) error
+	SetRetryDecision(ctx context.Context, id, decision string) error
+	SetExecutionProgress(ctx context.Context, id string, percent float64, message string, at int64) error
+	ListExecutions(ctx context.Context, f ExecutionFilter) ([]ExecutionRecord, error)
+	GetExecution(ctx context.Context, id string) (ExecutionRecord, error)
+	IterateExecutions(ctx context.Context, f ExecutionFilter, fn func(ExecutionRecord) error) error
+	PurgeExecutions(ctx context.Context, olderThan time.Time) (int64, error)
+	Close() error
+}
+
+// Options tunes an SQLStore beyond its driver and path.
+type Options struct {
+	// CompressResults gzips execution results before they are written.
+	CompressResults bool
+	// SpecParser validates schedule specs on Upsert, so a spec the scheduler
+	// cannot run is never persisted. Defaults to accepting both cron with
+	// seconds and unix cron; set SecondsParser or StandardParser to match the
+	// scheduler that runs the specs.
+	SpecParser SpecParser
+	// Clock dates leases (default: SystemClock).
+	Clock Clock
+	// Connection pool settings. Zero keeps the driver default: for postgres
+	// 100 open and 10 idle connections, a 1h lifetime and a 15m idle time;
+	// for mysql the same counts with a 3m lifetime and a 1m idle time, so
+	// connections are recycled before MySQL or a proxy drops them;
+	// for sqlite a single open connection, since concurrent writers fail
+	// with SQLITE_BUSY; database/sql's own defaults otherwise.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+	// ReadPath is the DSN of a read replica of the same driver. Reads made
+	// with a WithReplica context go there; everything else, and every read
+	// without it, uses the primary.
+	ReadPath string
+}
+
+// pool returns opts with the driver's defaults filled in for unset settings.
+func (o Options) pool(driver string) (Options, error) {
+	if o.MaxOpenConns < 0 || o.MaxIdleConns < 0 || o.ConnMaxLifetime < 0 || o.ConnMaxIdleTime < 0 {
+		return o, errors.New("store connection pool settings must not be negative")
+	}
+	if DBDriver(driver) == PostgreSQL {
+		if o.MaxOpenConns == 0 {
+			o.MaxOpenConns = 100
+		}
+		if o.MaxIdleConns == 0 {
+			o.MaxIdleConns = 10
+		}
+		if o.ConnMaxLifetime == 0 {
+			o.ConnMaxLifetime = time.Hour
+		}
+		if o.ConnMaxIdleTime == 0 {
+			o.ConnMaxIdleTime = 15 * time.Minute
+		}
+	}
+	if DBDriver(driver) == MySQL {
+		if o.MaxOpenConns == 0 {
+			o.MaxOpenConns = 100
+		}
+		if o.MaxIdleConns == 0 {
+			o.MaxIdleConns = 10
+		}
+		if o.ConnMaxLifetime == 0 {
+			o.ConnMaxLifetime = 3 * time.Minute
+		}
+		if o.ConnMaxIdleTime == 0 {
+			o.ConnMaxIdleTime = time.Minute
+		}
+	}
+	if DBDriver(driver) == SQLite && o.MaxOpenConns == 0 {
+		o.MaxOpenConns = 1
+	}
+	return o, nil
+}
+
+// SQLStore is the database/sql backed Store used for sqlite, postgres and
+// mysql.
+type SQLStore struct {
 	db     *sql.DB
 	driver string
+	opts   Options
+	// replica serves WithReplica reads; nil without Options.ReadPath
+	replica *sql.DB
+}
+
+var _ Store = (*SQLStore)(nil)
+
+func OpenStore(driver, path string, opts Options) (*SQLStore, error) {
+	pool, err := opts.pool(driver)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+	db, err := openDB(driver, path, pool)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(db, driver); err != nil {
+		return nil, err
+	}
+	s := &SQLStore{db: db, driver: driver, opts: opts}
+	if opts.ReadPath != "" {
+		// the replica follows the primary's schema, so it is not migrated
+		if s.replica, err = openDB(driver, opts.ReadPath, pool); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("open read replica: %w", err)
+		}
+	}
+	return s, nil
 }
 
-func OpenStore(driver, path string) (*Store, error) {
+// openDB opens a connection pool to path with pool's settings.
+func openDB(driver, path string, pool Options) (*sql.DB, error) {
 	db, err := sql.Open(driver, path)
 	if err != nil {
 		return nil, err
@@ -46,19 +258,24 @@ func OpenStore(driver, path string) (*Store, error) {
 	if driver == "sqlite" {
 		db.Exec(`PRAGMA foreign_keys = ON`)
 	}
-	if driver == "postgres" {
-		db.SetConnMaxIdleTime(15 * time.Minute)
-		db.SetMaxIdleConns(10)
-		db.SetMaxOpenConns(100)
-		db.SetConnMaxLifetime(1 * time.Hour)
+	if pool.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 	}
-	if err := migrate(db); err != nil {
-		return nil, err
+	if pool.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
 	}
-	return &Store{db: db, driver: driver}, nil
+	if pool.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	return db, nil
 }
 
-func migrate(db *sql.DB) error {
+// createTables creates the tables as they were first defined; later columns
+// are added by migrate.
+func createTables(db *sql.DB) error {
 	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS apollo_jobs (
         name TEXT PRIMARY KEY,
         command TEXT NOT NULL,
@@ -82,11 +299,143 @@ func migrate(db *sql.DB) error {
         result TEXT,
         started_at INTEGER,
         finished_at INTEGER
+    )`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS apollo_leases (
+        key TEXT PRIMARY KEY,
+        holder TEXT NOT NULL,
+        expires_at INTEGER NOT NULL
+    )`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS apollo_audit_events (
+        id TEXT PRIMARY KEY,
+        at INTEGER NOT NULL,
+        method TEXT NOT NULL,
+        caller TEXT NOT NULL,
+        args TEXT NOT NULL,
+        outcome TEXT NOT NULL,
+        error TEXT NOT NULL DEFAULT ''
+    )`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_apollo_audit_events_at ON apollo_audit_events(at)`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS apollo_settings (
+        key TEXT PRIMARY KEY,
+        value TEXT NOT NULL
+    )`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS apollo_execution_labels (
+        execution_id TEXT NOT NULL,
+        key TEXT NOT NULL,
+        value TEXT NOT NULL,
+        PRIMARY KEY (execution_id, key)
     )`)
 	if err != nil {
 		return err
 	}
 	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_apollo_executions_name_started ON apollo_executions(name, started_at)`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_apollo_execution_labels_key_value ON apollo_execution_labels(key, value)`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS apollo_execution_metrics (
+        execution_id TEXT NOT NULL,
+        key TEXT NOT NULL,
+        value DOUBLE PRECISION NOT NULL,
+        PRIMARY KEY (execution_id, key)
+    )`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE INDEX IF NOT EXISTS idx_apollo_execution_metrics_key_value ON apollo_execution_metrics(key, value)`)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func migrate(db *sql.DB, driver string) error {
+	create := createTables
+	if DBDriver(driver) == MySQL {
+		create = createMySQLTables
+	}
+	if err := create(db); err != nil {
+		return err
+	}
+	columns := []struct{ table, name, ddl string }{
+		{"apollo_jobs", "coalesce_missed", "BOOLEAN NOT NULL DEFAULT TRUE"},
+		{"apollo_jobs", "max_catchup", "INTEGER NOT NULL DEFAULT 0"},
+		{"apollo_jobs", "last_fired_at", "INTEGER NOT NULL DEFAULT 0"},
+		{"apollo_executions", "result_compressed", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"apollo_jobs", "runner", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_executions", "estimated_cost", "DOUBLE PRECISION NOT NULL DEFAULT 0"},
+		{"apollo_jobs", "singleton", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"apollo_executions", "retry_decision", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_jobs", "run_at", "INTEGER NOT NULL DEFAULT 0"},
+		{"apollo_jobs", "run_if_missed", "BOOLEAN NOT NULL DEFAULT TRUE"},
+		{"apollo_executions", "progress", "DOUBLE PRECISION NOT NULL DEFAULT 0"},
+		{"apollo_executions", "progress_message", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_executions", "progress_updated_at", "INTEGER NOT NULL DEFAULT 0"},
+		{"apollo_jobs", "labels", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_executions", "batch_id", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_jobs", "skip_if_running", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"apollo_jobs", "time_zone", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_jobs", "paused", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"apollo_executions", "result_hash", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_executions", "result_unchanged", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"apollo_jobs", "job_name", "TEXT NOT NULL DEFAULT ''"},
+		{"apollo_jobs", "timeout_ms", "BIGINT NOT NULL DEFAULT 0"},
+		{"apollo_jobs", "max_retries", "INTEGER"},
+	}
+	for _, c := range columns {
+		if err := addColumn(db, driver, c.table, c.name, c.ddl); err != nil {
+			return err
+		}
+	}
+	batchIndex := "batch_id"
+	if DBDriver(driver) == MySQL {
+		// MySQL only indexes a prefix of text columns
+		batchIndex = "batch_id(255)"
+	}
+	return createIndex(db, driver, "idx_apollo_executions_batch", "apollo_executions", batchIndex)
+}
+
+// createIndex creates an index on a table unless it already exists.
+func createIndex(db *sql.DB, driver, name, table, columns string) error {
+	if DBDriver(driver) == MySQL {
+		return createMySQLIndex(db, name, table, columns)
+	}
+	_, err := db.Exec(fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s(%s)`, name, table, columns))
+	return err
+}
+
+// addColumn adds a column to an existing table, ignoring the error when the
+// column is already there so migrate stays idempotent.
+func addColumn(db *sql.DB, driver, table, column, ddl string) error {
+	if DBDriver(driver) == PostgreSQL {
+		_, err := db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s`, table, column, ddl))
+		return err
+	}
+	if DBDriver(driver) == MySQL {
+		return addMySQLColumn(db, table, column, ddl)
+	}
+	_, err := db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, ddl))
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+		return nil
+	}
 	return err
 }
 
@@ -95,48 +444,154 @@ type DBDriver string
 const (
 	SQLite     DBDriver = "sqlite"
 	PostgreSQL DBDriver = "postgres"
+	MySQL      DBDriver = "mysql"
 )
 
-func (s *Store) IsSQLite() bool {
+func (s *SQLStore) IsSQLite() bool {
 	return DBDriver(s.driver) == SQLite
 }
 
-func (s *Store) IsPostgres() bool {
+func (s *SQLStore) IsPostgres() bool {
 	return DBDriver(s.driver) == PostgreSQL
 }
 
-func (s *Store) Upsert(ctx context.Context, r JobRecord) error {
+func (s *SQLStore) IsMySQL() bool {
+	return DBDriver(s.driver) == MySQL
+}
+
+func (s *SQLStore) Upsert(ctx context.Context, r JobRecord) error {
+	if r.CronSpec != "" {
+		parser := s.opts.SpecParser
+		if parser == nil {
+			parser = anySpecParser
+		}
+		if _, err := parser.Parse(zonedSpec(r.CronSpec, r.TimeZone)); err != nil {
+			return fmt.Errorf("invalid schedule %q for %s: %w", r.CronSpec, r.Name, err)
+		}
+	}
 	// Use UPSERT syntax appropriate for each database
-	query := `INSERT INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory)
-        VALUES (?, ?, ?, ?, ?, ?)
+	query := `INSERT INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory, coalesce_missed, max_catchup, runner, singleton, run_at, run_if_missed, labels, skip_if_running, time_zone, paused, job_name, timeout_ms, max_retries)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
         ON CONFLICT(name) DO UPDATE SET 
             command = EXCLUDED.command, 
             args_base64 = EXCLUDED.args_base64, 
             cron_spec = EXCLUDED.cron_spec, 
             cpu = EXCLUDED.cpu, 
-            memory = EXCLUDED.memory`
+            memory = EXCLUDED.memory,
+            coalesce_missed = EXCLUDED.coalesce_missed,
+            max_catchup = EXCLUDED.max_catchup,
+            runner = EXCLUDED.runner,
+            singleton = EXCLUDED.singleton,
+            run_at = EXCLUDED.run_at,
+            run_if_missed = EXCLUDED.run_if_missed,
+            labels = EXCLUDED.labels,
+            skip_if_running = EXCLUDED.skip_if_running,
+            time_zone = EXCLUDED.time_zone,
+            paused = EXCLUDED.paused,
+            job_name = EXCLUDED.job_name,
+            timeout_ms = EXCLUDED.timeout_ms,
+            max_retries = EXCLUDED.max_retries`
 
 	// For SQLite, use REPLACE or INSERT OR REPLACE for better performance
 	if s.IsSQLite() {
-		query = `INSERT OR REPLACE INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory)
-            VALUES (?, ?, ?, ?, ?, ?)`
+		query = `INSERT OR REPLACE INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory, coalesce_missed, max_catchup, runner, singleton, run_at, run_if_missed, labels, skip_if_running, time_zone, paused, job_name, timeout_ms, max_retries)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	}
 	if s.IsPostgres() {
-		query = `INSERT INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory)
-            VALUES ($1, $2, $3, $4, $5, $6)
+		query = `INSERT INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory, coalesce_missed, max_catchup, runner, singleton, run_at, run_if_missed, labels, skip_if_running, time_zone, paused, job_name, timeout_ms, max_retries)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
             ON CONFLICT(name) DO UPDATE SET 
                 command = EXCLUDED.command, 
                 args_base64 = EXCLUDED.args_base64, 
                 cron_spec = EXCLUDED.cron_spec, 
                 cpu = EXCLUDED.cpu, 
-                memory = EXCLUDED.memory`
+                memory = EXCLUDED.memory,
+                coalesce_missed = EXCLUDED.coalesce_missed,
+                max_catchup = EXCLUDED.max_catchup,
+            runner = EXCLUDED.runner,
+            singleton = EXCLUDED.singleton,
+            run_at = EXCLUDED.run_at,
+            run_if_missed = EXCLUDED.run_if_missed,
+            labels = EXCLUDED.labels,
+            skip_if_running = EXCLUDED.skip_if_running,
+            time_zone = EXCLUDED.time_zone,
+            paused = EXCLUDED.paused,
+            job_name = EXCLUDED.job_name,
+            timeout_ms = EXCLUDED.timeout_ms,
+            max_retries = EXCLUDED.max_retries`
+	}
+	if s.IsMySQL() {
+		query = `INSERT INTO apollo_jobs (name, command, args_base64, cron_spec, cpu, memory, coalesce_missed, max_catchup, runner, singleton, run_at, run_if_missed, labels, skip_if_running, time_zone, paused, job_name, timeout_ms, max_retries)
+            VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+            ON DUPLICATE KEY UPDATE
+                command = VALUES(command),
+                args_base64 = VALUES(args_base64),
+                cron_spec = VALUES(cron_spec),
+                cpu = VALUES(cpu),
+                memory = VALUES(memory),
+                coalesce_missed = VALUES(coalesce_missed),
+                max_catchup = VALUES(max_catchup),
+                runner = VALUES(runner),
+                singleton = VALUES(singleton),
+                run_at = VALUES(run_at),
+                run_if_missed = VALUES(run_if_missed),
+                labels = VALUES(labels),
+                skip_if_running = VALUES(skip_if_running),
+                time_zone = VALUES(time_zone),
+                paused = VALUES(paused),
+                job_name = VALUES(job_name),
+                timeout_ms = VALUES(timeout_ms),
+                max_retries = VALUES(max_retries)`
 	}
 
-	_, err := s.db.ExecContext(ctx, query, r.Name, r.Command, r.ArgsBase64, r.CronSpec, r.Cpu, r.Memory)
+	_, err := s.db.ExecContext(ctx, query, r.Name, r.Command, r.ArgsBase64, r.CronSpec, r.Cpu, r.Memory, r.CoalesceMissed, r.MaxCatchup, r.Runner, r.Singleton, r.RunAt, r.RunIfMissed, encodeLabels(r.Labels), r.SkipIfRunning, r.TimeZone, r.Paused, r.JobName, r.Timeout.Milliseconds(), r.MaxRetries)
+	return err
+}
+
+// MarkFired records the time a schedule last fired so missed ticks can be
+// computed after a restart.
+func (s *SQLStore) MarkFired(ctx context.Context, name string, at int64) error {
+	query := `UPDATE apollo_jobs SET last_fired_at = ? WHERE name = ?`
+	if s.IsPostgres() {
+		query = `UPDATE apollo_jobs SET last_fired_at = $1 WHERE name = $2`
+	}
+	_, err := s.db.ExecContext(ctx, query, at, name)
 	return err
 }
 
-func (s *Store) Delete(ctx context.Context, name string) error {
+// SetPaused pauses or resumes a stored schedule.
+func (s *SQLStore) SetPaused(ctx context.Context, name string, paused bool) error {
+	query := `UPDATE apollo_jobs SET paused = ? WHERE name = ?`
+	if s.IsPostgres() {
+		query = `UPDATE apollo_jobs SET paused = $1 WHERE name = $2`
+	}
+	res, err := s.db.ExecContext(ctx, query, paused, name)
+	if err != nil {
+		return err
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return errors.New("not found")
+	}
+	return nil
+}
+
+// Paused reports whether the named schedule is stored as paused. An unknown
+// schedule is not paused.
+func (s *SQLStore) Paused(ctx context.Context, name string) (bool, error) {
+	query := `SELECT paused FROM apollo_jobs WHERE name = ?`
+	if s.IsPostgres() {
+		query = `SELECT paused FROM apollo_jobs WHERE name = $1`
+	}
+	var paused bool
+	err := s.db.QueryRowContext(ctx, query, name).Scan(&paused)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return paused, err
+}
+
+func (s *SQLStore) Delete(ctx context.Context, name string) error {
 	query := `DELETE FROM apollo_jobs WHERE name = ?`
 	if s.IsPostgres() {
 		query = `DELETE FROM apollo_jobs WHERE name = $1`
@@ -152,10 +607,31 @@ func (s *Store) Delete(ctx context.Context, name string) error {
 	return nil
 }
 
-func (s *Store) List(ctx context.Context) ([]JobRecord, error) {
+// CountSchedules counts the stored schedules and reports whether one of them
+// is named name.
+func (s *SQLStore) CountSchedules(ctx context.Context, name string) (count int, exists bool, err error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(CASE WHEN name = ? THEN 1 ELSE 0 END), 0) FROM apollo_jobs`
+	if s.IsPostgres() {
+		query = `SELECT COUNT(*), COALESCE(SUM(CASE WHEN name = $1 THEN 1 ELSE 0 END), 0) FROM apollo_jobs`
+	}
+	var named int
+	if err := s.reader(ctx).QueryRowContext(ctx, query, name).Scan(&count, &named); err != nil {
+		return 0, false, err
+	}
+	return count, named > 0, nil
+}
+
+func (s *SQLStore) List(ctx context.Context) ([]JobRecord, error) {
+	return s.ListPage(ctx, 0, 0)
+}
+
+func (s *SQLStore) ListPage(ctx context.Context, limit, offset int) ([]JobRecord, error) {
+	var args []any
 	// Add ORDER BY for consistent results and potential index usage
-	rows, err := s.db.QueryContext(ctx, `SELECT name, command, args_base64, cron_spec, cpu, memory 
-        FROM apollo_jobs ORDER BY name`)
+	query := `SELECT name, command, args_base64, cron_spec, cpu, memory,
+        coalesce_missed, max_catchup, last_fired_at, runner, singleton, run_at, run_if_missed, labels, skip_if_running, time_zone, paused, job_name, timeout_ms, max_retries
+        FROM apollo_jobs ORDER BY name` + s.pageClause(limit, offset, s.argFunc(&args))
+	rows, err := s.reader(ctx).QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -164,46 +640,349 @@ func (s *Store) List(ctx context.Context) ([]JobRecord, error) {
 	var out []JobRecord
 	for rows.Next() {
 		var r JobRecord
-		if err := rows.Scan(&r.Name, &r.Command, &r.ArgsBase64, &r.CronSpec, &r.Cpu, &r.Memory); err != nil {
+		var labels string
+		var timeoutMs int64
+		var maxRetries sql.NullInt32
+		if err := rows.Scan(&r.Name, &r.Command, &r.ArgsBase64, &r.CronSpec, &r.Cpu, &r.Memory,
+			&r.CoalesceMissed, &r.MaxCatchup, &r.LastFiredAt, &r.Runner, &r.Singleton, &r.RunAt, &r.RunIfMissed, &labels, &r.SkipIfRunning, &r.TimeZone, &r.Paused, &r.JobName, &timeoutMs, &maxRetries); err != nil {
 			return nil, err
 		}
+		r.Timeout = time.Duration(timeoutMs) * time.Millisecond
+		if maxRetries.Valid {
+			r.MaxRetries = &maxRetries.Int32
+		}
+		if r.Labels, err = decodeLabels(labels); err != nil {
+			return nil, fmt.Errorf("decode labels of %s: %w", r.Name, err)
+		}
 		out = append(out, r)
 	}
 	return out, rows.Err()
 }
 
-func (s *Store) AddExecution(ctx context.Context, e ExecutionRecord) error {
+func (s *SQLStore) AddExecution(ctx context.Context, e ExecutionRecord) error {
 	// Use UPSERT to support updating execution records (e.g., when status changes from "running" to "success"/"error")
 	var query string
 	if s.IsSQLite() {
-		query = `INSERT OR REPLACE INTO apollo_executions 
-        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		// upsert rather than replace, so columns written while the job runs
+		// (e.g. progress) survive the final status update
+		query = `INSERT INTO apollo_executions 
+        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at, result_compressed, batch_id, result_hash, result_unchanged)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT (id) DO UPDATE SET 
+            status = EXCLUDED.status,
+            error = EXCLUDED.error,
+            result = EXCLUDED.result,
+            started_at = EXCLUDED.started_at,
+            finished_at = EXCLUDED.finished_at,
+            result_compressed = EXCLUDED.result_compressed,
+            result_hash = EXCLUDED.result_hash,
+            result_unchanged = EXCLUDED.result_unchanged`
 	} else if s.IsPostgres() {
 		query = `INSERT INTO apollo_executions 
-        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at, result_compressed, batch_id, result_hash, result_unchanged)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
         ON CONFLICT (id) DO UPDATE SET 
             status = EXCLUDED.status,
             error = EXCLUDED.error,
             result = EXCLUDED.result,
-            finished_at = EXCLUDED.finished_at`
+            started_at = EXCLUDED.started_at,
+            finished_at = EXCLUDED.finished_at,
+            result_compressed = EXCLUDED.result_compressed,
+            result_hash = EXCLUDED.result_hash,
+            result_unchanged = EXCLUDED.result_unchanged`
+	} else if s.IsMySQL() {
+		query = `INSERT INTO apollo_executions
+        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at, result_compressed, batch_id, result_hash, result_unchanged)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            status = VALUES(status),
+            error = VALUES(error),
+            result = VALUES(result),
+            started_at = VALUES(started_at),
+            finished_at = VALUES(finished_at),
+            result_compressed = VALUES(result_compressed),
+            result_hash = VALUES(result_hash),
+            result_unchanged = VALUES(result_unchanged)`
 	} else {
 		// Fallback for other databases
 		query = `INSERT INTO apollo_executions 
-        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+        (id, name, command, args_base64, cpu, memory, status, error, result, started_at, finished_at, result_compressed, batch_id, result_hash, result_unchanged)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	}
+
+	result, compressed, err := s.encodeResult(e.Result)
+	if err != nil {
+		return err
 	}
 
-	var err error
 	if s.IsPostgres() {
 		_, err = s.db.ExecContext(ctx, query,
-			e.ID, e.Name, e.Command, e.ArgsBase64, e.Cpu, e.Memory, e.Status, e.Error, e.Result, e.StartedAt, e.FinishedAt,
+			e.ID, e.Name, e.Command, e.ArgsBase64, e.Cpu, e.Memory, e.Status, e.Error, result, e.StartedAt, e.FinishedAt, compressed, e.BatchID, e.ResultHash, e.ResultUnchanged,
 		)
 	} else {
 		_, err = s.db.ExecContext(ctx, query,
-			e.ID, e.Name, e.Command, e.ArgsBase64, e.Cpu, e.Memory, e.Status, e.Error, e.Result, e.StartedAt, e.FinishedAt,
+			e.ID, e.Name, e.Command, e.ArgsBase64, e.Cpu, e.Memory, e.Status, e.Error, result, e.StartedAt, e.FinishedAt, compressed, e.BatchID, e.ResultHash, e.ResultUnchanged,
 		)
 	}
+	if err != nil {
+		return err
+	}
+	if err := s.setExecutionLabels(ctx, e.ID, e.Labels); err != nil {
+		return err
+	}
+	return s.setExecutionMetrics(ctx, e.ID, e.Metrics)
+}
+
+// SetExecutionCost stores the estimated cost of a finished execution.
+func (s *SQLStore) SetExecutionCost(ctx context.Context, id string, cost float64) error {
+	query := `UPDATE apollo_executions SET estimated_cost = ? WHERE id = ?`
+	if s.IsPostgres() {
+		query = `UPDATE apollo_executions SET estimated_cost = $1 WHERE id = $2`
+	}
+	_, err := s.db.ExecContext(ctx, query, cost, id)
+	return err
+}
+
+// SetRetryDecision records why a failed execution was or was not retried.
+func (s *SQLStore) SetRetryDecision(ctx context.Context, id, decision string) error {
+	query := `UPDATE apollo_executions SET retry_decision = ? WHERE id = ?`
+	if s.IsPostgres() {
+		query = `UPDATE apollo_executions SET retry_decision = $1 WHERE id = $2`
+	}
+	_, err := s.db.ExecContext(ctx, query, decision, id)
 	return err
 }
+
+// SetExecutionProgress stores the progress a running job reported. It
+// returns ErrExecutionNotFound when no execution has the given id.
+func (s *SQLStore) SetExecutionProgress(ctx context.Context, id string, percent float64, message string, at int64) error {
+	query := `UPDATE apollo_executions SET progress = ?, progress_message = ?, progress_updated_at = ? WHERE id = ?`
+	if s.IsPostgres() {
+		query = `UPDATE apollo_executions SET progress = $1, progress_message = $2, progress_updated_at = $3 WHERE id = $4`
+	}
+	res, err := s.db.ExecContext(ctx, query, percent, message, at, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrExecutionNotFound
+	}
+	return nil
+}
+
+// argFunc returns a function that appends a query argument to args and
+// returns its placeholder in the driver's syntax.
+func (s *SQLStore) argFunc(args *[]any) func(v any) string {
+	return func(v any) string {
+		*args = append(*args, v)
+		if s.IsPostgres() {
+			return fmt.Sprintf("$%d", len(*args))
+		}
+		return "?"
+	}
+}
+
+// MaxFilters caps the label and metric filters of an execution query, each
+// of which adds a subquery.
+const MaxFilters = 32
+
+// inBatchSize caps the IDs of an IN list, well under every driver's limit on
+// bind parameters.
+const inBatchSize = 500
+
+// inBatches calls load with consecutive runs of at most inBatchSize
+// executions.
+func inBatches(execs []ExecutionRecord, load func([]ExecutionRecord) error) error {
+	for len(execs) > 0 {
+		n := min(len(execs), inBatchSize)
+		if err := load(execs[:n]); err != nil {
+			return err
+		}
+		execs = execs[n:]
+	}
+	return nil
+}
+
+// pageClause is the LIMIT/OFFSET clause returning limit rows (all when 0)
+// after skipping offset.
+func (s *SQLStore) pageClause(limit, offset int, arg func(v any) string) string {
+	var clause string
+	switch {
+	case limit > 0:
+		clause = " LIMIT " + arg(limit)
+	case offset > 0 && s.IsMySQL():
+		// mysql only takes OFFSET after a LIMIT, and has no "none"
+		clause = " LIMIT 18446744073709551615"
+	case offset > 0 && !s.IsPostgres():
+		// sqlite only takes OFFSET after a LIMIT; -1 means none
+		clause = " LIMIT -1"
+	}
+	if offset > 0 {
+		clause += " OFFSET " + arg(offset)
+	}
+	return clause
+}
+
+// ListExecutions returns executions matching f, most recent first.
+func (s *SQLStore) ListExecutions(ctx context.Context, f ExecutionFilter) ([]ExecutionRecord, error) {
+	return s.queryExecutions(ctx, f, nil, f.Limit)
+}
+
+// GetExecution returns the execution with the given id, or
+// ErrExecutionNotFound when there is none.
+func (s *SQLStore) GetExecution(ctx context.Context, id string) (ExecutionRecord, error) {
+	recs, err := s.queryExecutions(ctx, ExecutionFilter{ID: id}, nil, 1)
+	if err != nil {
+		return ExecutionRecord{}, err
+	}
+	if len(recs) == 0 {
+		return ExecutionRecord{}, ErrExecutionNotFound
+	}
+	return recs[0], nil
+}
+
+// executionPageSize is how many executions IterateExecutions reads at once.
+const executionPageSize = 500
+
+// IterateExecutions calls fn for every execution matching f, most recent
+// first, reading them a page at a time so a long history is never held in
+// memory whole and the connection is free between pages. It stops at the
+// first error fn returns.
+func (s *SQLStore) IterateExecutions(ctx context.Context, f ExecutionFilter, fn func(ExecutionRecord) error) error {
+	var after *ExecutionRecord
+	seen := 0
+	for {
+		page := executionPageSize
+		if f.Limit > 0 {
+			page = min(page, f.Limit-seen)
+		}
+		if page <= 0 {
+			return nil
+		}
+		recs, err := s.queryExecutions(ctx, f, after, page)
+		if err != nil {
+			return err
+		}
+		for _, e := range recs {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		seen += len(recs)
+		if len(recs) < page {
+			return nil
+		}
+		after = &recs[len(recs)-1]
+	}
+}
+
+// queryExecutions returns up to limit executions matching f (all when limit
+// is 0) that sort after the given one, or without one after skipping
+// f.Offset, most recent first.
+func (s *SQLStore) queryExecutions(ctx context.Context, f ExecutionFilter, after *ExecutionRecord, limit int) ([]ExecutionRecord, error) {
+	var where []string
+	var args []any
+	arg := s.argFunc(&args)
+	if f.ID != "" {
+		where = append(where, "id = "+arg(f.ID))
+	}
+	if f.BatchID != "" {
+		where = append(where, "batch_id = "+arg(f.BatchID))
+	}
+	if f.Name != "" {
+		where = append(where, "name = "+arg(f.Name))
+	}
+	if f.Status != "" {
+		where = append(where, "status = "+arg(f.Status))
+	}
+	if f.Since > 0 {
+		where = append(where, "started_at >= "+arg(f.Since))
+	}
+	if f.Until > 0 {
+		where = append(where, "started_at < "+arg(f.Until))
+	}
+	if after != nil {
+		where = append(where, fmt.Sprintf("(started_at < %s OR (started_at = %s AND id < %s))",
+			arg(after.StartedAt), arg(after.StartedAt), arg(after.ID)))
+	}
+	if len(f.Labels)+len(f.Metrics) > MaxFilters {
+		return nil, fmt.Errorf("at most %d label and metric filters are allowed", MaxFilters)
+	}
+	where = append(where, labelConditions(f.Labels, arg)...)
+	metrics, err := metricConditions(f.Metrics, arg)
+	if err != nil {
+		return nil, err
+	}
+	where = append(where, metrics...)
+	result := "result"
+	if f.OmitResult {
+		result = "NULL"
+	}
+	query := `SELECT id, name, command, args_base64, cpu, memory, status, error, ` + result + `,
+        started_at, finished_at, result_compressed, estimated_cost, retry_decision,
+        progress, progress_message, progress_updated_at, batch_id, result_hash, result_unchanged
+        FROM apollo_executions`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY started_at DESC, id DESC"
+	offset := f.Offset
+	if after != nil {
+		// later pages continue from the last row read
+		offset = 0
+	}
+	query += s.pageClause(limit, offset, arg)
+
+	db := s.reader(ctx)
+	if f.ID != "" {
+		// an execution looked up by ID was likely just recorded
+		db = s.db
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ExecutionRecord
+	for rows.Next() {
+		var e ExecutionRecord
+		var argsBase64, cpu, memory, status, errText, result sql.NullString
+		var compressed bool
+		if err := rows.Scan(&e.ID, &e.Name, &e.Command, &argsBase64, &cpu, &memory, &status, &errText, &result,
+			&e.StartedAt, &e.FinishedAt, &compressed, &e.EstimatedCost, &e.RetryDecision,
+			&e.Progress, &e.ProgressMessage, &e.ProgressUpdatedAt, &e.BatchID, &e.ResultHash, &e.ResultUnchanged); err != nil {
+			return nil, err
+		}
+		e.ArgsBase64, e.Cpu, e.Memory = argsBase64.String, cpu.String, memory.String
+		e.Status, e.Error = status.String, errText.String
+		if e.Result, err = decodeResult(result.String, compressed && !f.OmitResult); err != nil {
+			return nil, fmt.Errorf("decode result of %s: %w", e.ID, err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// the single sqlite connection must be released before the next query
+	rows.Close()
+	if err := s.loadExecutionLabels(ctx, out); err != nil {
+		return nil, err
+	}
+	if err := s.loadExecutionMetrics(ctx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Ping checks that the primary database is reachable.
+func (s *SQLStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *SQLStore) Close() error {
+	if s.replica != nil {
+		s.replica.Close()
+	}
+	return s.db.Close()
+}
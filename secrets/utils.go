@@ -2,51 +2,131 @@ package _secrets
 
 import (
 	"log"
+	"maps"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 
 	config "github.com/SyneHQ/apollo"
 	"github.com/infisical/go-sdk/packages/models"
 )
 
-func FilterSecrets(secrets []models.Secret, secretsConfig []config.SecretConfig) []models.Secret {
+// FilterSecrets selects the configured secrets from those loaded from the
+// provider. With prefixes, provider keys starting with one of them are
+// matched by their unprefixed name, e.g. PROD_DATABASE_URL as DATABASE_URL.
+// When several keys strip to the same name, the earliest prefix wins and
+// prefixed keys win over unprefixed ones.
+//
+// The result holds one secret per key, sorted by key. A key configured more
+// than once takes its value from the provider over a literal value over the
+// environment, and otherwise from its first entry. Keys that are not valid
+// env var names are dropped with a warning.
+func FilterSecrets(secrets []models.Secret, secretsConfig []config.SecretConfig, prefixes ...string) []models.Secret {
 	// Create a map for O(1) secret lookups
 	secretMap := make(map[string]models.Secret, len(secrets))
+	rank := make(map[string]int, len(secrets))
 	for _, s := range secrets {
-		secretMap[s.SecretKey] = s
+		name, r := stripPrefix(s.SecretKey, prefixes)
+		if prev, seen := rank[name]; seen && prev <= r {
+			continue
+		}
+		rank[name] = r
+		s.SecretKey = name
+		secretMap[name] = s
 	}
 
-	// Pre-allocate slice with estimated capacity
-	allSecrets := make([]models.Secret, 0, len(secretsConfig))
-
-	// Use map for O(1) lookup instead of slice for injected secrets
-	injectedSecrets := make(map[string]bool, len(secretsConfig))
-
-	// Process secrets that need injection
+	selected := make(map[string]models.Secret, len(secretsConfig))
+	source := make(map[string]secretSource, len(secretsConfig))
+	add := func(s models.Secret, from secretSource) {
+		if prev, seen := source[s.SecretKey]; seen && prev <= from {
+			return
+		}
+		source[s.SecretKey] = from
+		selected[s.SecretKey] = s
+	}
 	for _, secret := range secretsConfig {
+		if !strings.Contains(secret.Value, "$") {
+			add(models.Secret{SecretKey: secret.Name, SecretValue: secret.Value}, fromLiteral)
+			continue
+		}
 		if s, exists := secretMap[secret.Name]; exists {
-			if strings.Contains(secret.Value, "$") {
-				allSecrets = append(allSecrets, s)
-				injectedSecrets[s.SecretKey] = true
-			}
+			add(s, fromProvider)
+			continue
+		}
+		if value := os.Getenv(secret.Name); value != "" {
+			add(models.Secret{SecretKey: secret.Name, SecretValue: value}, fromEnvironment)
 		}
 	}
-
-	// Add remaining secrets
-	for _, s := range secretsConfig {
-		if !injectedSecrets[s.Name] {
-			if !strings.Contains(s.Value, "$") {
-				allSecrets = append(allSecrets, models.Secret{SecretKey: s.Name, SecretValue: s.Value})
-			} else {
-				value := os.Getenv(s.Name)
-				if value == "" {
-					log.Printf("Secret %s not found in environment", s.Name)
-				} else {
-					allSecrets = append(allSecrets, models.Secret{SecretKey: s.Name, SecretValue: value})
-				}
-			}
+	for _, secret := range secretsConfig {
+		if _, ok := selected[secret.Name]; !ok {
+			log.Printf("Secret %s not found in environment", secret.Name)
 		}
 	}
 
+	allSecrets := make([]models.Secret, 0, len(selected))
+	for _, key := range slices.Sorted(maps.Keys(selected)) {
+		if !ValidEnvName(key) {
+			// runners refuse to run with it, so leave it out
+			log.Printf("Warning: dropping secret %q, which is not a valid env var name", key)
+			continue
+		}
+		allSecrets = append(allSecrets, selected[key])
+	}
 	return allSecrets
 }
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidEnvName reports whether name is an env var name a shell accepts:
+// letters, digits and underscores, not starting with a digit.
+func ValidEnvName(name string) bool {
+	return envNamePattern.MatchString(name)
+}
+
+// secretSource is where a selected secret's value came from, in precedence
+// order.
+type secretSource int
+
+const (
+	fromProvider secretSource = iota
+	fromLiteral
+	fromEnvironment
+)
+
+// stripPrefix removes the first matching prefix from key and returns its
+// precedence: the prefix's index, or len(prefixes) for unprefixed keys.
+func stripPrefix(key string, prefixes []string) (string, int) {
+	for i, p := range prefixes {
+		if p != "" && strings.HasPrefix(key, p) && len(key) > len(p) {
+			return key[len(p):], i
+		}
+	}
+	return key, len(prefixes)
+}
+
+// SelectSecrets narrows secrets to those carrying one of tags, plus those
+// named in names. Secrets without tags, such as literal and environment
+// values, are only selected by name.
+func SelectSecrets(secrets []models.Secret, tags, names []string) []models.Secret {
+	selected := make([]models.Secret, 0, len(secrets))
+	for _, s := range secrets {
+		if slices.Contains(names, s.SecretKey) || slices.ContainsFunc(tags, func(tag string) bool { return HasTag(s, tag) }) {
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
+
+// HasTag reports whether secret carries tag. The Infisical SDK does not
+// return a secret's tags, so they are read from its metadata: "tier:db"
+// matches the metadata entry tier=db and "tier" any entry keyed tier.
+func HasTag(secret models.Secret, tag string) bool {
+	key, value, hasValue := strings.Cut(tag, ":")
+	for _, m := range secret.SecretMetadata {
+		if m.Key == key && (!hasValue || m.Value == value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// auditedMethods are the RPCs that change jobs, schedules or server state.
+var auditedMethods = map[string]bool{
+	proto.JobsService_RunJob_FullMethodName:             true,
+	proto.JobsService_RunJobs_FullMethodName:            true,
+	proto.JobsService_RunNamedJob_FullMethodName:        true,
+	proto.JobsService_DeleteJob_FullMethodName:          true,
+	proto.JobsService_CancelJob_FullMethodName:          true,
+	proto.JobsService_UpdateSchedule_FullMethodName:     true,
+	proto.JobsService_PauseSchedule_FullMethodName:      true,
+	proto.JobsService_ResumeSchedule_FullMethodName:     true,
+	proto.JobsService_ReconcileSchedules_FullMethodName: true,
+	proto.JobsService_SetMaintenanceMode_FullMethodName: true,
+}
+
+type callerKey struct{}
+
+// WithCaller attaches the authenticated identity of the caller to ctx, for
+// an auth interceptor running ahead of AuditInterceptor to report who made
+// the call.
+func WithCaller(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, callerKey{}, identity)
+}
+
+// callerOf identifies who made a call: the identity an auth interceptor
+// attached, else the common name of a verified client certificate, else the
+// peer address.
+func callerOf(ctx context.Context) string {
+	if identity, ok := ctx.Value(callerKey{}).(string); ok && identity != "" {
+		return identity
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		for _, chain := range info.State.VerifiedChains {
+			if len(chain) > 0 && chain[0].Subject.CommonName != "" {
+				return "cn=" + chain[0].Subject.CommonName
+			}
+		}
+	}
+	if p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+// AuditInterceptor records every call of a mutating RPC, with its caller,
+// redacted arguments and outcome, in the store's audit log, or in the server
+// log when there is no store. Failing to record does not fail the call.
+func (s *JobsServer) AuditInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !auditedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		resp, err := handler(ctx, req)
+		s.audit(ctx, info.FullMethod, req, err)
+		return resp, err
+	}
+}
+
+func (s *JobsServer) audit(ctx context.Context, method string, req any, callErr error) {
+	now := s.clock.Now()
+	st := status.Convert(callErr)
+	e := scheduler.AuditEvent{
+		ID:      fmt.Sprintf("%019d-%08x", now.UnixNano(), rand.Uint32()),
+		At:      now.Unix(),
+		Method:  method,
+		Caller:  callerOf(ctx),
+		Args:    s.auditArgs(req),
+		Outcome: st.Code().String(),
+	}
+	if callErr != nil {
+		e.Error = st.Message()
+	}
+	if s.store != nil {
+		// record even when the caller gave up on the call
+		err := s.store.AddAuditEvent(context.WithoutCancel(ctx), e)
+		if err == nil {
+			return
+		}
+		log.Printf("failed to record audit event: %v", err)
+	}
+	log.Printf("audit: method=%s caller=%q outcome=%s error=%q args=%s", e.Method, e.Caller, e.Outcome, e.Error, e.Args)
+}
+
+// auditArgs renders a request as JSON with env values, fields and map
+// entries whose names look secret, and the values of configured secrets
+// redacted, wherever they appear in the request.
+func (s *JobsServer) auditArgs(req any) string {
+	msg, ok := req.(protobuf.Message)
+	if !ok {
+		return ""
+	}
+	msg = protobuf.Clone(msg)
+	redactMessage(msg.ProtoReflect())
+	b, err := protojson.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	args := string(b)
+	for _, secret := range s.config().Jobs.Secrets {
+		if secret.Value != "" {
+			args = strings.ReplaceAll(args, secret.Value, redacted)
+		}
+	}
+	return args
+}
+
+// secretWords mark field names and map keys whose values audit redacts.
+var secretWords = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "private_key"}
+
+func looksSecret(name string) bool {
+	name = strings.ToLower(name)
+	for _, w := range secretWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactMessage redacts m in place: the value of every EnvVar, string fields
+// whose names look secret, and string map values whose keys do.
+func redactMessage(m protoreflect.Message) {
+	_, envVar := m.Interface().(*proto.EnvVar)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			redactMap(fd, v.Map())
+		case fd.IsList():
+			if fd.Kind() == protoreflect.MessageKind {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					redactMessage(list.Get(i).Message())
+				}
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			redactMessage(v.Message())
+		case fd.Kind() == protoreflect.StringKind:
+			if (envVar && fd.Name() == "value") || looksSecret(string(fd.Name())) {
+				m.Set(fd, protoreflect.ValueOfString(redacted))
+			}
+		}
+		return true
+	})
+}
+
+func redactMap(fd protoreflect.FieldDescriptor, mv protoreflect.Map) {
+	val := fd.MapValue()
+	mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		switch {
+		case val.Kind() == protoreflect.MessageKind:
+			redactMessage(v.Message())
+		case val.Kind() == protoreflect.StringKind && looksSecret(k.String()):
+			mv.Set(k, protoreflect.ValueOfString(redacted))
+		}
+		return true
+	})
+}
+
+// ListAuditEvents returns recorded calls of mutating RPCs, most recent first.
+func (s *JobsServer) ListAuditEvents(ctx context.Context, req *proto.ListAuditEventsRequest) (*proto.ListAuditEventsResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no store configured")
+	}
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	events, err := s.store.ListAuditEvents(scheduler.WithReplica(ctx), scheduler.AuditFilter{
+		Method: req.GetMethod(),
+		Caller: req.GetCaller(),
+		Since:  req.GetSince(),
+		Until:  req.GetUntil(),
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*proto.AuditEvent, 0, len(events))
+	for _, e := range events {
+		out = append(out, &proto.AuditEvent{
+			Id:      e.ID,
+			At:      e.At,
+			Method:  e.Method,
+			Caller:  e.Caller,
+			Args:    e.Args,
+			Outcome: e.Outcome,
+			Error:   e.Error,
+		})
+	}
+	return &proto.ListAuditEventsResponse{Items: out}, nil
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticate checks the bearer token in the call's "authorization"
+// metadata against the configured AUTH_TOKEN and API keys, and attaches the
+// caller it identifies to ctx. Only with neither AUTH_TOKEN nor any API key
+// configured is every call let through.
+func (s *JobsServer) authenticate(ctx context.Context) (context.Context, error) {
+	if !s.authRequired() {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	caller, err := s.callerFor(values[0])
+	if err != nil {
+		return nil, err
+	}
+	return WithCaller(ctx, caller), nil
+}
+
+func (s *JobsServer) authRequired() bool {
+	c := s.config()
+	return c.AuthToken != "" || len(c.AuthAPIKeys) > 0
+}
+
+// callerFor names the caller an "authorization" value authenticates: "token"
+// for AUTH_TOKEN, "key=<name>" for an API key.
+func (s *JobsServer) callerFor(authorization string) (string, error) {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+	if tokenMatches(token, s.config().AuthToken) {
+		return "token", nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(s.config().AuthAPIKeys)) {
+		if tokenMatches(token, s.config().AuthAPIKeys[name]) {
+			return "key=" + name, nil
+		}
+	}
+	return "", status.Error(codes.Unauthenticated, "invalid bearer token")
+}
+
+// unauthenticated reports whether method is let through without a token:
+// the gRPC health service, which load balancers and orchestrators probe
+// without credentials, and reflection when GRPC_REFLECTION registered it.
+func (s *JobsServer) unauthenticated(method string) bool {
+	if strings.HasPrefix(method, "/grpc.health.v1.Health/") {
+		return true
+	}
+	return s.config().GRPCReflection && strings.HasPrefix(method, "/grpc.reflection.")
+}
+
+func tokenMatches(token, want string) bool {
+	return want != "" && subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1
+}
+
+// AuthInterceptor rejects unary calls without a valid bearer token with
+// codes.Unauthenticated once AUTH_TOKEN or AUTH_API_KEYS is set. It must run
+// ahead of AuditInterceptor so audited calls carry the caller it identified.
+func (s *JobsServer) AuthInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if s.unauthenticated(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor is AuthInterceptor for streaming calls.
+func (s *JobsServer) AuthStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if s.unauthenticated(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := s.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream carries the caller authenticate identified.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authenticatedStream) Context() context.Context { return a.ctx }
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// runJobsParallelism caps how many jobs of one RunJobs call run at once.
+const runJobsParallelism = 10
+
+// RunJobs runs a fan-out of jobs under one batch ID, so their executions can
+// be queried and tracked together. Each job is run as by RunJob; a failing
+// job does not stop the others.
+func (s *JobsServer) RunJobs(ctx context.Context, req *proto.RunJobsRequest) (*proto.RunJobsResponse, error) {
+	if len(req.GetJobs()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "jobs are required")
+	}
+	batchID := req.GetBatchId()
+	if batchID == "" {
+		suffix := make([]byte, 4)
+		_, _ = rand.Read(suffix)
+		batchID = fmt.Sprintf("batch-%d-%s", s.clock.Now().Unix(), hex.EncodeToString(suffix))
+	}
+
+	results := make([]*proto.RunJobsResult, len(req.GetJobs()))
+	sem := make(chan struct{}, runJobsParallelism)
+	var wg sync.WaitGroup
+	for i, job := range req.GetJobs() {
+		job.BatchId = batchID
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := &proto.RunJobsResult{}
+			if err := safely("RunJobs "+job.GetName(), func() {
+				resp, err := s.RunJob(ctx, job)
+				if err != nil {
+					result.Error = err.Error()
+				} else {
+					result.Id = resp.GetId()
+				}
+			}); err != nil {
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	return &proto.RunJobsResponse{BatchId: batchID, Results: results}, nil
+}
+
+// GetBatchStatus aggregates the executions of a batch by outcome.
+func (s *JobsServer) GetBatchStatus(ctx context.Context, req *proto.GetBatchStatusRequest) (*proto.GetBatchStatusResponse, error) {
+	if req.GetBatchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "batch_id is required")
+	}
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no store configured")
+	}
+	recs, err := s.store.ListExecutions(ctx, scheduler.ExecutionFilter{BatchID: req.GetBatchId()})
+	if err != nil {
+		return nil, err
+	}
+	if len(recs) == 0 {
+		return nil, status.Errorf(codes.NotFound, "batch %s not found", req.GetBatchId())
+	}
+	resp := &proto.GetBatchStatusResponse{BatchId: req.GetBatchId(), Total: int32(len(recs))}
+	for _, e := range recs {
+		switch e.Status {
+		case "running", "starting", "submitted":
+			resp.Running++
+		case "success":
+			resp.Succeeded++
+		default:
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
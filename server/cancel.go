@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errCancelled marks a run stopped by CancelJob.
+var errCancelled = errors.New("cancelled")
+
+// CancelJob stops one execution and records it as "cancelled", keeping its
+// schedule. A run in flight on this server has its context cancelled, which
+// stops a local container, and its runner is asked to delete the job so a
+// cloud job stops too. A run a cloud provider only accepted is deleted there.
+func (s *JobsServer) CancelJob(ctx context.Context, req *proto.CancelJobRequest) (*proto.CancelJobResponse, error) {
+	id := req.GetId()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	s.mu.Lock()
+	run, ok := s.inflight[id]
+	if ok {
+		run.cancelled = true
+		s.inflight[id] = run
+	}
+	s.mu.Unlock()
+	if ok {
+		// the run records itself as cancelled once it has stopped
+		run.cancel()
+		if err := run.rn.DeleteJob(ctx, id); err != nil {
+			return nil, err
+		}
+		return &proto.CancelJobResponse{}, nil
+	}
+
+	if s.store == nil {
+		return nil, status.Errorf(codes.NotFound, "job %s is not running", id)
+	}
+	rec, err := s.store.GetExecution(ctx, id)
+	if errors.Is(err, scheduler.ErrExecutionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "execution %s not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rec.Status != "submitted" {
+		return nil, status.Errorf(codes.FailedPrecondition, "execution %s is %s, not running on this server", id, rec.Status)
+	}
+	rn, _, err := s.submitterOf(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := rn.DeleteJob(ctx, id); err != nil {
+		return nil, err
+	}
+	rec.Status = "cancelled"
+	rec.Error = errCancelled.Error()
+	rec.FinishedAt = s.clock.Now().Unix()
+	if err := s.store.AddExecution(ctx, rec); err != nil {
+		return nil, err
+	}
+	return &proto.CancelJobResponse{}, nil
+}
+
+// cancelledErr returns the error a run ended with, marked as cancelled when
+// CancelJob stopped it or as interrupted when Shutdown did. A run that
+// finished cleanly before it could be stopped keeps its success.
+func (s *JobsServer) cancelledErr(id string, runErr error) error {
+	if runErr == nil {
+		return nil
+	}
+	s.mu.Lock()
+	run := s.inflight[id]
+	s.mu.Unlock()
+	switch {
+	case run.cancelled:
+		return fmt.Errorf("%w: %v", errCancelled, runErr)
+	case run.interrupted:
+		return fmt.Errorf("%w: %v", errInterrupted, runErr)
+	}
+	return runErr
+}
+
+// isCancelled reports whether CancelJob stopped the run in flight with id.
+func (s *JobsServer) isCancelled(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inflight[id].cancelled
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"log"
+	"reflect"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// config is the server's current config. Take it once per use, as
+// ReloadConfig may swap it at any time.
+func (s *JobsServer) config() *cfg.Config {
+	return s.conf.Load()
+}
+
+// Config returns the server's current config, as last swapped in by
+// ReloadConfig.
+func (s *JobsServer) Config() *cfg.Config {
+	return s.config()
+}
+
+// ReloadConfig swaps in jobs as the jobs config, so later runs and lookups
+// such as GetResourcesFor use it, cmd included, and re-registers the stored
+// schedules whose jobs changed with it: with the in-memory scheduler, or at
+// the provider when it schedules them. Missed ticks are not caught up and
+// deferred runs are left alone. A config that fails Validate is rejected and
+// the current one kept; an unchanged one is a no-op. The image the runners
+// were built with is not passed to them per run, so a changed top-level image
+// is ignored with a warning; it, the runner profiles and the secrets the
+// runners hold keep their startup values until a restart, though the secret
+// list applies from the next SIGHUP.
+func (s *JobsServer) ReloadConfig(ctx context.Context, jobs *cfg.JobsConfig) error {
+	old := s.config()
+	c := *old
+	if reflect.DeepEqual(c.Jobs, *jobs) {
+		return nil
+	}
+	c.Jobs = *jobs
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if c.Jobs.Image != old.Jobs.Image {
+		log.Printf("jobs config: image changed from %q to %q; keeping %q until a restart", old.Jobs.Image, c.Jobs.Image, old.Jobs.Image)
+		c.Jobs.Image = old.Jobs.Image
+	}
+	s.conf.Store(&c)
+	log.Printf("jobs config reloaded: %d job(s)", len(c.Jobs.Jobs))
+	s.rescheduleChanged(ctx, &old.Jobs, &c.Jobs)
+	return nil
+}
+
+// rescheduleChanged re-registers the stored schedules of the jobs whose
+// config differs between before and after, or of every job when a setting
+// beyond the per-job ones changed.
+func (s *JobsServer) rescheduleChanged(ctx context.Context, before, after *cfg.JobsConfig) {
+	if s.store == nil {
+		return
+	}
+	all, changed := changedJobs(before, after)
+	if !all && len(changed) == 0 {
+		return
+	}
+	recs, err := s.store.List(ctx)
+	if err != nil {
+		log.Printf("jobs config: schedules not updated: %v", err)
+		return
+	}
+	for _, rec := range recs {
+		if rec.RunAt != 0 || rec.CronSpec == "" || (!all && !changed[rec.Command]) {
+			continue
+		}
+		if err := s.reschedule(ctx, rec); err != nil {
+			log.Printf("jobs config: failed to update schedule %s: %v", rec.Name, err)
+		}
+	}
+}
+
+// reschedule re-registers the stored schedule rec under the current config.
+func (s *JobsServer) reschedule(ctx context.Context, rec scheduler.JobRecord) error {
+	rn, req, err := s.storedRequest(rec)
+	if err != nil {
+		return err
+	}
+	if s.sched != nil {
+		_, err = s.register(rec, rn, req)
+		return err
+	}
+	return s.updateProviderSchedule(ctx, rn, rec)
+}
+
+// changedJobs names the jobs whose config differs between before and after,
+// or reports all when a setting beyond the per-job ones changed.
+func changedJobs(before, after *cfg.JobsConfig) (all bool, changed map[string]bool) {
+	b, a := *before, *after
+	b.Jobs, a.Jobs = nil, nil
+	if !reflect.DeepEqual(b, a) {
+		return true, nil
+	}
+	jobs := map[string]cfg.JobConfig{}
+	for _, job := range before.Jobs {
+		jobs[job.Name] = job
+	}
+	changed = map[string]bool{}
+	for _, job := range after.Jobs {
+		if prev, ok := jobs[job.Name]; !ok || !reflect.DeepEqual(prev, job) {
+			changed[job.Name] = true
+		}
+		delete(jobs, job.Name)
+	}
+	for name := range jobs {
+		changed[name] = true
+	}
+	return false, changed
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unfinishedStatuses are the execution statuses of runs that have not ended.
+var unfinishedStatuses = []string{"submitted", "starting", "running"}
+
+// beginUniqueRun is beginRun for deduplicated runs: it fails with
+// codes.AlreadyExists while a run of the same name, deduplicated or not, is
+// in flight on this server, or is recorded in the store as not having ended,
+// e.g. because another replica or a cloud provider is running it.
+func (s *JobsServer) beginUniqueRun(ctx context.Context, run inflightRun) error {
+	if s.store != nil {
+		for _, st := range unfinishedStatuses {
+			recs, err := s.store.ListExecutions(ctx, scheduler.ExecutionFilter{Name: run.req.Name, Status: st, Limit: 1})
+			if err != nil {
+				return status.Errorf(codes.Unavailable, "failed to check for runs of %s: %v", run.req.Name, err)
+			}
+			if len(recs) > 0 {
+				return status.Errorf(codes.AlreadyExists, "job %s is already %s as %s", run.req.Name, st, recs[0].ID)
+			}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, other := range s.inflight {
+		if other.req.Name == run.req.Name {
+			return status.Errorf(codes.AlreadyExists, "job %s is already running as %s", run.req.Name, id)
+		}
+	}
+	if !s.beginRunLocked(run) {
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deferRun schedules a one-time job to run once at req.RunAt and persists it,
+// so a restart reschedules it. The run is keyed by an ID of its own, which
+// the response returns and DeleteJob cancels it by, so it replaces neither a
+// schedule of the same name nor other deferred runs of the job.
+func (s *JobsServer) deferRun(ctx context.Context, req *proto.RunJobRequest, rn runner.Runner, r runner.JobRequest, profile string) (*proto.RunJobResponse, error) {
+	if r.Type != runner.JobTypeOneTime {
+		return nil, status.Error(codes.InvalidArgument, "run_at only applies to one-time jobs")
+	}
+	if s.sched == nil {
+		return nil, status.Error(codes.FailedPrecondition, "run_at requires the local provider with a store")
+	}
+	at := time.Unix(req.GetRunAt(), 0)
+	if !at.After(s.clock.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "run_at must be in the future")
+	}
+	id := deferredID(r.Name, at)
+	runIfMissed := true
+	if req.RunIfMissed != nil {
+		runIfMissed = req.GetRunIfMissed()
+	}
+	err := s.withinScheduleLimit(ctx, id, func() error {
+		err := s.store.Upsert(ctx, scheduler.JobRecord{
+			Name:        id,
+			JobName:     r.Name,
+			Command:     r.Command,
+			ArgsBase64:  r.ArgsJSONBase64,
+			Cpu:         r.Resources.CPU,
+			Memory:      r.Resources.Memory,
+			Runner:      profile,
+			RunAt:       at.Unix(),
+			RunIfMissed: runIfMissed,
+			Labels:      r.Labels,
+			Timeout:     r.Timeout,
+			MaxRetries:  r.MaxRetries,
+		})
+		if err != nil {
+			return err
+		}
+		s.sched.ScheduleAt(id, at, s.deferredRun(id, rn, r), scheduler.WithTimeout(s.config().GetTimeoutFor(r.Command)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RunJobResponse{Id: id, Logs: "scheduled for " + at.UTC().Format(time.RFC3339)}, nil
+}
+
+// deferredID keys a deferred run of the named job at at.
+func deferredID(name string, at time.Time) string {
+	return fmt.Sprintf("%s@%d-%s", name, at.Unix(), uuid.NewString()[:8])
+}
+
+// deferredRun builds the callback of the deferred one-time job id. The job is
+// forgotten before it runs, so a crash mid-run never runs it twice.
+func (s *JobsServer) deferredRun(id string, rn runner.Runner, r runner.JobRequest) scheduler.JobFunc {
+	return func(c context.Context) {
+		_ = safely("deferred run "+id, func() {
+			s.sched.Delete(id)
+			if err := s.store.Delete(context.Background(), id); err != nil {
+				log.Printf("failed to remove deferred job %s: %v", id, err)
+			}
+			_ = s.runTick(c, rn, r)
+		})
+	}
+}
+
+// restoreDeferred reschedules a deferred one-time job after a restart. Jobs
+// whose time passed while the server was down run now if they asked to,
+// and are dropped otherwise.
+func (s *JobsServer) restoreDeferred(rec scheduler.JobRecord, rn runner.Runner, r runner.JobRequest) {
+	r.Type, r.ScheduleSpec = runner.JobTypeOneTime, ""
+	if rec.JobName != "" {
+		// records stored before deferred runs had IDs are keyed by the job
+		r.Name = rec.JobName
+	}
+	at := time.Unix(rec.RunAt, 0)
+	run := s.deferredRun(rec.Name, rn, r)
+	timeout := s.config().GetTimeoutFor(r.Command)
+	if at.After(s.clock.Now()) {
+		s.sched.ScheduleAt(rec.Name, at, run, scheduler.WithTimeout(timeout))
+		return
+	}
+	if !rec.RunIfMissed {
+		log.Printf("dropping one-time job %s: its run at %s was missed", rec.Name, at.UTC().Format(time.RFC3339))
+		if err := s.store.Delete(context.Background(), rec.Name); err != nil {
+			log.Printf("failed to remove deferred job %s: %v", rec.Name, err)
+		}
+		return
+	}
+	log.Printf("running one-time job %s missed at %s", rec.Name, at.UTC().Format(time.RFC3339))
+	go func() {
+		ctx, cancel := context.Background(), context.CancelFunc(func() {})
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		defer cancel()
+		run(ctx)
+	}()
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const redacted = "<redacted>"
+
+// resolveResources falls back to the jobs.yml resources for a command run on
+// the given runner profile when the request did not specify any.
+func (s *JobsServer) resolveResources(command, profile string, requested runner.Resources) runner.Resources {
+	if requested != (runner.Resources{}) {
+		return requested
+	}
+	res := s.config().GetResourcesFor(command, profile)
+	return runner.Resources{CPU: res.CPU, Memory: res.Memory, GPUCount: res.GPUCount, GPUType: res.GPUType}
+}
+
+// GetEffectiveJobConfig reports what a job will run with once config defaults
+// and stored schedules are applied. The name is matched against stored
+// schedules first and otherwise treated as a jobs.yml command key.
+func (s *JobsServer) GetEffectiveJobConfig(ctx context.Context, req *proto.GetEffectiveJobConfigRequest) (*proto.GetEffectiveJobConfigResponse, error) {
+	name := req.GetName()
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	command := name
+	var requested runner.Resources
+	var schedule, profile string
+	if s.store != nil {
+		recs, err := s.store.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range recs {
+			if rec.Name == name {
+				command = rec.Command
+				requested = runner.Resources{CPU: rec.Cpu, Memory: rec.Memory}
+				schedule = runner.ZonedSpec(rec.CronSpec, rec.TimeZone)
+				profile = rec.Runner
+				break
+			}
+		}
+	}
+
+	if _, resolved, err := s.runnerFor(profile, command); err == nil {
+		profile = resolved
+	}
+	res := s.resolveResources(command, profile, requested)
+	out := &proto.GetEffectiveJobConfigResponse{
+		Name:          name,
+		Runner:        profile,
+		Provider:      s.providerFor(profile),
+		Image:         s.config().Jobs.Image,
+		CommandPrefix: s.config().Jobs.Cmd,
+		Command:       command,
+		Resources:     &proto.Resources{Cpu: res.CPU, Memory: res.Memory},
+		Schedule:      schedule,
+	}
+	for _, secret := range s.config().Jobs.Secrets {
+		out.Env = append(out.Env, &proto.EnvVar{Name: secret.Name, Value: redacted})
+	}
+	if job, ok := s.config().GetJobConfig(command); ok {
+		if job.Timeout > 0 {
+			out.Timeout = job.Timeout.String()
+		}
+		for _, code := range job.SuccessExitCodes {
+			out.SuccessExitCodes = append(out.SuccessExitCodes, int32(code))
+		}
+		out.SuccessOutputRegex = job.SuccessOutputRegex
+		if job.Image != "" {
+			out.Image = job.Image
+		}
+	}
+	if retry := s.config().GetRetryFor(command); retry.MaxRetries > 0 {
+		out.Retry = &proto.RetryPolicy{
+			MaxRetries:   int32(retry.MaxRetries),
+			InitialDelay: retry.Delay(1).String(),
+			Multiplier:   retry.Multiplier,
+		}
+		if out.Retry.Multiplier <= 0 {
+			out.Retry.Multiplier = 2
+		}
+		if retry.MaxDelay > 0 {
+			out.Retry.MaxDelay = retry.MaxDelay.String()
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"github.com/SyneHQ/apollo/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkEnvOverrides rejects client env overrides that the job's env policy
+// reserves for the server, so they cannot shadow managed variables.
+func (s *JobsServer) checkEnvOverrides(req *proto.RunJobRequest) error {
+	env := req.GetOverrides().GetEnv()
+	if len(env) == 0 {
+		return nil
+	}
+	policy := s.config().GetEnvPolicyFor(req.GetCommand())
+	for _, v := range env {
+		if err := policy.Check(v.GetName()); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// costPollInterval is how often a submitted cloud run is checked for
+// completion so its cost can be estimated.
+var costPollInterval = time.Minute
+
+// ListExecutions returns recorded executions, most recent first.
+func (s *JobsServer) ListExecutions(ctx context.Context, req *proto.ListExecutionsRequest) (*proto.ListExecutionsResponse, error) {
+	if s.store == nil {
+		return &proto.ListExecutionsResponse{Items: []*proto.ExecutionItem{}}, nil
+	}
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	var metrics []scheduler.MetricFilter
+	for _, m := range req.GetMetrics() {
+		f, err := scheduler.ParseMetricFilter(m)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		metrics = append(metrics, f)
+	}
+	if err := checkFilters(req.GetLabels(), len(metrics)); err != nil {
+		return nil, err
+	}
+	recs, err := s.store.ListExecutions(scheduler.WithReplica(ctx), scheduler.ExecutionFilter{
+		Name:    req.GetName(),
+		Status:  req.GetStatus(),
+		Since:   req.GetSince(),
+		Until:   req.GetUntil(),
+		Limit:   int(req.GetLimit()),
+		Offset:  int(req.GetOffset()),
+		Labels:  req.GetLabels(),
+		BatchID: req.GetBatchId(),
+		Metrics: metrics,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*proto.ExecutionItem, 0, len(recs))
+	for _, e := range recs {
+		out = append(out, executionItem(e))
+	}
+	return &proto.ListExecutionsResponse{Items: out}, nil
+}
+
+// GetExecution returns a single execution, including the progress the job
+// last reported.
+func (s *JobsServer) GetExecution(ctx context.Context, req *proto.GetExecutionRequest) (*proto.ExecutionItem, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no store configured")
+	}
+	e, err := s.store.GetExecution(ctx, req.GetId())
+	if errors.Is(err, scheduler.ErrExecutionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "execution %s not found", req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return executionItem(e), nil
+}
+
+func executionItem(e scheduler.ExecutionRecord) *proto.ExecutionItem {
+	return &proto.ExecutionItem{
+		Id:                e.ID,
+		Name:              e.Name,
+		Command:           e.Command,
+		Status:            e.Status,
+		Error:             e.Error,
+		StartedAt:         e.StartedAt,
+		FinishedAt:        e.FinishedAt,
+		EstimatedCost:     e.EstimatedCost,
+		RetryDecision:     e.RetryDecision,
+		Progress:          e.Progress,
+		ProgressMessage:   e.ProgressMessage,
+		ProgressUpdatedAt: e.ProgressUpdatedAt,
+		Labels:            e.Labels,
+		BatchId:           e.BatchID,
+		ResultHash:        e.ResultHash,
+		ResultUnchanged:   e.ResultUnchanged,
+		Metrics:           e.Metrics,
+	}
+}
+
+// CostReport sums the estimated cost of a job's executions since a point in
+// time.
+func (s *JobsServer) CostReport(ctx context.Context, req *proto.CostReportRequest) (*proto.CostReportResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no store configured")
+	}
+	if err := checkFilters(req.GetLabels(), 0); err != nil {
+		return nil, err
+	}
+	recs, err := s.store.ListExecutions(scheduler.WithReplica(ctx), scheduler.ExecutionFilter{Name: req.GetName(), Since: req.GetSince(), Labels: req.GetLabels()})
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.CostReportResponse{Name: req.GetName(), Executions: int32(len(recs))}
+	for _, e := range recs {
+		resp.EstimatedCost += e.EstimatedCost
+	}
+	return resp, nil
+}
+
+// checkFilters rejects requests with more label and metric filters than an
+// execution query takes.
+func checkFilters(labels map[string]string, metrics int) error {
+	if len(labels)+metrics > scheduler.MaxFilters {
+		return status.Errorf(codes.InvalidArgument, "at most %d label and metric filters are allowed", scheduler.MaxFilters)
+	}
+	return nil
+}
+
+// executionLabels merges a run's labels over the server's default labels.
+func (s *JobsServer) executionLabels(labels map[string]string) map[string]string {
+	if len(s.config().DefaultLabels) == 0 {
+		return labels
+	}
+	merged := maps.Clone(s.config().DefaultLabels)
+	maps.Copy(merged, labels)
+	return merged
+}
+
+// hashResult sets the result hash of a finished run of a job configured
+// with hash_result, and whether a successful run's output is the same as that
+// of the previous successful run of the same name.
+func (s *JobsServer) hashResult(ctx context.Context, rec *scheduler.ExecutionRecord) {
+	if job, ok := s.config().GetJobConfig(rec.Command); !ok || !job.HashResult {
+		return
+	}
+	sum := sha256.Sum256([]byte(rec.Result))
+	rec.ResultHash = hex.EncodeToString(sum[:])
+	if rec.Status != "success" {
+		return
+	}
+	prev, err := s.store.ListExecutions(ctx, scheduler.ExecutionFilter{Name: rec.Name, Status: "success", Limit: 1, OmitResult: true})
+	if err != nil {
+		log.Printf("failed to read the previous run of %s: %v", rec.Name, err)
+		return
+	}
+	rec.ResultUnchanged = len(prev) > 0 && prev[0].ResultHash == rec.ResultHash
+}
+
+// trackCost waits in the background for a submitted run to finish on a
+// runner that can price it, then stores the estimate on its execution. name
+// is the run's result, whose first line is the provider's job name.
+func (s *JobsServer) trackCost(rn runner.Runner, id, name string) {
+	name, _, _ = strings.Cut(name, "\n")
+	estimator, ok := rn.(runner.CostEstimator)
+	if !ok || s.store == nil || name == "" {
+		return
+	}
+	go safely("cost of "+id, func() {
+		ticker := time.NewTicker(costPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.quit:
+				return
+			case <-ticker.C:
+			}
+			cost, done, err := estimator.EstimateCost(context.Background(), name)
+			if err != nil {
+				log.Printf("failed to estimate cost of %s: %v", id, err)
+			}
+			if !done {
+				continue
+			}
+			if err == nil {
+				if err := s.store.SetExecutionCost(context.Background(), id, cost); err != nil {
+					log.Printf("failed to store cost of %s: %v", id, err)
+				}
+			}
+			return
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportContentTypes are the formats executions export to, with the content
+// type each is served as.
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json",
+}
+
+// exportChunkSize is how much of an export each streamed chunk carries.
+const exportChunkSize = 32 << 10
+
+// ExportExecutions streams the executions matching the request as CSV or a
+// JSON array, most recent first, for analysis outside Apollo.
+func (s *JobsServer) ExportExecutions(req *proto.ExportExecutionsRequest, stream proto.JobsService_ExportExecutionsServer) error {
+	format := req.GetFormat()
+	if format == "" {
+		format = "csv"
+	}
+	if _, ok := exportContentTypes[format]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown export format %q: want csv or json", format)
+	}
+	if s.store == nil {
+		return status.Error(codes.FailedPrecondition, "no store configured")
+	}
+	if err := checkFilters(req.GetLabels(), 0); err != nil {
+		return err
+	}
+	w := bufio.NewWriterSize(chunkWriter{stream}, exportChunkSize)
+	if err := s.exportExecutions(stream.Context(), w, format, scheduler.ExecutionFilter{
+		Name:       req.GetName(),
+		Since:      req.GetSince(),
+		Until:      req.GetUntil(),
+		Labels:     req.GetLabels(),
+		BatchID:    req.GetBatchId(),
+		Status:     req.GetStatus(),
+		OmitResult: req.GetExcludeResult(),
+	}); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// chunkWriter sends each write as one export chunk.
+type chunkWriter struct {
+	stream proto.JobsService_ExportExecutionsServer
+}
+
+func (c chunkWriter) Write(p []byte) (int, error) {
+	if err := c.stream.Send(&proto.ExportExecutionsChunk{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// exportedExecution is the JSON shape of an exported execution.
+type exportedExecution struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Command       string            `json:"command"`
+	Status        string            `json:"status"`
+	Error         string            `json:"error"`
+	StartedAt     int64             `json:"started_at"`
+	FinishedAt    int64             `json:"finished_at"`
+	EstimatedCost float64           `json:"estimated_cost"`
+	RetryDecision string            `json:"retry_decision"`
+	BatchID       string            `json:"batch_id"`
+	Labels        map[string]string `json:"labels"`
+	Result        *string           `json:"result,omitempty"`
+}
+
+var exportColumns = []string{"id", "name", "command", "status", "error", "started_at", "finished_at",
+	"estimated_cost", "retry_decision", "batch_id", "labels", "result"}
+
+// exportExecutions writes the executions matching f to w in format, reading
+// them from the store, or its read replica, page by page.
+func (s *JobsServer) exportExecutions(ctx context.Context, w io.Writer, format string, f scheduler.ExecutionFilter) error {
+	ctx = scheduler.WithReplica(ctx)
+	if format == "json" {
+		sep := "["
+		err := s.store.IterateExecutions(ctx, f, func(e scheduler.ExecutionRecord) error {
+			out := exportedExecution{
+				ID: e.ID, Name: e.Name, Command: e.Command, Status: e.Status, Error: e.Error,
+				StartedAt: e.StartedAt, FinishedAt: e.FinishedAt, EstimatedCost: e.EstimatedCost,
+				RetryDecision: e.RetryDecision, BatchID: e.BatchID, Labels: e.Labels,
+			}
+			if !f.OmitResult {
+				out.Result = &e.Result
+			}
+			b, err := json.Marshal(out)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, sep+"\n"); err != nil {
+				return err
+			}
+			sep = ","
+			_, err = w.Write(b)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if sep == "[" {
+			_, err = io.WriteString(w, "[]\n")
+		} else {
+			_, err = io.WriteString(w, "\n]\n")
+		}
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	columns := exportColumns
+	if f.OmitResult {
+		columns = columns[:len(columns)-1]
+	}
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	err := s.store.IterateExecutions(ctx, f, func(e scheduler.ExecutionRecord) error {
+		row := []string{e.ID, e.Name, e.Command, e.Status, e.Error,
+			strconv.FormatInt(e.StartedAt, 10), strconv.FormatInt(e.FinishedAt, 10),
+			strconv.FormatFloat(e.EstimatedCost, 'f', -1, 64), e.RetryDecision, e.BatchID, formatLabels(e.Labels)}
+		if !f.OmitResult {
+			row = append(row, e.Result)
+		}
+		return cw.Write(row)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatLabels renders labels the way DEFAULT_LABELS spells them: sorted
+// "k=v" pairs separated by commas.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// exportHandler serves GET /executions/export. The format comes from the
+// format parameter, else the Accept header, else CSV; filters mirror
+// ExportExecutionsRequest, with labels given as repeated label=k=v.
+func (s *JobsServer) exportHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if format == "" {
+		format = negotiateExportFormat(r.Header.Get("Accept"))
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown export format %q: want csv or json", format), http.StatusBadRequest)
+		return
+	}
+	f := scheduler.ExecutionFilter{
+		Name:       q.Get("name"),
+		BatchID:    q.Get("batch_id"),
+		Status:     q.Get("status"),
+		OmitResult: q.Get("exclude_result") == "true",
+	}
+	for param, dst := range map[string]*int64{"since": &f.Since, "until": &f.Until} {
+		if v := q.Get(param); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s %q: want unix seconds", param, v), http.StatusBadRequest)
+				return
+			}
+			*dst = n
+		}
+	}
+	for _, label := range q["label"] {
+		k, v, ok := strings.Cut(label, "=")
+		if !ok || k == "" {
+			http.Error(w, fmt.Sprintf("invalid label %q: want key=value", label), http.StatusBadRequest)
+			return
+		}
+		if f.Labels == nil {
+			f.Labels = map[string]string{}
+		}
+		f.Labels[k] = v
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "executions." + format}))
+	bw := bufio.NewWriterSize(w, exportChunkSize)
+	if err := s.exportExecutions(r.Context(), bw, format, f); err != nil {
+		// the status line is gone once rows were written; cut the body short
+		log.Printf("execution export failed: %v", err)
+		return
+	}
+	if err := bw.Flush(); err != nil {
+		log.Printf("execution export failed: %v", err)
+	}
+}
+
+// negotiateExportFormat picks the first export format an Accept header
+// names, defaulting to CSV.
+func negotiateExportFormat(accept string) string {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "application/json":
+			return "json"
+		case "text/csv":
+			return "csv"
+		}
+	}
+	return "csv"
+}
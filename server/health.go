@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SyneHQ/apollo/runner"
+)
+
+// errStarting marks a run whose container is up but has not passed its
+// health check yet.
+var errStarting = errors.New("waiting for the health check")
+
+// healthCheckFor returns the runner health check configured for a command.
+func (s *JobsServer) healthCheckFor(command string) *runner.HealthCheck {
+	hc := s.config().GetHealthCheckFor(command)
+	if hc == nil {
+		return nil
+	}
+	return &runner.HealthCheck{
+		Command:  hc.Command,
+		HTTPURL:  hc.HTTP,
+		Interval: hc.Interval,
+		Timeout:  hc.Timeout,
+	}
+}
+
+// recordStart records a run as "running". Runs with a health check are
+// "starting" instead until the runner reports them healthy, at which point
+// their start time moves to that moment.
+func (s *JobsServer) recordStart(ctx context.Context, r *runner.JobRequest, start *int64) {
+	if r.HealthCheck == nil {
+		s.recordExecution(ctx, *r, r.JobID, "", nil, *start, 0)
+		return
+	}
+	s.recordExecution(ctx, *r, r.JobID, "", errStarting, *start, 0)
+	run := *r
+	r.OnStarted = func(at time.Time) {
+		*start = at.Unix()
+		s.recordExecution(ctx, run, run.JobID, "", nil, *start, 0)
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/api/idtoken"
+)
+
+// TokenVerifier authenticates the bearer token of an HTTP request.
+type TokenVerifier func(ctx context.Context, token string) error
+
+// GoogleOIDCVerifier accepts Google-signed OIDC tokens issued for audience
+// to the service account email, such as those Cloud Scheduler attaches to
+// its HTTP targets. Any Google account can mint a token for any audience, so
+// the audience alone does not identify the caller.
+func GoogleOIDCVerifier(audience, email string) TokenVerifier {
+	return func(ctx context.Context, token string) error {
+		payload, err := idtoken.Validate(ctx, token, audience)
+		if err != nil {
+			return err
+		}
+		return checkTokenEmail(payload.Claims, email)
+	}
+}
+
+// checkTokenEmail requires the verified email claim of a token to be email.
+func checkTokenEmail(claims map[string]any, email string) error {
+	if verified, _ := claims["email_verified"].(bool); !verified {
+		return errors.New("token email is not verified")
+	}
+	if got, _ := claims["email"].(string); email == "" || got != email {
+		return fmt.Errorf("token is for %q, not %q", got, email)
+	}
+	return nil
+}
+
+// HTTPHandler serves Apollo's HTTP endpoints. Schedule triggers are only
+// served when verify is set, since they start runs. Execution exports expose
+// history, so they are only served once AUTH_TOKEN or AUTH_API_KEYS is set,
+// to the callers the gRPC API accepts. Progress reports are served when
+// progress reporting is configured, authenticated by execution-scoped tokens.
+func (s *JobsServer) HTTPHandler(verify TokenVerifier) http.Handler {
+	mux := http.NewServeMux()
+	if verify != nil {
+		mux.Handle("POST /schedules/{name}/trigger", s.authenticated(verify, http.HandlerFunc(s.triggerSchedule)))
+	}
+	if s.store != nil && s.authRequired() {
+		mux.Handle("GET /executions/export", s.authenticatedCaller(http.HandlerFunc(s.exportHandler)))
+	}
+	if s.config().ProgressURL != "" && s.store != nil {
+		mux.HandleFunc("POST /executions/{id}/progress", s.reportProgress)
+	}
+	return mux
+}
+
+func (s *JobsServer) authenticated(verify TokenVerifier, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || verify(r.Context(), token) != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticatedCaller checks a request's bearer token as the gRPC API does.
+func (s *JobsServer) authenticatedCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, err := s.callerFor(r.Header.Get("Authorization"))
+		if err != nil {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithCaller(r.Context(), caller)))
+	})
+}
+
+// recordRequest is the request a run of a stored schedule is made with.
+func (s *JobsServer) recordRequest(rec scheduler.JobRecord) runner.JobRequest {
+	// an unknown profile fails the run itself; size it as the primary's meanwhile
+	_, profile, _ := s.runnerFor(rec.Runner, rec.Command)
+	req := runner.JobRequest{
+		Name:           rec.Name,
+		Command:        rec.Command,
+		ArgsJSONBase64: rec.ArgsBase64,
+		Resources:      s.resolveResources(rec.Command, profile, runner.Resources{CPU: rec.Cpu, Memory: rec.Memory}),
+		Type:           runner.JobTypeRepeatable,
+		ScheduleSpec:   rec.CronSpec,
+		TimeZone:       rec.TimeZone,
+		HealthCheck:    s.healthCheckFor(rec.Command),
+		Labels:         rec.Labels,
+		MachineType:    s.config().GetMachineTypeFor(rec.Command),
+		Security:       s.securityFor(rec.Command),
+		Secrets:        s.secretsFor(rec.Command),
+		StopSignal:     s.config().GetStopSignalFor(rec.Command),
+		Image:          s.config().GetImageFor(rec.Command),
+		Timeout:        rec.Timeout,
+		MaxRetries:     rec.MaxRetries,
+	}
+	s.withJobEnv(&req)
+	return req
+}
+
+// triggerSchedule runs a provider-scheduled job through Apollo, so the run is
+// recorded, resolved and retried exactly like a tick of a local schedule. A
+// tick that ran answers 204 even when its runs failed, since the job's retry
+// policy has already been applied and a provider retry would repeat it; only
+// a tick that could not be started answers 500.
+func (s *JobsServer) triggerSchedule(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if s.store == nil {
+		http.Error(w, "no store configured", http.StatusServiceUnavailable)
+		return
+	}
+	recs, err := s.store.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, rec := range recs {
+		if rec.Name != name {
+			continue
+		}
+		req := s.recordRequest(rec)
+		rn, _, err := s.runnerFor(rec.Runner, rec.Command)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.Printf("schedule %s triggered by the provider", name)
+		if err := s.runTick(r.Context(), rn, req); err != nil {
+			log.Printf("triggered run of %s failed: %v", name, err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Error(w, "schedule not found", http.StatusNotFound)
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"fmt"
+	"time"
+)
+
+// newJobID generates the ID of a run of the named job started at at, per the
+// configured template. Templates keyed on {unix} yield the same ID for runs
+// started in the same second, so an ID this server already handed out that
+// second gets a -2, -3, ... suffix.
+func (s *JobsServer) newJobID(name string, at time.Time) string {
+	id := s.config().JobID(name, at)
+	s.idsMu.Lock()
+	defer s.idsMu.Unlock()
+	if sec := at.Unix(); s.ids == nil || sec > s.idsSecond {
+		s.idsSecond, s.ids = sec, map[string]int{}
+	}
+	s.ids[id]++
+	if n := s.ids[id]; n > 1 {
+		return fmt.Sprintf("%s-%d", id, n)
+	}
+	return id
+}
@@ -2,102 +2,382 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	cfg "github.com/SyneHQ/apollo"
 	"github.com/SyneHQ/apollo/proto"
 	"github.com/SyneHQ/apollo/runner"
 	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type JobsServer struct {
 	proto.UnimplementedJobsServiceServer
-	runner runner.Runner
-	cfg    *cfg.Config
-	sched  *scheduler.Scheduler
-	store  *scheduler.Store
+	runner  runner.Runner
+	runners map[string]runner.Runner
+	// conf is swapped whole by ReloadConfig; read it through config
+	conf  atomic.Pointer[cfg.Config]
+	sched *scheduler.Scheduler
+	store scheduler.Store
+
+	mu       sync.Mutex
+	closing  bool
+	wg       sync.WaitGroup
+	inflight map[string]inflightRun
+	// quit is closed on Shutdown to stop background work
+	quit chan struct{}
+	// progressKey signs the tokens runs use to report progress
+	progressKey []byte
+	clock       scheduler.Clock
+	// maintenance is on while scheduled ticks are skipped and new runs refused;
+	// with a store it is the store's as of maintenanceRead, see inMaintenance
+	maintenance     atomic.Bool
+	maintenanceMu   sync.Mutex
+	maintenanceRead time.Time
+
+	// streams buffers the output of running jobs for StreamLogs, by job ID
+	streamsMu sync.Mutex
+	streams   map[string]*logStream
+
+	// scheduleMu serializes schedule creations against MaxSchedules
+	scheduleMu sync.Mutex
+
+	// ids counts the job IDs generated in idsSecond, see newJobID
+	idsMu     sync.Mutex
+	idsSecond int64
+	ids       map[string]int
 }
 
-func NewJobsServer(r runner.Runner, c *cfg.Config) *JobsServer {
+// ServerOption tunes a JobsServer beyond its runners, config and store.
+type ServerOption func(*JobsServer)
+
+// WithClock makes the server read the time from clock instead of the system
+// clock, e.g. to test time-dependent behaviour without sleeping.
+func WithClock(clock scheduler.Clock) ServerOption {
+	return func(s *JobsServer) { s.clock = clock }
+}
+
+// NewJobsServer wires the server to its primary runner, any named runner
+// profiles and the store. The store may be nil, in which case schedules and
+// executions are not persisted.
+func NewJobsServer(r runner.Runner, profiles map[string]runner.Runner, c *cfg.Config, st scheduler.Store, opts ...ServerOption) *JobsServer {
 	var sch *scheduler.Scheduler
-	var st *scheduler.Store
-	if c.JobsProvider == "local" && c.Store.Driver != "" && c.Store.Path != "" {
+	if c.JobsProvider == "local" && st != nil {
 		sch = scheduler.New()
-		// best-effort open local sqlite at ./jobs.db
-		s, err := scheduler.OpenStore(c.Store.Driver, c.Store.Path)
-		if err == nil {
-			st = s
-		}
 	}
-	return &JobsServer{runner: r, cfg: c, sched: sch, store: st}
+	if profiles == nil {
+		profiles = map[string]runner.Runner{}
+	}
+	s := &JobsServer{runner: r, runners: profiles, sched: sch, store: st, inflight: map[string]inflightRun{}, streams: map[string]*logStream{}, quit: make(chan struct{}), progressKey: progressKey(c.ProgressTokenSecret), clock: scheduler.SystemClock}
+	s.conf.Store(c)
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.initMaintenance()
+	s.listSubmittedFor(r)
+	for _, rn := range profiles {
+		s.listSubmittedFor(rn)
+	}
+	return s
 }
 
-func (s *JobsServer) RunJob(ctx context.Context, req *proto.RunJobRequest) (*proto.RunJobResponse, error) {
-	r := runner.JobRequest{
-		Name:           req.GetName(),
-		Command:        req.GetCommand(),
-		ArgsJSONBase64: req.GetArgsBase64(),
-		Resources:      runner.Resources{CPU: req.GetResources().Cpu, Memory: req.GetResources().Memory},
-		Type:           mapJobType(req.GetType()),
-		ScheduleSpec:   req.GetSchedule(),
+func (s *JobsServer) RunJob(ctx context.Context, req *proto.RunJobRequest) (resp *proto.RunJobResponse, err error) {
+	if s.inMaintenance(ctx) {
+		return nil, status.Error(codes.Unavailable, "maintenance mode is on")
+	}
+	if err := s.checkEnvOverrides(req); err != nil {
+		return nil, err
+	}
+	r, err := s.jobRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.Type == runner.JobTypeRepeatable && r.ScheduleSpec != "" {
+		if err := s.validateSpec(runner.ZonedSpec(r.ScheduleSpec, r.TimeZone)); err != nil {
+			return nil, err
+		}
 	}
-	// default resources if not provided
-	if r.Resources.CPU == "" && r.Resources.Memory == "" {
-		res := s.cfg.GetResourcesFor(r.Command)
-		r.Resources.CPU = res.CPU
-		r.Resources.Memory = res.Memory
+	rn, profile, err := s.runnerFor(req.GetRunner(), r.Command)
+	if err != nil {
+		return nil, err
+	}
+	if r.DryRun {
+		// nothing is run, scheduled or recorded
+		preview, err := s.renderCommand(ctx, rn, r)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.RunJobResponse{Id: r.JobID, Logs: truncateLogs(preview, s.config().GRPCMaxMessageBytes)}, nil
+	}
+	if err := s.checkImage(ctx, rn, r.Image); err != nil {
+		return nil, err
+	}
+	if req.GetRunAt() != 0 {
+		return s.deferRun(ctx, req, rn, r, profile)
 	}
 	if r.Type == runner.JobTypeRepeatable && s.sched != nil && r.ScheduleSpec != "" {
 		name := r.Name
-		err := s.sched.Schedule(name, r.ScheduleSpec, func(c context.Context) {
-			start := time.Now().Unix()
-			if r.JobID == "" {
-				r.JobID = fmt.Sprintf("job-%s-%d", req.Name, time.Now().Unix())
+		err := s.withinScheduleLimit(ctx, name, func() error {
+			run := s.scheduledRun(rn, r)
+			if req.GetSingleton() {
+				run = s.singleton(name, run)
+			}
+			err := s.sched.Schedule(name, r.ScheduleSpec, run, s.scheduleOptions(r, req.GetSkipIfRunning())...)
+			if err != nil {
+				return err
+			}
+			if s.store != nil {
+				coalesce := true
+				if req.CoalesceMissed != nil {
+					coalesce = req.GetCoalesceMissed()
+				}
+				_ = s.store.Upsert(ctx, scheduler.JobRecord{
+					Name:           r.Name,
+					Command:        r.Command,
+					ArgsBase64:     r.ArgsJSONBase64,
+					CronSpec:       r.ScheduleSpec,
+					Cpu:            r.Resources.CPU,
+					Memory:         r.Resources.Memory,
+					CoalesceMissed: coalesce,
+					MaxCatchup:     int(req.GetMaxCatchup()),
+					Runner:         profile,
+					Singleton:      req.GetSingleton(),
+					SkipIfRunning:  req.GetSkipIfRunning(),
+					TimeZone:       r.TimeZone,
+					Labels:         r.Labels,
+					Timeout:        r.Timeout,
+					MaxRetries:     r.MaxRetries,
+				})
 			}
-			log.Printf("Running job %s with cmd: %s and command: %s", r.JobID, s.cfg.Jobs.Cmd, r.Command)
-			result, runErr := s.runner.RunJob(c, s.cfg.Jobs.Cmd, r)
-			end := time.Now().Unix()
-			s.recordExecution(c, r, r.JobID, result, runErr, start, end)
+			return nil
 		})
 		if err != nil {
 			return nil, err
 		}
-		if s.store != nil {
-			_ = s.store.Upsert(ctx, scheduler.JobRecord{
-				Name:    r.Name,
-				Command: r.Command,
-				Cpu:     r.Resources.CPU,
-				Memory:  r.Resources.Memory,
-			})
-		}
 		return &proto.RunJobResponse{Id: name, Logs: "scheduled"}, nil
 	}
-	start := time.Now().Unix()
+	start := s.clock.Now().Unix()
 
 	if r.JobID == "" {
-		r.JobID = fmt.Sprintf("job-%s-%d", req.Name, time.Now().Unix())
+		r.JobID = s.newJobID(req.Name, time.Unix(start, 0))
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	inflight := inflightRun{req: r, start: start, rn: rn, cancel: cancel}
+	if req.GetDeduplicate() {
+		if err := s.beginUniqueRun(ctx, inflight); err != nil {
+			return nil, err
+		}
+	} else if !s.beginRun(inflight) {
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
 	}
+	defer s.endRun(r.JobID)
+	defer func() {
+		if p := recover(); p != nil {
+			resp, err = nil, s.recoverRun(ctx, r, start, p)
+		}
+	}()
 
-	log.Printf("Running job %s with cmd: %s and command: %s", r.JobID, s.cfg.Jobs.Cmd, r.Command)
+	log.Printf("Running job %s with cmd: %s and command: %s", r.JobID, s.config().Jobs.Cmd, r.Command)
 
-	s.recordExecution(ctx, r, r.JobID, "", nil, start, 0)
+	withLocation(rn, &r)
+	s.withProgress(&r)
+	s.recordStart(ctx, &r, &start)
 
-	result, err := s.runner.RunJob(ctx, s.cfg.Jobs.Cmd, r)
+	result, err := s.runJob(runCtx, rn, r)
+	if errors.Is(err, runner.ErrStoppedWaiting) && !s.isCancelled(r.JobID) {
+		// the caller went away while the job runs on; SettleSubmitted
+		// records how it ends
+		s.recordExecution(context.WithoutCancel(ctx), r, r.JobID, result, errSubmitted, start, 0)
+		s.trackCost(rn, r.JobID, result)
+		return nil, status.Errorf(codes.Canceled, "stopped waiting for job %s, which is still running", r.JobID)
+	}
+	if err == nil && submits(rn, r) {
+		s.recordExecution(ctx, r, r.JobID, result, errSubmitted, start, 0)
+		s.trackCost(rn, r.JobID, result)
+		return &proto.RunJobResponse{Id: r.JobID, Logs: truncateLogs(result, s.config().GRPCMaxMessageBytes)}, nil
+	}
+	err = s.cancelledErr(r.JobID, s.evaluateRun(r.Command, result, err))
 
-	end := time.Now().Unix()
+	end := s.clock.Now().Unix()
 
 	s.recordExecution(ctx, r, r.JobID, result, err, start, end)
 
 	if err != nil {
 		return nil, err
 	}
-	return &proto.RunJobResponse{Id: r.JobID, Logs: result}, nil
+	s.trackCost(rn, r.JobID, result)
+	return &proto.RunJobResponse{Id: r.JobID, Logs: truncateLogs(result, s.config().GRPCMaxMessageBytes)}, nil
+}
+
+// scheduledRun builds the cron callback for a repeatable job. Every tick gets
+// its own job ID and execution record, and marks the schedule as fired so
+// missed ticks can be caught up after a restart. Failed ticks are retried per
+// the job's retry policy, each attempt recorded as its own execution. A job
+// ID the schedule was created with is dropped, as every tick would reuse it.
+func (s *JobsServer) scheduledRun(rn runner.Runner, r runner.JobRequest) scheduler.JobFunc {
+	r.JobID = ""
+	return func(c context.Context) {
+		_ = safely("scheduled run of "+r.Name, func() {
+			if s.schedulePaused(c, r.Name) {
+				log.Printf("skipping %s: schedule is paused", r.Name)
+				return
+			}
+			_ = s.runTick(c, rn, r)
+		})
+	}
+}
+
+// runTick runs one tick of a schedule, retrying per the job's policy, and
+// returns the error of the last attempt.
+func (s *JobsServer) runTick(c context.Context, rn runner.Runner, r runner.JobRequest) error {
+	start := s.clock.Now().Unix()
+	jobID := r.JobID
+	if jobID == "" {
+		jobID = s.newJobID(r.Name, time.Unix(start, 0))
+	}
+	if s.store != nil {
+		if err := s.store.MarkFired(c, r.Name, start); err != nil {
+			log.Printf("failed to mark %s as fired: %v", r.Name, err)
+		}
+	}
+	if s.inMaintenance(c) {
+		s.skipForMaintenance(c, r, jobID, start)
+		return nil
+	}
+
+	policy := s.config().GetRetryFor(r.Command)
+	for attempt := 0; ; attempt++ {
+		run := r
+		run.JobID = jobID
+		if attempt > 0 {
+			run.JobID = fmt.Sprintf("%s-retry%d", jobID, attempt)
+		}
+		err := s.runScheduled(c, rn, run)
+		if err == nil || policy.MaxRetries == 0 || c.Err() != nil || errors.Is(err, errCancelled) {
+			return err
+		}
+		delay := policy.Delay(attempt + 1)
+		retry, decision := s.retryDecision(policy, r.Name, attempt, delay, err)
+		s.recordRetryDecision(c, run.JobID, decision)
+		if !retry {
+			log.Printf("not retrying %s: %s", run.JobID, decision)
+			return err
+		}
+		log.Printf("retrying %s in %s (retry %d/%d): %v", r.Name, delay, attempt+1, policy.MaxRetries, err)
+		select {
+		case <-c.Done():
+			return err
+		case <-s.clock.After(delay):
+		}
+	}
+}
+
+// nextTick returns when the named schedule fires next in this process, or the
+// zero time when it is not scheduled here (e.g. triggered by the provider).
+func (s *JobsServer) nextTick(name string) time.Time {
+	if s.sched == nil {
+		return time.Time{}
+	}
+	return s.sched.Next(name)
+}
+
+// runScheduled executes a single attempt of a scheduled job and records it.
+func (s *JobsServer) runScheduled(c context.Context, rn runner.Runner, run runner.JobRequest) (runErr error) {
+	start := s.clock.Now().Unix()
+	runCtx, cancel := context.WithCancel(c)
+	defer cancel()
+	if !s.beginRun(inflightRun{req: run, start: start, rn: rn, cancel: cancel}) {
+		log.Printf("skipping %s: server is shutting down", run.JobID)
+		return nil
+	}
+	defer s.endRun(run.JobID)
+	defer func() {
+		if p := recover(); p != nil {
+			runErr = s.recoverRun(c, run, start, p)
+		}
+	}()
+	log.Printf("Running job %s with cmd: %s and command: %s", run.JobID, s.config().Jobs.Cmd, run.Command)
+	// the run's deadline must not stop it from being recorded, least of all
+	// as timed out
+	record := context.WithoutCancel(c)
+	withLocation(rn, &run)
+	s.withProgress(&run)
+	s.recordStart(record, &run, &start)
+	result, runErr := s.runJob(runCtx, rn, run)
+	if errors.Is(runErr, runner.ErrStoppedWaiting) && c.Err() == nil && !s.isCancelled(run.JobID) {
+		// Shutdown stopped waiting for the job, which runs on;
+		// SettleSubmitted records how it ends
+		s.recordExecution(record, run, run.JobID, result, errSubmitted, start, 0)
+		s.trackCost(rn, run.JobID, result)
+		return nil
+	}
+	if runErr == nil && submits(rn, run) {
+		s.recordExecution(record, run, run.JobID, result, errSubmitted, start, 0)
+		s.trackCost(rn, run.JobID, result)
+		return nil
+	}
+	runErr = s.cancelledErr(run.JobID, s.evaluateRun(run.Command, result, runErr))
+	if errors.Is(c.Err(), context.DeadlineExceeded) {
+		runErr = fmt.Errorf("job %s timed out: %w (%v)", run.JobID, context.DeadlineExceeded, runErr)
+	}
+	end := s.clock.Now().Unix()
+	s.recordExecution(record, run, run.JobID, result, runErr, start, end)
+	if runErr == nil {
+		s.trackCost(rn, run.JobID, result)
+	}
+	return runErr
+}
+
+// evaluateRun applies the per-command success criteria from jobs.yml to a
+// finished run and returns the error that decides its final status. Without
+// criteria the runner's own error is kept (non-zero exit == error).
+func (s *JobsServer) evaluateRun(command, result string, runErr error) error {
+	job, ok := s.config().GetJobConfig(command)
+	if !ok || (len(job.SuccessExitCodes) == 0 && job.SuccessOutputRegex == "") {
+		return runErr
+	}
+	code := 0
+	if runErr != nil {
+		c, ok := runner.ExitCode(runErr)
+		if !ok {
+			return runErr
+		}
+		code = c
+	}
+	successCodes := job.SuccessExitCodes
+	if len(successCodes) == 0 {
+		successCodes = []int{0}
+	}
+	if !slices.Contains(successCodes, code) {
+		if runErr != nil {
+			return runErr
+		}
+		return fmt.Errorf("exit code %d is not a success exit code for %s", code, command)
+	}
+	if job.SuccessOutputRegex != "" {
+		re, err := regexp.Compile(job.SuccessOutputRegex)
+		if err != nil {
+			return fmt.Errorf("invalid success_output_regex for %s: %w", command, err)
+		}
+		if !re.MatchString(result) {
+			return fmt.Errorf("output of %s did not match success_output_regex %q", command, job.SuccessOutputRegex)
+		}
+	}
+	return nil
 }
 
 func (s *JobsServer) recordExecution(ctx context.Context, r runner.JobRequest, id string, result string, runErr error, start, optionalEnd int64) {
-	end := time.Now().Unix()
+	end := s.clock.Now().Unix()
 	isRunning := optionalEnd == 0
 	if optionalEnd != 0 {
 		end = optionalEnd
@@ -107,10 +387,23 @@ func (s *JobsServer) recordExecution(ctx context.Context, r runner.JobRequest, i
 		return
 	}
 
-	// Determine status: "running" if job hasn't finished, otherwise "error" or "success"
+	// Determine status: "running" if job hasn't finished, otherwise "timeout", "error" or "success"
 	var status string
 	if isRunning {
 		status = "running"
+		if errors.Is(runErr, errStarting) {
+			status, runErr = "starting", nil
+		} else if errors.Is(runErr, errSubmitted) {
+			status, runErr = "submitted", nil
+		}
+	} else if errors.Is(runErr, context.DeadlineExceeded) {
+		status = "timeout"
+	} else if errors.Is(runErr, errInterrupted) {
+		status = "interrupted"
+	} else if errors.Is(runErr, errCancelled) {
+		status = "cancelled"
+	} else if errors.Is(runErr, errSkipped) || errors.Is(runErr, errMaintenance) {
+		status = "skipped"
 	} else {
 		status = map[bool]string{true: "error", false: "success"}[runErr != nil]
 	}
@@ -132,6 +425,12 @@ func (s *JobsServer) recordExecution(ctx context.Context, r runner.JobRequest, i
 		Result:     result,
 		StartedAt:  start,
 		FinishedAt: end,
+		Labels:     s.executionLabels(r.Labels),
+		BatchID:    r.BatchID,
+	}
+	if !isRunning {
+		s.hashResult(ctx, &rec)
+		s.extractMetrics(&rec)
 	}
 	err := s.store.AddExecution(ctx, rec)
 	if err != nil {
@@ -139,22 +438,93 @@ func (s *JobsServer) recordExecution(ctx context.Context, r runner.JobRequest, i
 	}
 }
 
+// DeleteJob removes a job's schedule and stops its runs when given its name,
+// or stops the single run with the given job ID, leaving the schedule and
+// other runs of the job alone. Either goes to the runner that owns the job.
 func (s *JobsServer) DeleteJob(ctx context.Context, req *proto.DeleteJobRequest) (*proto.DeleteJobResponse, error) {
+	if (req.GetName() == "") == (req.GetJobId() == "") {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of name or job_id is required")
+	}
+	if id := req.GetJobId(); id != "" {
+		if err := s.runOwner(ctx, id).DeleteJob(ctx, id); err != nil {
+			return nil, err
+		}
+		return &proto.DeleteJobResponse{}, nil
+	}
+	runners, err := s.jobOwners(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
 	if s.sched != nil {
 		s.sched.Delete(req.GetName())
 	}
 	if s.store != nil {
 		_ = s.store.Delete(ctx, req.GetName())
 	}
-	if err := s.runner.DeleteJob(ctx, req.GetName()); err != nil {
-		return nil, err
+	for _, rn := range runners {
+		if err := rn.DeleteJob(ctx, req.GetName()); err != nil {
+			return nil, err
+		}
 	}
 	return &proto.DeleteJobResponse{}, nil
 }
 
+// runOwner returns the runner of the run id: the one running it in this
+// process, else the one that knows it, else the primary.
+func (s *JobsServer) runOwner(ctx context.Context, id string) runner.Runner {
+	s.mu.Lock()
+	run, ok := s.inflight[id]
+	s.mu.Unlock()
+	if ok {
+		return run.rn
+	}
+	if rn, _, err := s.ownerOf(ctx, id, func(runner.Runner) bool { return true }); err == nil {
+		return rn
+	}
+	return s.runner
+}
+
+// jobOwners returns the runners the named job may have runs on: its stored
+// schedule's and those of its runs in flight here, else the primary.
+func (s *JobsServer) jobOwners(ctx context.Context, name string) ([]runner.Runner, error) {
+	var owners []runner.Runner
+	add := func(rn runner.Runner) {
+		if !slices.Contains(owners, rn) {
+			owners = append(owners, rn)
+		}
+	}
+	rec, ok, err := s.storedSchedule(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		rn, _, err := s.runnerFor(rec.Runner, rec.Command)
+		if err != nil {
+			return nil, err
+		}
+		add(rn)
+	}
+	s.mu.Lock()
+	for _, run := range s.inflight {
+		if run.req.Name == name {
+			add(run.rn)
+		}
+	}
+	s.mu.Unlock()
+	if len(owners) == 0 {
+		add(s.runner)
+	}
+	return owners, nil
+}
+
 func (s *JobsServer) UpdateSchedule(ctx context.Context, req *proto.UpdateScheduleRequest) (*proto.UpdateScheduleResponse, error) {
 	name := req.GetName()
 	spec := req.GetSchedule()
+	if spec != "" {
+		if err := s.validateSpec(spec); err != nil {
+			return nil, err
+		}
+	}
 	if s.sched != nil {
 		if spec == "" {
 			s.sched.Delete(name)
@@ -164,7 +534,29 @@ func (s *JobsServer) UpdateSchedule(ctx context.Context, req *proto.UpdateSchedu
 		return &proto.UpdateScheduleResponse{}, fmt.Errorf("reschedule requires rerun with RunJob in local provider")
 	}
 	// Cloud provider path
-	if err := s.runner.UpdateSchedule(ctx, name, spec); err != nil {
+	err := s.withinScheduleLimit(ctx, name, func() error {
+		rec, _, err := s.storedSchedule(ctx, name)
+		if err != nil {
+			return err
+		}
+		// a CRON_TZ prefix moves the schedule to that time zone; without one it
+		// keeps its zone
+		tz, bare := runner.SplitTimeZone(spec)
+		rec.Name, rec.CronSpec = name, bare
+		if tz != "" {
+			rec.TimeZone = tz
+		}
+		rn, _, err := s.runnerFor(rec.Runner, rec.Command)
+		if err != nil {
+			return err
+		}
+		if err := s.updateProviderSchedule(ctx, rn, rec); err != nil {
+			return err
+		}
+		s.recordSchedule(ctx, name, bare, tz)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &proto.UpdateScheduleResponse{}, nil
@@ -174,18 +566,28 @@ func (s *JobsServer) ListSchedules(ctx context.Context, req *proto.ListSchedules
 	if s.store == nil {
 		return &proto.ListSchedulesResponse{Items: []*proto.ScheduleItem{}}, nil
 	}
-	recs, err := s.store.List(ctx)
+	if req.GetLimit() < 0 || req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit and offset must not be negative")
+	}
+	recs, err := s.store.ListPage(scheduler.WithReplica(ctx), int(req.GetLimit()), int(req.GetOffset()))
 	if err != nil {
 		return nil, err
 	}
 	out := make([]*proto.ScheduleItem, 0, len(recs))
 	for _, r := range recs {
 		out = append(out, &proto.ScheduleItem{
-			Name:       r.Name,
-			Command:    r.Command,
-			ArgsBase64: r.ArgsBase64,
-			Cron:       r.CronSpec,
-			Resources:  &proto.Resources{Cpu: r.Cpu, Memory: r.Memory},
+			Name:          r.Name,
+			Command:       r.Command,
+			ArgsBase64:    r.ArgsBase64,
+			Cron:          r.CronSpec,
+			Resources:     &proto.Resources{Cpu: r.Cpu, Memory: r.Memory},
+			Runner:        r.Runner,
+			Singleton:     r.Singleton,
+			RunAt:         r.RunAt,
+			Labels:        r.Labels,
+			SkipIfRunning: r.SkipIfRunning,
+			TimeZone:      r.TimeZone,
+			Paused:        r.Paused,
 		})
 	}
 	return &proto.ListSchedulesResponse{Items: out}, nil
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// logStreamBuffer caps the output of a run kept for followers; a follower
+// that joins or falls further behind misses the oldest output.
+const logStreamBuffer = 1 << 20
+
+// logChunkSize caps how much output each streamed chunk carries.
+const logChunkSize = 32 << 10
+
+// logStream buffers the output of one run and tells followers when more
+// arrives. Followers read at their own pace, so a slow one never holds up the
+// job.
+type logStream struct {
+	mu      sync.Mutex
+	buf     []byte
+	base    int64         // offset of buf[0] in the run's whole output
+	changed chan struct{} // closed and replaced on every write and on close
+	done    bool
+}
+
+func newLogStream() *logStream {
+	return &logStream{changed: make(chan struct{})}
+}
+
+func (l *logStream) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf = append(l.buf, p...)
+	if over := len(l.buf) - logStreamBuffer; over > 0 {
+		l.buf = append([]byte(nil), l.buf[over:]...)
+		l.base += int64(over)
+	}
+	close(l.changed)
+	l.changed = make(chan struct{})
+	return len(p), nil
+}
+
+// close marks the run finished, ending every follow once it is read out.
+func (l *logStream) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.done = true
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// read returns up to logChunkSize bytes of output from offset on, the offset
+// after them, a channel closed once there is more and whether the run has
+// finished.
+func (l *logStream) read(offset int64) ([]byte, int64, <-chan struct{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	offset = max(offset, l.base)
+	data := l.buf[offset-l.base:]
+	if len(data) > logChunkSize {
+		data = data[:logChunkSize]
+	}
+	return append([]byte(nil), data...), offset + int64(len(data)), l.changed, l.done
+}
+
+// runJob runs r on rn. When rn can stream output, the run can be followed
+// through StreamLogs while it runs.
+func (s *JobsServer) runJob(ctx context.Context, rn runner.Runner, r runner.JobRequest) (string, error) {
+	streamer, ok := rn.(runner.OutputStreamer)
+	if !ok {
+		return rn.RunJob(ctx, s.config().Jobs.Cmd, r)
+	}
+	l := newLogStream()
+	s.streamsMu.Lock()
+	s.streams[r.JobID] = l
+	s.streamsMu.Unlock()
+	defer func() {
+		s.streamsMu.Lock()
+		if s.streams[r.JobID] == l {
+			delete(s.streams, r.JobID)
+		}
+		s.streamsMu.Unlock()
+		l.close()
+	}()
+	return streamer.RunJobStream(ctx, s.config().Jobs.Cmd, r, l)
+}
+
+// StreamLogs follows the output of a run, from the start of what is still
+// buffered, until the run finishes. A run that already finished streams its
+// recorded output.
+func (s *JobsServer) StreamLogs(req *proto.StreamLogsRequest, stream proto.JobsService_StreamLogsServer) error {
+	if req.GetJobId() == "" {
+		return status.Error(codes.InvalidArgument, "job_id is required")
+	}
+	s.streamsMu.Lock()
+	l, ok := s.streams[req.GetJobId()]
+	s.streamsMu.Unlock()
+	if !ok {
+		return s.streamRecordedLogs(req.GetJobId(), stream)
+	}
+	var offset int64
+	for {
+		data, next, changed, done := l.read(offset)
+		if len(data) > 0 {
+			if err := stream.Send(&proto.LogChunk{Data: data}); err != nil {
+				return err
+			}
+			offset = next
+			continue
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-changed:
+		}
+	}
+}
+
+// streamRecordedLogs streams the output recorded for a finished run.
+func (s *JobsServer) streamRecordedLogs(id string, stream proto.JobsService_StreamLogsServer) error {
+	if s.store == nil {
+		return status.Errorf(codes.NotFound, "no running job %s", id)
+	}
+	e, err := s.store.GetExecution(stream.Context(), id)
+	if errors.Is(err, scheduler.ErrExecutionNotFound) {
+		return status.Errorf(codes.NotFound, "no job %s", id)
+	}
+	if err != nil {
+		return err
+	}
+	result := []byte(e.Result)
+	for len(result) > 0 {
+		n := min(len(result), logChunkSize)
+		if err := stream.Send(&proto.LogChunk{Data: result[:n]}); err != nil {
+			return err
+		}
+		result = result[n:]
+	}
+	return nil
+}
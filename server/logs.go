@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetLogs returns a job's output, optionally for a single task of a parallel
+// job so shards can be debugged individually.
+func (s *JobsServer) GetLogs(ctx context.Context, req *proto.GetLogsRequest) (*proto.GetLogsResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	rn, _, err := s.runnerFor(req.GetRunner(), "")
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := rn.(runner.LogReader)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "runner does not support reading logs")
+	}
+	taskIndex := runner.AllTasks
+	if req.TaskIndex != nil {
+		if req.GetTaskIndex() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "task_index must not be negative")
+		}
+		taskIndex = req.GetTaskIndex()
+	}
+	if req.GetMaxLines() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_lines must not be negative")
+	}
+	logs, err := reader.ReadLogs(ctx, req.GetName(), taskIndex, int(req.GetMaxLines()))
+	if err != nil {
+		return nil, err
+	}
+	return &proto.GetLogsResponse{Logs: logs}, nil
+}
+
+// logsEnvelopeBytes is reserved for the rest of a RunJob response.
+const logsEnvelopeBytes = 4 << 10
+
+// truncateLogs keeps the tail of logs so a RunJob response fits in
+// maxMessage bytes, which is where failures usually show. The full output is
+// still recorded on the execution. maxMessage <= 0 disables truncation.
+func truncateLogs(logs string, maxMessage int) string {
+	limit := maxMessage - logsEnvelopeBytes
+	if maxMessage <= 0 || len(logs) <= limit {
+		return logs
+	}
+	limit = max(limit, 0)
+	cut := len(logs) - limit
+	// don't split a UTF-8 sequence
+	for cut < len(logs) && !utf8.RuneStart(logs[cut]) {
+		cut++
+	}
+	return fmt.Sprintf("[truncated %d bytes; the full output is on the execution record]\n", cut) + logs[cut:]
+}
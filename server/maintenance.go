@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errMaintenance = errors.New("skipped: maintenance mode")
+
+// maintenanceTTL is how long a replica trusts the maintenance mode it last
+// read from the store, so one switched on elsewhere reaches it quickly.
+const maintenanceTTL = 5 * time.Second
+
+// initMaintenance turns maintenance mode on when the config asks for it, else
+// restores the mode the store last recorded.
+func (s *JobsServer) initMaintenance() {
+	if s.config().MaintenanceMode {
+		s.maintenance.Store(true)
+		if s.store != nil {
+			if err := s.store.SetMaintenance(context.Background(), true); err != nil {
+				log.Printf("failed to record maintenance mode: %v", err)
+			}
+		}
+		return
+	}
+	if s.store == nil {
+		return
+	}
+	s.inMaintenance(context.Background())
+}
+
+// inMaintenance reports whether maintenance mode is on. With a store it is
+// read from there at most every maintenanceTTL, so every replica follows
+// SetMaintenanceMode on any of them; a failed read keeps the last state.
+func (s *JobsServer) inMaintenance(ctx context.Context) bool {
+	if s.store == nil {
+		return s.maintenance.Load()
+	}
+	s.maintenanceMu.Lock()
+	defer s.maintenanceMu.Unlock()
+	now := s.clock.Now()
+	if !s.maintenanceRead.IsZero() && now.Sub(s.maintenanceRead) < maintenanceTTL {
+		return s.maintenance.Load()
+	}
+	on, err := s.store.Maintenance(ctx)
+	if err != nil {
+		log.Printf("failed to read maintenance mode: %v", err)
+		return s.maintenance.Load()
+	}
+	s.maintenance.Store(on)
+	s.maintenanceRead = now
+	return on
+}
+
+// SetMaintenanceMode turns maintenance mode on or off. While it is on,
+// scheduled ticks are recorded as skipped and RunJob refuses new runs with
+// codes.Unavailable; runs already going are left to finish.
+func (s *JobsServer) SetMaintenanceMode(ctx context.Context, req *proto.SetMaintenanceModeRequest) (*proto.SetMaintenanceModeResponse, error) {
+	if s.store != nil {
+		if err := s.store.SetMaintenance(ctx, req.GetOn()); err != nil {
+			return nil, status.Errorf(codes.Internal, "record maintenance mode: %v", err)
+		}
+	}
+	s.maintenanceMu.Lock()
+	s.maintenance.Store(req.GetOn())
+	s.maintenanceRead = s.clock.Now()
+	s.maintenanceMu.Unlock()
+	log.Printf("maintenance mode %s", map[bool]string{true: "on", false: "off"}[req.GetOn()])
+	return &proto.SetMaintenanceModeResponse{On: req.GetOn()}, nil
+}
+
+// skipForMaintenance records a tick of r that fired in maintenance mode as a
+// skipped execution.
+func (s *JobsServer) skipForMaintenance(c context.Context, r runner.JobRequest, jobID string, at int64) {
+	log.Printf("skipping %s: maintenance mode", r.Name)
+	s.recordExecution(c, r, jobID, "", errMaintenance, at, at)
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SyneHQ/apollo/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RunNamedJob runs a job from the jobs.yml catalog, so callers only need its
+// logical name. Params are merged over the entry's default args.
+func (s *JobsServer) RunNamedJob(ctx context.Context, req *proto.RunNamedJobRequest) (*proto.RunJobResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	job, ok := s.config().GetNamedJob(req.GetName())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no job named %s in the catalog", req.GetName())
+	}
+
+	args := make(map[string]string, len(job.Args)+len(req.GetParams()))
+	for k, v := range job.Args {
+		args[k] = v
+	}
+	for k, v := range req.GetParams() {
+		args[k] = v
+	}
+	var argsBase64 string
+	if len(args) > 0 {
+		raw, err := json.Marshal(args)
+		if err != nil {
+			return nil, fmt.Errorf("encode args for %s: %w", job.Name, err)
+		}
+		argsBase64 = base64.StdEncoding.EncodeToString(raw)
+	}
+
+	run := &proto.RunJobRequest{
+		Name:       job.Name,
+		JobId:      req.GetJobId(),
+		Command:    job.Command,
+		ArgsBase64: argsBase64,
+		Resources:  &proto.Resources{Cpu: job.Resources.CPU, Memory: job.Resources.Memory},
+		Type:       proto.JobType_JOB_TYPE_ONE_TIME,
+	}
+	if job.Schedule != "" {
+		run.Type = proto.JobType_JOB_TYPE_REPEATABLE
+		run.Schedule = job.Schedule
+	}
+	return s.RunJob(ctx, run)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"log"
+
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ReconcileOrphans settles executions a crashed server left "running" by
+// asking the provider what became of them: finished jobs get their terminal
+// status, jobs the provider no longer knows are marked interrupted and jobs
+// still active stay running. It must run before any new work is started.
+// Every runner is asked, the profiles' as well as the primary.
+func (s *JobsServer) ReconcileOrphans(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+	recs, err := s.store.ListExecutions(ctx, scheduler.ExecutionFilter{Status: "running"})
+	if err != nil {
+		log.Printf("orphan reconcile failed: %v", err)
+		return
+	}
+	settled := 0
+	for _, rec := range recs {
+		_, st, err := s.ownerOf(ctx, rec.ID, func(runner.Runner) bool { return true })
+		if status.Code(err) == codes.NotFound {
+			rec.Status = "interrupted"
+			rec.Error = "job not found at the provider after a server restart"
+			rec.FinishedAt = s.clock.Now().Unix()
+		} else if err != nil {
+			log.Printf("orphan reconcile: status of %s: %v", rec.ID, err)
+			continue
+		} else if !s.settle(&rec, st) {
+			continue
+		}
+		if err := s.store.AddExecution(ctx, rec); err != nil {
+			log.Printf("orphan reconcile: update %s: %v", rec.ID, err)
+			continue
+		}
+		settled++
+	}
+	if len(recs) > 0 {
+		log.Printf("orphan reconcile: settled %d of %d running execution(s)", settled, len(recs))
+	}
+}
+
+// settle gives rec the terminal status the provider reports in st. It
+// reports false, leaving rec alone, while the job is still active.
+func (s *JobsServer) settle(rec *scheduler.ExecutionRecord, st runner.JobStatus) bool {
+	if !st.State.Terminal() {
+		return false
+	}
+	rec.Status = "success"
+	if st.State == runner.JobStateFailed {
+		rec.Status = "error"
+		rec.Error = "job failed at the provider"
+	}
+	rec.FinishedAt = s.clock.Now().Unix()
+	if !st.FinishedAt.IsZero() {
+		rec.FinishedAt = st.FinishedAt.Unix()
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+var errSkipped = errors.New("skipped: the previous run is still running")
+
+// scheduleOptions are the scheduler options of a schedule running r. With
+// skipIfRunning, ticks that fire while the previous run is still going are
+// skipped and recorded as skipped executions. Ticks fire in r's time zone.
+func (s *JobsServer) scheduleOptions(r runner.JobRequest, skipIfRunning bool) []scheduler.Option {
+	opts := []scheduler.Option{scheduler.WithTimeout(s.config().GetTimeoutFor(r.Command)), scheduler.WithTimeZone(r.TimeZone)}
+	if skipIfRunning {
+		opts = append(opts, scheduler.SkipIfRunning(func() {
+			now := s.clock.Now()
+			s.recordExecution(context.Background(), r, s.newJobID(r.Name, now), "", errSkipped, now.Unix(), now.Unix())
+		}))
+	}
+	return opts
+}
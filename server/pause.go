@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PauseSchedule stops a schedule from firing while keeping its definition,
+// so ResumeSchedule can restart it unchanged. Registering the schedule again
+// with RunJob resumes it.
+func (s *JobsServer) PauseSchedule(ctx context.Context, req *proto.PauseScheduleRequest) (*proto.PauseScheduleResponse, error) {
+	if err := s.setPaused(ctx, req.GetName(), true); err != nil {
+		return nil, err
+	}
+	return &proto.PauseScheduleResponse{}, nil
+}
+
+// ResumeSchedule restarts a paused schedule. Ticks that fell while it was
+// paused are not run.
+func (s *JobsServer) ResumeSchedule(ctx context.Context, req *proto.ResumeScheduleRequest) (*proto.ResumeScheduleResponse, error) {
+	if err := s.setPaused(ctx, req.GetName(), false); err != nil {
+		return nil, err
+	}
+	return &proto.ResumeScheduleResponse{}, nil
+}
+
+// setPaused pauses or resumes the named schedule. In-process schedules are
+// paused in the store alone, which every replica reads at each tick;
+// provider-managed ones are paused with the provider too. The state is
+// recorded so it survives a restart.
+func (s *JobsServer) setPaused(ctx context.Context, name string, paused bool) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	rec, found, err := s.storedSchedule(ctx, name)
+	if err != nil {
+		return err
+	}
+	if s.sched != nil {
+		if !found {
+			return status.Errorf(codes.NotFound, "schedule %s not found", name)
+		}
+	} else {
+		rn, _, err := s.runnerFor(rec.Runner, rec.Command)
+		if err != nil {
+			return err
+		}
+		pauser, ok := rn.(runner.SchedulePauser)
+		if !ok {
+			return status.Error(codes.Unimplemented, "runner does not support pausing schedules")
+		}
+		if paused {
+			err = pauser.PauseSchedule(ctx, name)
+		} else {
+			err = pauser.ResumeSchedule(ctx, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if s.store == nil || !found {
+		return nil
+	}
+	if err := s.store.SetPaused(ctx, name, paused); err != nil {
+		return fmt.Errorf("record paused state of %s: %w", name, err)
+	}
+	if !paused {
+		// ticks that fell while paused are not caught up after a restart
+		if err := s.store.MarkFired(ctx, name, s.clock.Now().Unix()); err != nil {
+			log.Printf("failed to mark %s as fired: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// schedulePaused reports whether the named in-process schedule is paused, as
+// recorded in the store. A store that can't be read leaves it running.
+func (s *JobsServer) schedulePaused(ctx context.Context, name string) bool {
+	if s.store == nil {
+		return false
+	}
+	paused, err := s.store.Paused(ctx, name)
+	if err != nil {
+		log.Printf("failed to read paused state of %s: %v", name, err)
+		return false
+	}
+	return paused
+}
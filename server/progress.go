@@ -0,0 +1,89 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// progressKey returns the key signing progress tokens: the configured secret,
+// or a random one that lives as long as the process.
+func progressKey(secret string) []byte {
+	if secret != "" {
+		return []byte(secret)
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Printf("failed to generate progress token key: %v", err)
+	}
+	return key
+}
+
+// progressToken is the bearer token a run presents to report progress for
+// its own execution, and only that one.
+func (s *JobsServer) progressToken(id string) string {
+	mac := hmac.New(sha256.New, s.progressKey)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// withProgress gives a run the URL and token to report its progress when
+// progress reporting is configured.
+func (s *JobsServer) withProgress(r *runner.JobRequest) {
+	if s.config().ProgressURL == "" || s.store == nil {
+		return
+	}
+	overrides := runner.JobOverrides{}
+	if r.Overrides != nil {
+		overrides = *r.Overrides
+	}
+	overrides.Env = append(slices.Clone(overrides.Env),
+		runner.EnvVar{Name: "APOLLO_PROGRESS_URL", Value: strings.TrimSuffix(s.config().ProgressURL, "/") + "/executions/" + r.JobID + "/progress"},
+		runner.EnvVar{Name: "APOLLO_PROGRESS_TOKEN", Value: s.progressToken(r.JobID)},
+	)
+	r.Overrides = &overrides
+}
+
+// progressReport is the body a job POSTs to report progress.
+type progressReport struct {
+	Percent float64 `json:"percent"`
+	Message string  `json:"message"`
+}
+
+// reportProgress stores the progress a running job reports for its execution.
+func (s *JobsServer) reportProgress(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || !hmac.Equal([]byte(token), []byte(s.progressToken(id))) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var report progressReport
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&report); err != nil {
+		http.Error(w, "invalid progress report: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if report.Percent < 0 || report.Percent > 100 {
+		http.Error(w, "percent must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+	err := s.store.SetExecutionProgress(r.Context(), id, report.Percent, report.Message, s.clock.Now().Unix())
+	switch {
+	case errors.Is(err, scheduler.ErrExecutionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
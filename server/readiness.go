@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/SyneHQ/apollo/proto"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// pinger is implemented by stores that can check their connection.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// StartHealth drives hs's serving status from the server's readiness, for
+// both the overall status and JobsService: SERVING while the store answers
+// pings, NOT_SERVING when a ping fails and for good once Shutdown starts.
+// The store is pinged at start and then every interval.
+func (s *JobsServer) StartHealth(hs *health.Server, interval time.Duration) {
+	s.checkHealth(hs, interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.quit:
+				hs.Shutdown()
+				return
+			case <-ticker.C:
+				_ = safely("health check", func() { s.checkHealth(hs, interval) })
+			}
+		}
+	}()
+}
+
+func (s *JobsServer) checkHealth(hs *health.Server, timeout time.Duration) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if p, ok := s.store.(pinger); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := p.Ping(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("health: store ping failed: %v", err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	hs.SetServingStatus("", status)
+	hs.SetServingStatus(proto.JobsService_ServiceDesc.ServiceName, status)
+}
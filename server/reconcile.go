@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordSchedule keeps the store's copy of a provider-managed schedule in
+// step with what was sent to the provider, keeping the rest of the record.
+// An empty tz keeps the stored time zone.
+func (s *JobsServer) recordSchedule(ctx context.Context, name, spec, tz string) {
+	if s.store == nil || spec == "" {
+		return
+	}
+	rec := scheduler.JobRecord{Name: name, CoalesceMissed: true}
+	recs, err := s.store.List(ctx)
+	if err != nil {
+		log.Printf("failed to record schedule for %s: %v", name, err)
+		return
+	}
+	for _, r := range recs {
+		if r.Name == name {
+			rec = r
+			break
+		}
+	}
+	rec.CronSpec = spec
+	if tz != "" {
+		rec.TimeZone = tz
+	}
+	if err := s.store.Upsert(ctx, rec); err != nil {
+		log.Printf("failed to record schedule for %s: %v", name, err)
+	}
+}
+
+// updateProviderSchedule writes rec's schedule to its runner's provider. A
+// runner that schedules job specs itself gets the spec RunJob would submit
+// for the stored command, so scheduled and on-demand runs are identical.
+func (s *JobsServer) updateProviderSchedule(ctx context.Context, rn runner.Runner, rec scheduler.JobRecord) error {
+	js, ok := rn.(runner.JobScheduler)
+	if !ok || rec.Command == "" {
+		return rn.UpdateSchedule(ctx, rec.Name, runner.ZonedSpec(rec.CronSpec, rec.TimeZone))
+	}
+	req := s.recordRequest(rec)
+	withLocation(rn, &req)
+	return js.ScheduleJob(ctx, s.config().Jobs.Cmd, req)
+}
+
+// storedSchedule returns the stored record of the named schedule.
+func (s *JobsServer) storedSchedule(ctx context.Context, name string) (scheduler.JobRecord, bool, error) {
+	if s.store == nil {
+		return scheduler.JobRecord{}, false, nil
+	}
+	recs, err := s.store.List(ctx)
+	if err != nil {
+		return scheduler.JobRecord{}, false, err
+	}
+	for _, rec := range recs {
+		if rec.Name == name {
+			return rec, true, nil
+		}
+	}
+	return scheduler.JobRecord{}, false, nil
+}
+
+// ReconcileSchedules compares every stored schedule with the provider's copy
+// and reports the fields that drifted, e.g. after someone edited Cloud
+// Scheduler directly. With fix set, drifted schedules are rewritten from the
+// store.
+func (s *JobsServer) ReconcileSchedules(ctx context.Context, req *proto.ReconcileSchedulesRequest) (*proto.ReconcileSchedulesResponse, error) {
+	if s.store == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no store configured")
+	}
+	drifts, err := s.reconcileSchedules(ctx, req.GetFix())
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ReconcileSchedulesResponse{Drifts: drifts}, nil
+}
+
+func (s *JobsServer) reconcileSchedules(ctx context.Context, fix bool) ([]*proto.ScheduleDrift, error) {
+	// with the in-memory scheduler the store is the only copy of a schedule
+	if s.sched != nil {
+		return nil, nil
+	}
+	recs, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var drifts []*proto.ScheduleDrift
+	for _, rec := range recs {
+		if rec.CronSpec == "" {
+			continue
+		}
+		rn, _, err := s.runnerFor(rec.Runner, rec.Command)
+		if err != nil {
+			drifts = append(drifts, &proto.ScheduleDrift{Name: rec.Name, Error: err.Error()})
+			continue
+		}
+		inspector, ok := rn.(runner.ScheduleInspector)
+		if !ok {
+			continue
+		}
+
+		want := inspector.DesiredSchedule(rec.Name, runner.ZonedSpec(rec.CronSpec, rec.TimeZone))
+		var found []*proto.ScheduleDrift
+		got, err := inspector.GetSchedule(ctx, rec.Name)
+		switch {
+		case status.Code(err) == codes.NotFound:
+			found = append(found, &proto.ScheduleDrift{Name: rec.Name, Field: "missing", Expected: want.Spec})
+		case err != nil:
+			drifts = append(drifts, &proto.ScheduleDrift{Name: rec.Name, Error: err.Error()})
+			continue
+		default:
+			for _, f := range []struct{ field, want, got string }{
+				{"spec", want.Spec, got.Spec},
+				{"time_zone", want.TimeZone, got.TimeZone},
+				{"target", want.Target, got.Target},
+			} {
+				if f.want != f.got {
+					found = append(found, &proto.ScheduleDrift{Name: rec.Name, Field: f.field, Expected: f.want, Actual: f.got})
+				}
+			}
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		if fix {
+			fixErr := s.updateProviderSchedule(ctx, rn, rec)
+			for _, d := range found {
+				d.Fixed = fixErr == nil
+				if fixErr != nil {
+					d.Error = fixErr.Error()
+				}
+			}
+		}
+		drifts = append(drifts, found...)
+	}
+	return drifts, nil
+}
+
+// StartReconciler periodically reconciles provider schedules in the
+// background until Shutdown. A zero interval disables it.
+func (s *JobsServer) StartReconciler(interval time.Duration, fix bool) {
+	if interval <= 0 || s.store == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.quit:
+				return
+			case <-ticker.C:
+			}
+			_ = safely("schedule reconciler", func() { s.logReconcile(fix) })
+		}
+	}()
+}
+
+// logReconcile reconciles provider schedules once and logs the drift found.
+func (s *JobsServer) logReconcile(fix bool) {
+	drifts, err := s.reconcileSchedules(context.Background(), fix)
+	if err != nil {
+		log.Printf("schedule reconcile failed: %v", err)
+		return
+	}
+	for _, d := range drifts {
+		if d.Error != "" {
+			log.Printf("schedule reconcile: %s: %s", d.Name, d.Error)
+			continue
+		}
+		log.Printf("schedule drift: %s %s: expected %q, provider has %q (fixed: %v)", d.Name, d.Field, d.Expected, d.Actual, d.Fixed)
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryInterceptor turns a panic in a unary call into codes.Internal and
+// logs its stack, so one bad call can't take the server down. It should be
+// first in the chain to also cover the other interceptors.
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(info.FullMethod, p)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor is RecoveryInterceptor for streaming calls.
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(info.FullMethod, p)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a recovered panic with the stack that raised it. It must be
+// called from the deferred function that recovered.
+func recovered(where string, p any) error {
+	log.Printf("panic in %s: %v\n%s", where, p, debug.Stack())
+	return status.Error(codes.Internal, "internal error")
+}
+
+// safely runs fn and turns a panic in it into a logged codes.Internal error,
+// for work outside a gRPC call, such as scheduled runs and pollers, that
+// nothing else would recover.
+func safely(where string, fn func()) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(where, p)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// recoverRun records a run that panicked as failed, so it is not left
+// "running", and returns the error for the caller.
+func (s *JobsServer) recoverRun(ctx context.Context, r runner.JobRequest, start int64, p any) error {
+	err := recovered("run "+r.JobID, p)
+	s.recordExecution(context.WithoutCancel(ctx), r, r.JobID, "", fmt.Errorf("panic: %v", p), start, s.clock.Now().Unix())
+	return err
+}
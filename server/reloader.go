@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
 )
 
 // Reload schedules from store at startup
@@ -19,22 +20,96 @@ func (s *JobsServer) Reload(ctx context.Context) {
 		return
 	}
 	for _, r := range records {
-		req := runner.JobRequest{
-			Name:           r.Name,
-			Command:        r.Command,
-			ArgsJSONBase64: r.ArgsBase64,
-			Resources:      runner.Resources{CPU: r.Cpu, Memory: r.Memory},
-			Type:           runner.JobTypeRepeatable,
-			ScheduleSpec:   r.CronSpec,
+		rn, req, err := s.storedRequest(r)
+		if err != nil {
+			log.Printf("failed to restore schedule for %s: %v", r.Name, err)
+			continue
+		}
+		if r.RunAt != 0 {
+			s.restoreDeferred(r, rn, req)
+			continue
 		}
-		spec := r.CronSpec
-		err := s.sched.Schedule(r.Name, spec, func(c context.Context) {
-			_, _ = s.runner.RunJob(c, s.cfg.Jobs.Cmd, req)
-		})
+		run, err := s.register(r, rn, req)
 		if err != nil {
 			log.Printf("failed to restore schedule for %s: %v", r.Name, err)
+			continue
+		}
+		if !r.Paused {
+			s.catchUp(r, run, s.config().GetTimeoutFor(r.Command))
 		}
 		// small delay to avoid thundering herd on boot
 		time.Sleep(50 * time.Millisecond)
 	}
 }
+
+// storedRequest builds the request runs of the stored schedule r are made
+// with under the current config, and the runner they run on.
+func (s *JobsServer) storedRequest(r scheduler.JobRecord) (runner.Runner, runner.JobRequest, error) {
+	req := runner.JobRequest{
+		Name:           r.Name,
+		Command:        r.Command,
+		ArgsJSONBase64: r.ArgsBase64,
+		Resources:      runner.Resources{CPU: r.Cpu, Memory: r.Memory},
+		Type:           runner.JobTypeRepeatable,
+		ScheduleSpec:   r.CronSpec,
+		TimeZone:       r.TimeZone,
+		HealthCheck:    s.healthCheckFor(r.Command),
+		Labels:         r.Labels,
+		MachineType:    s.config().GetMachineTypeFor(r.Command),
+		Security:       s.securityFor(r.Command),
+		Secrets:        s.secretsFor(r.Command),
+		StopSignal:     s.config().GetStopSignalFor(r.Command),
+		Image:          s.config().GetImageFor(r.Command),
+		Timeout:        r.Timeout,
+		MaxRetries:     r.MaxRetries,
+	}
+	s.withJobEnv(&req)
+	rn, _, err := s.runnerFor(r.Runner, r.Command)
+	return rn, req, err
+}
+
+// register (re)registers the stored schedule r with the in-memory scheduler
+// and returns the callback its ticks run.
+func (s *JobsServer) register(r scheduler.JobRecord, rn runner.Runner, req runner.JobRequest) (scheduler.JobFunc, error) {
+	run := s.scheduledRun(rn, req)
+	if r.Singleton {
+		run = s.singleton(r.Name, run)
+	}
+	// a paused schedule is registered all the same; its ticks read the
+	// paused state from the store and are skipped until it is resumed
+	return run, s.sched.Schedule(r.Name, r.CronSpec, run, s.scheduleOptions(req, r.SkipIfRunning)...)
+}
+
+// catchUp replays ticks missed while the server was down. Coalesced schedules
+// get at most one run; otherwise up to MaxCatchup runs execute back to back.
+func (s *JobsServer) catchUp(r scheduler.JobRecord, run scheduler.JobFunc, timeout time.Duration) {
+	if r.LastFiredAt == 0 {
+		return
+	}
+	limit := 1
+	if !r.CoalesceMissed {
+		limit = r.MaxCatchup
+		if limit <= 0 {
+			return
+		}
+	}
+	missed, err := scheduler.MissedRuns(runner.ZonedSpec(r.CronSpec, r.TimeZone), time.Unix(r.LastFiredAt, 0), s.clock.Now(), limit)
+	if err != nil {
+		log.Printf("failed to compute missed runs for %s: %v", r.Name, err)
+		return
+	}
+	if missed == 0 {
+		return
+	}
+	log.Printf("catching up %d missed run(s) for %s", missed, r.Name)
+	go func() {
+		for i := 0; i < missed; i++ {
+			ctx, cancel := context.Background(), context.CancelFunc(func() {})
+			if timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			run(ctx)
+			cancel()
+		}
+	}()
+}
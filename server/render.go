@@ -0,0 +1,187 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RenderCommand returns the command line RunJob would execute for req, with
+// secret values redacted, so a run can be reproduced outside Apollo.
+func (s *JobsServer) RenderCommand(ctx context.Context, req *proto.RunJobRequest) (*proto.RenderCommandResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := s.checkEnvOverrides(req); err != nil {
+		return nil, err
+	}
+	r, err := s.jobRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	rn, _, err := s.runnerFor(req.GetRunner(), r.Command)
+	if err != nil {
+		return nil, err
+	}
+	command, err := s.renderCommand(ctx, rn, r)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.RenderCommandResponse{Command: command}, nil
+}
+
+// renderCommand renders what rn would run for r, for RenderCommand and dry
+// runs.
+func (s *JobsServer) renderCommand(ctx context.Context, rn runner.Runner, r runner.JobRequest) (string, error) {
+	renderer, ok := rn.(runner.CommandRenderer)
+	if !ok {
+		return "", status.Error(codes.Unimplemented, "runner does not support rendering commands")
+	}
+	return renderer.RenderCommand(ctx, s.config().Jobs.Cmd, r)
+}
+
+// jobRequest maps a RunJobRequest onto the runner's request, applying the
+// resource defaults configured for the job and the runner it is routed to.
+func (s *JobsServer) jobRequest(req *proto.RunJobRequest) (runner.JobRequest, error) {
+	r := runner.JobRequest{
+		Name:           req.GetName(),
+		JobID:          req.GetJobId(),
+		Command:        req.GetCommand(),
+		ArgsJSONBase64: req.GetArgsBase64(),
+		Resources:      resources(req.GetResources()),
+		Type:           mapJobType(req.GetType()),
+		ScheduleSpec:   req.GetSchedule(),
+		Labels:         req.GetLabels(),
+		BatchID:        req.GetBatchId(),
+		MaxRetries:     req.MaxRetries,
+		MachineType:    req.GetMachineType(),
+		Wait:           req.GetWait(),
+		DryRun:         req.GetDryRun(),
+		StopSignal:     req.GetStopSignal(),
+		Image:          s.config().GetImageFor(req.GetCommand()),
+	}
+	if req.GetMaxRetries() < 0 {
+		return r, status.Error(codes.InvalidArgument, "max_retries must not be negative")
+	}
+	if t := req.GetTimeout(); t != "" {
+		timeout, err := time.ParseDuration(t)
+		if err != nil || timeout <= 0 {
+			return r, status.Errorf(codes.InvalidArgument, "invalid timeout %q: must be a positive duration such as \"30m\"", t)
+		}
+		r.Timeout = timeout
+	}
+	tz, spec := runner.SplitTimeZone(r.ScheduleSpec)
+	if z := req.GetTimeZone(); z != "" {
+		if tz != "" && tz != z {
+			return r, status.Errorf(codes.InvalidArgument, "schedule %q runs in %s but time_zone is %s", r.ScheduleSpec, tz, z)
+		}
+		tz = z
+	}
+	r.ScheduleSpec, r.TimeZone = spec, tz
+	_, profile, err := s.runnerFor(req.GetRunner(), r.Command)
+	if err != nil {
+		return r, err
+	}
+	r.Resources = s.resolveResources(r.Command, profile, r.Resources)
+	r.HealthCheck = s.healthCheckFor(r.Command)
+	r.Secrets = s.secretsFor(r.Command)
+	if r.MachineType == "" {
+		r.MachineType = s.config().GetMachineTypeFor(r.Command)
+	}
+	if r.StopSignal == "" {
+		r.StopSignal = s.config().GetStopSignalFor(r.Command)
+	}
+	r.Security = s.securityFor(r.Command)
+	if sec := req.GetSecurity(); sec != nil {
+		floor := r.Security
+		if floor == nil {
+			floor = s.runnerSecurity(profile)
+		}
+		requested := &runner.Security{CapDrop: sec.GetCapDrop(), CapAdd: sec.GetCapAdd(), NoNewPrivileges: sec.GetNoNewPrivileges()}
+		if r.Security, err = runner.TightenSecurity(floor, requested); err != nil {
+			return r, err
+		}
+	}
+	if o := req.GetOverrides(); o != nil {
+		if err := s.checkVolumes(o.GetVolumes()); err != nil {
+			return r, err
+		}
+		overrides := &runner.JobOverrides{
+			Args:      o.GetArgs(),
+			TaskCount: o.GetTaskCount(),
+			Network:   o.GetNetwork(),
+		}
+		for _, env := range o.GetEnv() {
+			overrides.Env = append(overrides.Env, runner.EnvVar{Name: env.GetName(), Value: env.GetValue()})
+		}
+		for _, v := range o.GetVolumes() {
+			overrides.Volumes = append(overrides.Volumes, runner.VolumeMount{HostPath: v.GetHostPath(), ContainerPath: v.GetContainerPath(), ReadOnly: v.GetReadOnly()})
+		}
+		if res := o.GetResources(); res != nil {
+			override := resources(res)
+			overrides.Resources = &override
+		}
+		r.Overrides = overrides
+	}
+	s.withJobEnv(&r)
+	return r, nil
+}
+
+// securityFor is the container security configured for a job, or nil to use
+// the runner's.
+func (s *JobsServer) securityFor(command string) *runner.Security {
+	sec := s.config().GetSecurityFor(command)
+	if sec == nil {
+		return nil
+	}
+	return &runner.Security{CapDrop: sec.CapDrop, CapAdd: sec.CapAdd, NoNewPrivileges: sec.NoNewPrivileges}
+}
+
+// runnerSecurity is the default container security of the named runner
+// profile ("" for the primary runner), or nil when it has none.
+func (s *JobsServer) runnerSecurity(profile string) *runner.Security {
+	rc := s.config().PrimaryRunner()
+	if p, ok := s.config().GetRunnerConfig(profile); ok && profile != "" {
+		rc = s.config().ResolveRunner(p)
+	}
+	if rc.Security == nil {
+		return nil
+	}
+	return &runner.Security{CapDrop: rc.Security.CapDrop, CapAdd: rc.Security.CapAdd, NoNewPrivileges: rc.Security.NoNewPrivileges}
+}
+
+// secretsFor narrows the secrets a job receives as configured for it, or is
+// nil to give it all of the runner's.
+func (s *JobsServer) secretsFor(command string) *runner.SecretSelection {
+	tags, names, ok := s.config().GetSecretsFor(command)
+	if !ok {
+		return nil
+	}
+	return &runner.SecretSelection{Tags: tags, Names: names}
+}
+
+func resources(res *proto.Resources) runner.Resources {
+	return runner.Resources{
+		CPU:      res.GetCpu(),
+		Memory:   res.GetMemory(),
+		GPUCount: res.GetGpuCount(),
+		GPUType:  res.GetGpuType(),
+	}
+}
+
+// checkImage verifies the image a run uses, its own or else the runner's,
+// exists when image validation is enabled and the runner supports it.
+func (s *JobsServer) checkImage(ctx context.Context, rn runner.Runner, image string) error {
+	if !s.config().ValidateImages {
+		return nil
+	}
+	checker, ok := rn.(runner.ImageChecker)
+	if !ok {
+		return nil
+	}
+	return checker.CheckImage(ctx, image)
+}
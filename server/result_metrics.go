@@ -0,0 +1,60 @@
+package server
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// extractMetrics sets the metrics of a finished run of a job configured
+// with metrics, read from its output.
+func (s *JobsServer) extractMetrics(rec *scheduler.ExecutionRecord) {
+	job, ok := s.config().GetJobConfig(rec.Command)
+	if !ok || job.Metrics == nil {
+		return
+	}
+	rec.Metrics = resultMetrics(rec.Result, job.Metrics)
+}
+
+// resultMetrics extracts the metrics mc describes from a run's output, or nil
+// when there are none.
+func resultMetrics(result string, mc *cfg.MetricsConfig) map[string]float64 {
+	metrics := map[string]float64{}
+	if mc.JSON {
+		lines := strings.Split(result, "\n")
+		for i := len(lines) - 1; i >= 0; i-- {
+			line := strings.TrimSpace(lines[i])
+			var fields map[string]any
+			if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &fields) != nil {
+				continue
+			}
+			for k, v := range fields {
+				if n, ok := v.(float64); ok {
+					metrics[k] = n
+				}
+			}
+			break
+		}
+	}
+	for name, re := range mc.Regexps() {
+		matches := re.FindAllStringSubmatch(result, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		last := matches[len(matches)-1]
+		value := last[0]
+		if len(last) > 1 {
+			value = last[1]
+		}
+		if n, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			metrics[name] = n
+		}
+	}
+	if len(metrics) == 0 {
+		return nil
+	}
+	return metrics
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// retentionInterval is how often old executions are purged, unless the
+// retention itself is shorter.
+const retentionInterval = time.Hour
+
+// StartRetention deletes executions that finished more than retention ago,
+// once at start and then periodically until Shutdown. A zero retention keeps
+// the history forever.
+func (s *JobsServer) StartRetention(retention time.Duration) {
+	if retention <= 0 || s.store == nil {
+		return
+	}
+	interval := min(retention, retentionInterval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			_ = safely("execution retention", func() { s.purgeExecutions(retention) })
+			select {
+			case <-s.quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *JobsServer) purgeExecutions(retention time.Duration) {
+	n, err := s.store.PurgeExecutions(context.Background(), s.clock.Now().Add(-retention))
+	if err != nil {
+		log.Printf("execution retention: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("execution retention: purged %d execution(s) older than %s", n, retention)
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/runner"
+)
+
+// retryDecision decides whether the failed attempt of a scheduled run is
+// retried under policy and explains why, for the execution history.
+func (s *JobsServer) retryDecision(policy cfg.RetryConfig, name string, attempt int, delay time.Duration, runErr error) (bool, string) {
+	if attempt >= policy.MaxRetries {
+		return false, fmt.Sprintf("retries exhausted (%d/%d)", attempt, policy.MaxRetries)
+	}
+	matched := ""
+	if policy.RetryIfStderrMatches != "" {
+		re, err := regexp.Compile(policy.RetryIfStderrMatches)
+		if err != nil {
+			return false, fmt.Sprintf("invalid retry_if_stderr_matches: %v", err)
+		}
+		stderr, ok := runner.Stderr(runErr)
+		if !ok {
+			return false, "stderr was not captured, so retry_if_stderr_matches cannot match"
+		}
+		if !re.MatchString(stderr) {
+			return false, fmt.Sprintf("stderr does not match %q", policy.RetryIfStderrMatches)
+		}
+		matched = fmt.Sprintf(": stderr matches %q", policy.RetryIfStderrMatches)
+	}
+	if next := s.nextTick(name); !next.IsZero() && s.clock.Now().Add(delay).After(next) {
+		return false, fmt.Sprintf("next scheduled run at %s comes first", next.Format(time.RFC3339))
+	}
+	return true, fmt.Sprintf("retry %d/%d in %s%s", attempt+1, policy.MaxRetries, delay, matched)
+}
+
+func (s *JobsServer) recordRetryDecision(ctx context.Context, id, decision string) {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.SetRetryDecision(ctx, id, decision); err != nil {
+		log.Printf("failed to record retry decision for %s: %v", id, err)
+	}
+}
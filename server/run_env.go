@@ -0,0 +1,49 @@
+package server
+
+import (
+	"slices"
+
+	"github.com/SyneHQ/apollo/runner"
+)
+
+// withLocation tells a run where it executes through APOLLO_PROVIDER and
+// APOLLO_REGION, so job code can branch on its execution context instead of
+// guessing it. APOLLO_REGION is empty for runners without a region.
+func withLocation(rn runner.Runner, r *runner.JobRequest) {
+	locator, ok := rn.(runner.Locator)
+	if !ok {
+		return
+	}
+	provider, region := locator.Location()
+	overrides := runner.JobOverrides{}
+	if r.Overrides != nil {
+		overrides = *r.Overrides
+	}
+	overrides.Env = append(slices.Clone(overrides.Env),
+		runner.EnvVar{Name: "APOLLO_PROVIDER", Value: provider},
+		runner.EnvVar{Name: "APOLLO_REGION", Value: region},
+	)
+	r.Overrides = &overrides
+}
+
+// withJobEnv puts the env vars configured for r's job in jobs.yml ahead of
+// its overrides, leaving out those an override sets so the client's value
+// wins.
+func (s *JobsServer) withJobEnv(r *runner.JobRequest) {
+	env := s.config().GetEnvFor(r.Command)
+	if len(env) == 0 {
+		return
+	}
+	overrides := runner.JobOverrides{}
+	if r.Overrides != nil {
+		overrides = *r.Overrides
+	}
+	merged := make([]runner.EnvVar, 0, len(env)+len(overrides.Env))
+	for _, e := range env {
+		if !slices.ContainsFunc(overrides.Env, func(o runner.EnvVar) bool { return o.Name == e.Name }) {
+			merged = append(merged, runner.EnvVar{Name: e.Name, Value: e.Value})
+		}
+	}
+	overrides.Env = append(merged, overrides.Env...)
+	r.Overrides = &overrides
+}
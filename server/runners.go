@@ -0,0 +1,35 @@
+package server
+
+import (
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// runnerFor picks the runner for a job: the requested profile, else the
+// profile configured for the command in jobs.yml, else the primary runner.
+// It returns the resolved profile name, empty for the primary runner.
+func (s *JobsServer) runnerFor(requested, command string) (runner.Runner, string, error) {
+	name := requested
+	if name == "" {
+		if job, ok := s.config().GetJobConfig(command); ok {
+			name = job.Runner
+		}
+	}
+	if name == "" {
+		return s.runner, "", nil
+	}
+	r, ok := s.runners[name]
+	if !ok {
+		return nil, "", status.Errorf(codes.InvalidArgument, "unknown runner %q", name)
+	}
+	return r, name, nil
+}
+
+// providerFor reports the provider behind a runner profile.
+func (s *JobsServer) providerFor(profile string) string {
+	if rc, ok := s.config().GetRunnerConfig(profile); ok {
+		return rc.Provider
+	}
+	return s.config().JobsProvider
+}
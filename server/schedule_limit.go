@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// withinScheduleLimit runs create, which stores the named schedule, unless
+// MaxSchedules are stored already and it is not one of them: updating a
+// stored schedule is always allowed. Checks and creations on this server are
+// serialized, so concurrent creations cannot overshoot the limit; those on
+// other replicas still may, slightly.
+func (s *JobsServer) withinScheduleLimit(ctx context.Context, name string, create func() error) error {
+	limit := s.config().MaxSchedules
+	if limit == 0 || s.store == nil {
+		return create()
+	}
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	count, exists, err := s.store.CountSchedules(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !exists && count >= limit {
+		return status.Errorf(codes.ResourceExhausted, "schedule limit of %d reached; delete unused schedules before creating %s", limit, name)
+	}
+	return create()
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/SyneHQ/apollo/runner"
+)
+
+// errInterrupted marks executions that were still running when the server
+// shut down and never reported a final status.
+var errInterrupted = errors.New("interrupted by server shutdown")
+
+// stopGrace is how long Shutdown waits for runs it stopped to record how
+// they ended, e.g. for a local container to be stopped.
+const stopGrace = 30 * time.Second
+
+type inflightRun struct {
+	req   runner.JobRequest
+	start int64
+	rn    runner.Runner
+	// cancel stops the run's context; cancelled is set by CancelJob and
+	// interrupted by a Shutdown that timed out
+	cancel      context.CancelFunc
+	cancelled   bool
+	interrupted bool
+}
+
+// beginRun registers an in-flight execution. It reports false once Shutdown
+// has started so no new work is accepted.
+func (s *JobsServer) beginRun(run inflightRun) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.beginRunLocked(run)
+}
+
+func (s *JobsServer) beginRunLocked(run inflightRun) bool {
+	if s.closing {
+		return false
+	}
+	s.inflight[run.req.JobID] = run
+	s.wg.Add(1)
+	return true
+}
+
+// endRun must be called once the execution's final status has been recorded.
+func (s *JobsServer) endRun(id string) {
+	s.mu.Lock()
+	delete(s.inflight, id)
+	s.mu.Unlock()
+	s.wg.Done()
+}
+
+// Shutdown stops accepting new runs, stops the scheduler and waits for its
+// running jobs and in-flight executions to record their final status.
+// Executions still running when ctx expires are stopped: local containers are
+// stopped and recorded as interrupted, while cloud jobs run on and are
+// recorded as submitted so they are settled later. Runs that have not
+// recorded themselves stopGrace later are recorded as interrupted. The store
+// is closed last.
+func (s *JobsServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closing = true
+	s.mu.Unlock()
+	close(s.quit)
+
+	// a tick can be past cron but not yet in wg, so wait for cron's running
+	// jobs as well
+	var cronDone <-chan struct{}
+	if s.sched != nil {
+		cronDone = s.sched.Stop().Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		if cronDone != nil {
+			<-cronDone
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.mu.Lock()
+		stopping := make([]inflightRun, 0, len(s.inflight))
+		for id, run := range s.inflight {
+			run.interrupted = true
+			s.inflight[id] = run
+			stopping = append(stopping, run)
+		}
+		s.mu.Unlock()
+		log.Printf("shutdown timed out, stopping %d execution(s)", len(stopping))
+		for _, run := range stopping {
+			run.cancel()
+		}
+		select {
+		case <-done:
+		case <-time.After(stopGrace):
+			s.mu.Lock()
+			pending := make([]inflightRun, 0, len(s.inflight))
+			for _, run := range s.inflight {
+				pending = append(pending, run)
+			}
+			s.mu.Unlock()
+			log.Printf("%d execution(s) did not stop, marking them as interrupted", len(pending))
+			end := s.clock.Now().Unix()
+			for _, run := range pending {
+				s.recordExecution(context.Background(), run.req, run.req.JobID, "", errInterrupted, run.start, end)
+			}
+		}
+	}
+
+	if s.store != nil {
+		return s.store.Close()
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// singletonLeaseTTL is how long a tick's lease row is kept. It only needs to
+// outlive clock skew between replicas, since every tick has its own key.
+const singletonLeaseTTL = time.Hour
+
+// instanceID identifies this replica as a lease holder.
+var instanceID = func() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}()
+
+// singleton wraps a scheduled run so that each tick runs on only one replica
+// sharing the store. Ticks are keyed by name and fire minute, so sub-minute
+// schedules run at most once a minute cluster-wide.
+func (s *JobsServer) singleton(name string, run scheduler.JobFunc) scheduler.JobFunc {
+	return func(c context.Context) {
+		minute := s.clock.Now().Truncate(time.Minute).Unix()
+		key := fmt.Sprintf("%s@%d", name, minute)
+		ok, err := s.store.AcquireLease(c, key, instanceID, singletonLeaseTTL)
+		if err != nil {
+			log.Printf("skipping %s: failed to acquire lease: %v", name, err)
+			return
+		}
+		if !ok {
+			log.Printf("skipping %s: tick %s is held by another replica", name, time.Unix(minute, 0).UTC().Format(time.RFC3339))
+			return
+		}
+		run(c)
+	}
+}
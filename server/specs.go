@@ -0,0 +1,44 @@
+package server
+
+import (
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	cron "github.com/robfig/cron/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SpecParserFor returns the parser of the scheduler that runs schedules for
+// the configured provider: the in-process scheduler takes cron with seconds,
+// Cloud Scheduler takes unix cron with a resolution of one minute.
+func SpecParserFor(c *cfg.Config) scheduler.SpecParser {
+	if c.JobsProvider == "local" {
+		return scheduler.SecondsParser
+	}
+	return minuteSpecParser{}
+}
+
+// minuteSpecParser accepts what Cloud Scheduler can run: unix cron, or a
+// 6-field spec that fires on the minute, which is converted before parsing.
+type minuteSpecParser struct{}
+
+func (minuteSpecParser) Parse(spec string) (cron.Schedule, error) {
+	minute, err := runner.MinuteCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.StandardParser.Parse(minute)
+}
+
+// validateSpec rejects a schedule spec the provider's scheduler cannot run,
+// with the parse error, before anything is scheduled or stored.
+func (s *JobsServer) validateSpec(spec string) error {
+	if _, err := SpecParserFor(s.config()).Parse(spec); err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return err
+		}
+		return status.Errorf(codes.InvalidArgument, "invalid schedule %q: %v", spec, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// GetStats reports the outstanding job count of every runner that caps its
+// submissions, primary runner first, the stored schedule count and whether
+// maintenance mode is on.
+func (s *JobsServer) GetStats(ctx context.Context, req *proto.GetStatsRequest) (*proto.GetStatsResponse, error) {
+	resp := &proto.GetStatsResponse{Runners: []*proto.RunnerStats{}, MaxSchedules: int32(s.config().MaxSchedules), MaintenanceMode: s.inMaintenance(ctx)}
+	if s.store != nil {
+		count, _, err := s.store.CountSchedules(scheduler.WithReplica(ctx), "")
+		if err != nil {
+			return nil, err
+		}
+		resp.Schedules = int32(count)
+	}
+	add := func(profile string, rn runner.Runner) {
+		if reporter, ok := rn.(runner.OutstandingReporter); ok {
+			count, limit := reporter.OutstandingJobs(ctx)
+			resp.Runners = append(resp.Runners, &proto.RunnerStats{
+				Runner:             profile,
+				OutstandingJobs:    int32(count),
+				MaxOutstandingJobs: int32(limit),
+			})
+		}
+	}
+	add("", s.runner)
+	for _, name := range slices.Sorted(maps.Keys(s.runners)) {
+		add(name, s.runners[name])
+	}
+	return resp, nil
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetJobStatus reports a job's state as its runner sees it, so clients can
+// poll a submitted job until it finishes.
+func (s *JobsServer) GetJobStatus(ctx context.Context, req *proto.GetJobStatusRequest) (*proto.GetJobStatusResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	rn, _, err := s.runnerFor(req.GetRunner(), "")
+	if err != nil {
+		return nil, err
+	}
+	st, err := rn.GetJobStatus(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	resp := &proto.GetJobStatusResponse{State: mapJobState(st.State), ExitCode: st.ExitCode}
+	if !st.StartedAt.IsZero() {
+		resp.StartedAt = st.StartedAt.Unix()
+	}
+	if !st.FinishedAt.IsZero() {
+		resp.FinishedAt = st.FinishedAt.Unix()
+	}
+	return resp, nil
+}
+
+// ListJobs lists the jobs a runner's provider holds, including those Apollo
+// did not start, so operators can reconcile them with Apollo's records.
+func (s *JobsServer) ListJobs(ctx context.Context, req *proto.ListJobsRequest) (*proto.ListJobsResponse, error) {
+	rn, _, err := s.runnerFor(req.GetRunner(), "")
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := rn.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*proto.JobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		info := &proto.JobInfo{Name: job.Name, State: mapJobState(job.State)}
+		if !job.CreatedAt.IsZero() {
+			info.CreatedAt = job.CreatedAt.Unix()
+		}
+		out = append(out, info)
+	}
+	return &proto.ListJobsResponse{Items: out}, nil
+}
+
+func mapJobState(s runner.JobState) proto.JobState {
+	switch s {
+	case runner.JobStateRunning:
+		return proto.JobState_JOB_STATE_RUNNING
+	case runner.JobStateSucceeded:
+		return proto.JobState_JOB_STATE_SUCCEEDED
+	case runner.JobStateFailed:
+		return proto.JobState_JOB_STATE_FAILED
+	default:
+		return proto.JobState_JOB_STATE_PENDING
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errSubmitted marks a run that its runner only created at the provider; how
+// it ends is recorded once the provider reports it finished.
+var errSubmitted = errors.New("submitted to the provider")
+
+// submits reports whether rn returns from RunJob for r before the job has
+// run.
+func submits(rn runner.Runner, r runner.JobRequest) bool {
+	sub, ok := rn.(runner.Submitter)
+	return ok && sub.Submits() && !r.Wait
+}
+
+// SettleSubmitted asks the provider what became of every "submitted"
+// execution and records the terminal status of those that have finished. Jobs
+// the provider no longer knows are marked as errors.
+func (s *JobsServer) SettleSubmitted(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+	recs, err := s.store.ListExecutions(ctx, scheduler.ExecutionFilter{Status: "submitted"})
+	if err != nil {
+		log.Printf("settling submitted jobs failed: %v", err)
+		return
+	}
+	for _, rec := range recs {
+		_, st, err := s.submitterOf(ctx, rec.ID)
+		if status.Code(err) == codes.NotFound {
+			rec.Status = "error"
+			rec.Error = "job not found at the provider"
+			rec.FinishedAt = s.clock.Now().Unix()
+		} else if err != nil {
+			log.Printf("settling submitted jobs: status of %s: %v", rec.ID, err)
+			continue
+		} else if !s.settle(&rec, st) {
+			continue
+		}
+		if err := s.store.AddExecution(ctx, rec); err != nil {
+			log.Printf("settling submitted jobs: update %s: %v", rec.ID, err)
+		}
+	}
+}
+
+// submitterOf asks each runner that submits jobs about id, as executions do
+// not record which runner profile started them, and returns the one that
+// knows it with the job's status.
+func (s *JobsServer) submitterOf(ctx context.Context, id string) (runner.Runner, runner.JobStatus, error) {
+	return s.ownerOf(ctx, id, func(rn runner.Runner) bool {
+		sub, ok := rn.(runner.Submitter)
+		return ok && sub.Submits()
+	})
+}
+
+// ownerOf asks the primary and then each profile runner that include accepts
+// about id, and returns the first that knows it with the job's status.
+func (s *JobsServer) ownerOf(ctx context.Context, id string, include func(runner.Runner) bool) (runner.Runner, runner.JobStatus, error) {
+	runners := []runner.Runner{s.runner}
+	for _, name := range slices.Sorted(maps.Keys(s.runners)) {
+		runners = append(runners, s.runners[name])
+	}
+	err := status.Errorf(codes.NotFound, "job %s not found", id)
+	for _, rn := range runners {
+		if !include(rn) {
+			continue
+		}
+		st, e := rn.GetJobStatus(ctx, id)
+		if status.Code(e) != codes.NotFound {
+			return rn, st, e
+		}
+		err = e
+	}
+	return nil, runner.JobStatus{}, err
+}
+
+// StartSubmittedPoller periodically settles submitted executions in the
+// background until Shutdown. A zero interval disables it.
+func (s *JobsServer) StartSubmittedPoller(interval time.Duration) {
+	if interval <= 0 || s.store == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.quit:
+				return
+			case <-ticker.C:
+			}
+			_ = safely("submitted poller", func() { s.SettleSubmitted(context.Background()) })
+		}
+	}()
+}
+
+// listSubmittedFor has rn count the jobs the store records as submitted
+// towards its cap on outstanding jobs.
+func (s *JobsServer) listSubmittedFor(rn runner.Runner) {
+	lister, ok := rn.(runner.SubmittedLister)
+	if !ok || s.store == nil {
+		return
+	}
+	lister.SetSubmittedLister(s.submittedJobs)
+}
+
+// submittedJobs returns the provider names of the "submitted" executions
+// whose names start with prefix.
+func (s *JobsServer) submittedJobs(ctx context.Context, prefix string) ([]string, error) {
+	var names []string
+	err := s.store.IterateExecutions(ctx, scheduler.ExecutionFilter{Status: "submitted"}, func(rec scheduler.ExecutionRecord) error {
+		// the result of a submitted run starts with the job's name
+		name, _, _ := strings.Cut(rec.Result, "\n")
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+		return nil
+	})
+	return names, err
+}
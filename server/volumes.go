@@ -0,0 +1,44 @@
+package server
+
+import (
+	"path"
+	"strings"
+
+	"github.com/SyneHQ/apollo/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkVolumes rejects request volumes whose host path is not under one of
+// the configured VolumeHostRoots, and every volume when none is configured.
+func (s *JobsServer) checkVolumes(volumes []*proto.VolumeMount) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+	roots := s.config().VolumeHostRoots
+	if len(roots) == 0 {
+		return status.Error(codes.InvalidArgument, "volumes are disabled: set VOLUME_HOST_ROOTS to allow them")
+	}
+	for _, v := range volumes {
+		if !underRoot(v.GetHostPath(), roots) {
+			return status.Errorf(codes.InvalidArgument, "volume host path %q is not under an allowed root (%s)", v.GetHostPath(), strings.Join(roots, ", "))
+		}
+	}
+	return nil
+}
+
+// underRoot reports whether the absolute path p is one of roots or below
+// one, after resolving any "..".
+func underRoot(p string, roots []string) bool {
+	if !path.IsAbs(p) {
+		return false
+	}
+	p = path.Clean(p)
+	for _, root := range roots {
+		root = path.Clean(root)
+		if p == root || strings.HasPrefix(p, strings.TrimSuffix(root, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestAuditInterceptorRecordsMutatingCalls(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Secrets: []cfg.SecretConfig{{Name: "DB_PASSWORD", Value: "hunter2"}}}}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer js.Shutdown(ctx)
+
+	// stands in for an auth interceptor that identifies callers by a header
+	auth := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-user")) > 0 {
+			ctx = jobsserver.WithCaller(ctx, md.Get("x-user")[0])
+		}
+		return handler(ctx, req)
+	}
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(auth, js.AuditInterceptor()))
+	proto.RegisterJobsServiceServer(srv, js)
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewJobsServiceClient(conn)
+
+	alice := metadata.AppendToOutgoingContext(ctx, "x-user", "alice")
+	if _, err := client.RunJob(alice, &proto.RunJobRequest{
+		Name: "report", Command: "report", ArgsBase64: "hunter2",
+		Overrides: &proto.JobOverrides{Env: []*proto.EnvVar{{Name: "TOKEN", Value: "s3cret"}}},
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := client.ListSchedules(alice, &proto.ListSchedulesRequest{}); err != nil {
+		t.Fatalf("ListSchedules: %v", err)
+	}
+	if _, err := client.PauseSchedule(ctx, &proto.PauseScheduleRequest{Name: "missing"}); err == nil {
+		t.Fatal("PauseSchedule of an unknown schedule succeeded")
+	}
+
+	resp, err := client.ListAuditEvents(ctx, &proto.ListAuditEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListAuditEvents: %v", err)
+	}
+	events := resp.GetItems()
+	if len(events) != 2 {
+		t.Fatalf("audit events = %v, want RunJob and PauseSchedule only", events)
+	}
+	pause, run := events[0], events[1]
+	if pause.GetMethod() != proto.JobsService_PauseSchedule_FullMethodName || pause.GetOutcome() != "NotFound" || pause.GetError() == "" {
+		t.Errorf("PauseSchedule event = %v, want a NotFound outcome", pause)
+	}
+	if !strings.HasPrefix(pause.GetCaller(), "bufconn") {
+		t.Errorf("anonymous caller = %q, want the peer address", pause.GetCaller())
+	}
+	if run.GetMethod() != proto.JobsService_RunJob_FullMethodName || run.GetCaller() != "alice" || run.GetOutcome() != "OK" || run.GetAt() == 0 {
+		t.Errorf("RunJob event = %v, want alice's successful call", run)
+	}
+	if args := run.GetArgs(); !strings.Contains(args, `"report"`) || strings.Contains(args, "s3cret") || strings.Contains(args, "hunter2") {
+		t.Errorf("RunJob args = %s, want the request with secret values redacted", args)
+	}
+
+	filtered, err := client.ListAuditEvents(ctx, &proto.ListAuditEventsRequest{Caller: "alice"})
+	if err != nil || len(filtered.GetItems()) != 1 || filtered.GetItems()[0].GetId() != run.GetId() {
+		t.Fatalf("ListAuditEvents by caller = %v (%v), want alice's RunJob", filtered.GetItems(), err)
+	}
+}
+
+func TestAuditInterceptorRedactsSecretsAcrossTheRequest(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	intercept := js.AuditInterceptor()
+	handler := func(context.Context, any) (any, error) { return nil, nil }
+	reqs := map[string]any{
+		proto.JobsService_RunJobs_FullMethodName: &proto.RunJobsRequest{Jobs: []*proto.RunJobRequest{{
+			Name: "report", Overrides: &proto.JobOverrides{Env: []*proto.EnvVar{{Name: "PLAIN", Value: "s3cret"}}},
+		}}},
+		proto.JobsService_RunNamedJob_FullMethodName: &proto.RunNamedJobRequest{
+			Name: "export", Params: map[string]string{"api_token": "s3cret", "day": "monday"},
+		},
+	}
+	for method, req := range reqs {
+		if _, err := intercept(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+			t.Fatalf("%s: %v", method, err)
+		}
+	}
+
+	resp, err := js.ListAuditEvents(ctx, &proto.ListAuditEventsRequest{})
+	if err != nil || len(resp.GetItems()) != 2 {
+		t.Fatalf("ListAuditEvents = %v (%v), want both calls", resp.GetItems(), err)
+	}
+	for _, e := range resp.GetItems() {
+		if strings.Contains(e.GetArgs(), "s3cret") || !strings.Contains(e.GetArgs(), "<redacted>") {
+			t.Errorf("%s args = %s, want secret values redacted", e.GetMethod(), e.GetArgs())
+		}
+	}
+	for _, e := range resp.GetItems() {
+		if e.GetMethod() == proto.JobsService_RunNamedJob_FullMethodName && !strings.Contains(e.GetArgs(), "monday") {
+			t.Errorf("RunNamedJob args = %s, want plain params kept", e.GetArgs())
+		}
+	}
+}
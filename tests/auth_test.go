@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// authClient serves js behind its auth and audit interceptors.
+func authClient(t *testing.T, js *jobsserver.JobsServer) proto.JobsServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(js.AuthInterceptor(), js.AuditInterceptor()),
+		grpc.StreamInterceptor(js.AuthStreamInterceptor()),
+	)
+	proto.RegisterJobsServiceServer(srv, js)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return proto.NewJobsServiceClient(conn)
+}
+
+func TestAuthInterceptorRequiresABearerToken(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local", AuthToken: "sh4red", AuthAPIKeys: map[string]string{"ci": "k3y"}}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer js.Shutdown(ctx)
+	client := authClient(t, js)
+
+	run := &proto.RunJobRequest{Name: "report", Command: "report"}
+	for _, header := range []string{"", "Bearer wrong", "Basic sh4red", "sh4red"} {
+		callCtx := ctx
+		if header != "" {
+			callCtx = metadata.AppendToOutgoingContext(ctx, "authorization", header)
+		}
+		if _, err := client.RunJob(callCtx, run); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("RunJob with authorization %q: err = %v, want Unauthenticated", header, err)
+		}
+	}
+	stream, err := client.StreamLogs(ctx, &proto.StreamLogsRequest{JobId: "report-1"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("StreamLogs without a token: err = %v, want Unauthenticated", err)
+	}
+
+	shared := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer sh4red")
+	if _, err := client.RunJob(shared, run); err != nil {
+		t.Fatalf("RunJob with the shared token: %v", err)
+	}
+	ci := metadata.AppendToOutgoingContext(ctx, "authorization", "bearer k3y")
+	if _, err := client.RunJob(ci, run); err != nil {
+		t.Fatalf("RunJob with an API key: %v", err)
+	}
+	events, err := client.ListAuditEvents(ci, &proto.ListAuditEventsRequest{})
+	if err != nil {
+		t.Fatalf("ListAuditEvents: %v", err)
+	}
+	if items := events.GetItems(); len(items) != 2 || items[0].GetCaller() != "key=ci" || items[1].GetCaller() != "token" {
+		t.Errorf("audit events = %v, want the API key's and the shared token's calls", items)
+	}
+}
+
+func TestAuthInterceptorIsOffWithoutAToken(t *testing.T) {
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, nil)
+	defer js.Shutdown(ctx)
+	client := authClient(t, js)
+
+	if _, err := client.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "report"}); err != nil {
+		t.Fatalf("RunJob without auth configured: %v", err)
+	}
+}
+
+func TestAuthInterceptorRequiresATokenWithOnlyAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local", AuthAPIKeys: map[string]string{"ci": "k3y"}}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, nil)
+	defer js.Shutdown(ctx)
+	client := authClient(t, js)
+
+	run := &proto.RunJobRequest{Name: "report", Command: "report"}
+	if _, err := client.RunJob(ctx, run); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("RunJob without a token: err = %v, want Unauthenticated", err)
+	}
+	ci := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer k3y")
+	if _, err := client.RunJob(ci, run); err != nil {
+		t.Fatalf("RunJob with the API key: %v", err)
+	}
+}
+
+func TestAuthInterceptorLetsHealthChecksThrough(t *testing.T) {
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local", AuthToken: "sh4red"}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, nil)
+	defer js.Shutdown(ctx)
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(js.AuthInterceptor()),
+		grpc.StreamInterceptor(js.AuthStreamInterceptor()),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Check without a token = %v, %v; want SERVING", resp, err)
+	}
+	watch, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err == nil {
+		_, err = watch.Recv()
+	}
+	if err != nil {
+		t.Fatalf("Watch without a token: %v", err)
+	}
+}
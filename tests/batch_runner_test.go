@@ -0,0 +1,690 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/infisical/go-sdk/packages/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// fakeBatchClient is an in-memory runner.BatchClient. Create calls pop errors
+// from failCreates first; a failing create still leaves a partially created job
+// behind, as Batch can when it runs out of quota mid-request.
+type fakeBatchClient struct {
+	mu          sync.Mutex
+	jobs        map[string]*batchpb.Job
+	failCreates []error
+	createdIDs  []string
+	deleted     []string
+	// submitted holds the job spec of every CreateJob call
+	submitted []*batchpb.Job
+}
+
+func newFakeBatchClient(failures ...error) *fakeBatchClient {
+	return &fakeBatchClient{jobs: map[string]*batchpb.Job{}, failCreates: failures}
+}
+
+func (f *fakeBatchClient) CreateJob(ctx context.Context, req *batchpb.CreateJobRequest) (*batchpb.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name := req.GetParent() + "/jobs/" + req.GetJobId()
+	f.createdIDs = append(f.createdIDs, req.GetJobId())
+	f.submitted = append(f.submitted, req.GetJob())
+	if len(f.failCreates) > 0 {
+		err := f.failCreates[0]
+		f.failCreates = f.failCreates[1:]
+		f.jobs[name] = &batchpb.Job{Name: name}
+		return nil, err
+	}
+	if _, ok := f.jobs[name]; ok {
+		return nil, status.Error(codes.AlreadyExists, "job already exists")
+	}
+	job := &batchpb.Job{Name: name, Labels: req.GetJob().GetLabels()}
+	f.jobs[name] = job
+	return job, nil
+}
+
+func (f *fakeBatchClient) GetJob(ctx context.Context, name string) (*batchpb.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if job, ok := f.jobs[name]; ok {
+		return job, nil
+	}
+	return nil, status.Error(codes.NotFound, "job not found")
+}
+
+func (f *fakeBatchClient) DeleteJob(ctx context.Context, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.jobs, name)
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func (f *fakeBatchClient) ListJobs(ctx context.Context, parent string) ([]*batchpb.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var jobs []*batchpb.Job
+	for _, name := range slices.Sorted(maps.Keys(f.jobs)) {
+		if strings.HasPrefix(name, parent+"/jobs/") {
+			jobs = append(jobs, f.jobs[name])
+		}
+	}
+	return jobs, nil
+}
+
+func (f *fakeBatchClient) Close() error { return nil }
+
+// fakeLogClient serves lines, oldest first, as the entries of every filter.
+type fakeLogClient struct {
+	lines   []string
+	filters []string
+}
+
+func (f *fakeLogClient) Entries(ctx context.Context, filter string, newestFirst bool, limit int) ([]string, error) {
+	f.filters = append(f.filters, filter)
+	lines := slices.Clone(f.lines)
+	if newestFirst {
+		slices.Reverse(lines)
+	}
+	if limit > 0 && len(lines) > limit {
+		lines = lines[:limit]
+	}
+	return lines, nil
+}
+
+func (f *fakeLogClient) Close() error { return nil }
+
+func newTestBatchRunner(client runner.BatchClient) *runner.BatchRunner {
+	b := runner.NewBatchRunner("test-project", "us-central1", "example/image:latest", nil)
+	b.QuotaRetryDelay = 0
+	b.NewClient = func(ctx context.Context) (runner.BatchClient, error) { return client, nil }
+	b.NewLogClient = func(ctx context.Context) (runner.LogClient, error) { return &fakeLogClient{}, nil }
+	return b
+}
+
+func TestBatchRunnerRetriesQuotaWithFreshJobID(t *testing.T) {
+	client := newFakeBatchClient(status.Error(codes.ResourceExhausted, "quota exceeded"))
+	b := newTestBatchRunner(client)
+
+	name, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name:      "nightly-backup",
+		Command:   "handleBackupJob",
+		Resources: runner.Resources{CPU: "500m", Memory: "1Gi"},
+	})
+	if err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+
+	if len(client.createdIDs) != 2 {
+		t.Fatalf("expected 2 create attempts, got %d: %v", len(client.createdIDs), client.createdIDs)
+	}
+	first, second := client.createdIDs[0], client.createdIDs[1]
+	if first != "nightly-backup" {
+		t.Errorf("first attempt should use the request name, got %q", first)
+	}
+	if second == first || !strings.HasPrefix(second, "nightly-backup-") {
+		t.Errorf("retry should use a fresh ID derived from the name, got %q", second)
+	}
+	if len(client.deleted) != 1 || !strings.HasSuffix(client.deleted[0], "/jobs/nightly-backup") {
+		t.Errorf("partially created job should be cleaned up, deleted: %v", client.deleted)
+	}
+	if !strings.HasSuffix(name, "/jobs/"+second) {
+		t.Errorf("RunJob should report the finally created job, got %q", name)
+	}
+}
+
+func TestBatchRunnerDoesNotRetryNameClash(t *testing.T) {
+	client := newFakeBatchClient(status.Error(codes.AlreadyExists, "job already exists"))
+	b := newTestBatchRunner(client)
+
+	_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "nightly-backup", Command: "handleBackupJob"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+	if len(client.createdIDs) != 1 {
+		t.Errorf("expected a single create attempt, got %v", client.createdIDs)
+	}
+}
+
+func TestBatchRunnerPassesArgsAsDistinctTokens(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	filter := "--filter=name = 'foo bar'"
+	_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name:           "report",
+		Command:        "buildReport",
+		ArgsJSONBase64: "e30=",
+		Resources:      runner.Resources{CPU: "500m", Memory: "1Gi"},
+		Overrides:      &runner.JobOverrides{Args: []string{filter, "--dry-run"}},
+	})
+	if err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+
+	container := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetContainer()
+	want := []string{"/app/rover", "buildReport", "e30=", filter, "--dry-run"}
+	if !slices.Equal(container.GetCommands(), want) {
+		t.Fatalf("commands = %q, want %q", container.GetCommands(), want)
+	}
+	if container.GetOptions() != "" {
+		t.Fatalf("options = %q, want args kept out of docker options", container.GetOptions())
+	}
+}
+
+func TestBatchRunnerQueuesSubmissionsAtOutstandingCap(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.MaxOutstandingJobs = 1
+	b.OutstandingPollInterval = 10 * time.Millisecond
+
+	first, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "first", Command: "sync"})
+	if err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "second", Command: "sync"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("second submission was not queued: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if count, limit := b.OutstandingJobs(context.Background()); count != 1 || limit != 1 {
+		t.Fatalf("OutstandingJobs = %d, %d, want 1, 1", count, limit)
+	}
+
+	client.mu.Lock()
+	client.jobs[first].Status = &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}
+	client.mu.Unlock()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("queued RunJob failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued submission did not go through after the outstanding job finished")
+	}
+	if count, _ := b.OutstandingJobs(context.Background()); count != 1 {
+		t.Fatalf("OutstandingJobs = %d after the first job finished, want 1", count)
+	}
+}
+
+func TestBatchRunnerMapsTimeoutAndRetriesOntoTaskSpec(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "default", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	five, none := int32(5), int32(0)
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name: "backfill", Command: "backfill", Timeout: 72 * time.Hour, MaxRetries: &five,
+	}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "once", Command: "sync", MaxRetries: &none}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+
+	for i, want := range []struct {
+		duration time.Duration
+		retries  int32
+	}{{24 * time.Hour, 3}, {72 * time.Hour, 5}, {24 * time.Hour, 0}} {
+		spec := client.submitted[i].GetTaskGroups()[0].GetTaskSpec()
+		if spec.GetMaxRunDuration().AsDuration() != want.duration || spec.GetMaxRetryCount() != want.retries {
+			t.Errorf("job %d: max run duration %s, retries %d, want %s, %d", i,
+				spec.GetMaxRunDuration().AsDuration(), spec.GetMaxRetryCount(), want.duration, want.retries)
+		}
+	}
+}
+
+func TestBatchRunnerAttachesRequestedGPUs(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name: "train", Command: "train", Resources: runner.Resources{CPU: "1", Memory: "2Gi", GPUCount: 1, GPUType: "nvidia-tesla-t4"},
+	}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	instances := client.submitted[0].GetAllocationPolicy().GetInstances()[0]
+	accelerators := instances.GetPolicy().GetAccelerators()
+	if len(accelerators) != 1 || accelerators[0].GetType() != "nvidia-tesla-t4" || accelerators[0].GetCount() != 1 {
+		t.Fatalf("accelerators = %v, want one nvidia-tesla-t4", accelerators)
+	}
+	if !instances.GetInstallGpuDrivers() {
+		t.Fatal("GPU drivers are not installed")
+	}
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "plain", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	plain := client.submitted[1].GetAllocationPolicy().GetInstances()[0]
+	if len(plain.GetPolicy().GetAccelerators()) != 0 || plain.GetInstallGpuDrivers() {
+		t.Fatalf("job without GPUs got accelerators: %v", plain)
+	}
+}
+
+func TestBatchRunnerRejectsIncompatibleGPUs(t *testing.T) {
+	b := newTestBatchRunner(newFakeBatchClient())
+	for _, res := range []runner.Resources{
+		{GPUCount: 3, GPUType: "nvidia-l4"},
+		{GPUCount: 1, GPUType: "nvidia-imaginary"},
+		{GPUCount: 2},
+	} {
+		_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "train", Command: "train", Resources: res})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("RunJob with %+v: err = %v, want InvalidArgument", res, err)
+		}
+	}
+}
+
+func TestBatchRunnerFitsAcceleratorOptimizedMachineTypes(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	var want []string
+	for _, tc := range []struct {
+		res  runner.Resources
+		want string
+	}{
+		{runner.Resources{CPU: "2", Memory: "8Gi", GPUCount: 1, GPUType: "nvidia-l4"}, "g2-standard-4"},
+		{runner.Resources{CPU: "20", Memory: "64Gi", GPUCount: 1, GPUType: "nvidia-l4"}, "g2-standard-32"},
+		{runner.Resources{CPU: "4", Memory: "16Gi", GPUCount: 2, GPUType: "nvidia-tesla-a100"}, "a2-highgpu-2g"},
+		{runner.Resources{CPU: "4", Memory: "16Gi", GPUCount: 1, GPUType: "nvidia-a100-80gb"}, "a2-ultragpu-1g"},
+	} {
+		want = append(want, tc.want)
+		if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "train", JobID: "train-" + tc.want, Command: "train", Resources: tc.res}); err != nil {
+			t.Fatalf("RunJob with %+v: %v", tc.res, err)
+		}
+	}
+	for i, job := range client.submitted {
+		if got := job.GetAllocationPolicy().GetInstances()[0].GetPolicy().GetMachineType(); got != want[i] {
+			t.Errorf("job %d machine type = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestBatchRunnerMachineType(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	for _, req := range []runner.JobRequest{
+		{Name: "small", Command: "sync", Resources: runner.Resources{CPU: "500m", Memory: "1Gi"}},
+		{Name: "big", Command: "sync", Resources: runner.Resources{CPU: "2", Memory: "32Gi"}},
+		{Name: "pinned", Command: "sync", Resources: runner.Resources{CPU: "2", Memory: "32Gi"}, MachineType: "n2-highmem-8"},
+		{Name: "share", Command: "sync", Resources: runner.Resources{CPU: "50%", Memory: "50%"}},
+	} {
+		if _, err := b.RunJob(context.Background(), "/app/rover", req); err != nil {
+			t.Fatalf("RunJob %s: %v", req.Name, err)
+		}
+	}
+	b.MachineType = "e2-standard-8"
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "default", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob default: %v", err)
+	}
+
+	want := []string{"n1-standard-1", "e2-highmem-4", "n2-highmem-8", "n1-standard-1", "e2-standard-8"}
+	for i, job := range client.submitted {
+		if got := job.GetAllocationPolicy().GetInstances()[0].GetPolicy().GetMachineType(); got != want[i] {
+			t.Errorf("job %d machine type = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestBatchRunnerRejectsResourcesNoMachineFits(t *testing.T) {
+	b := newTestBatchRunner(newFakeBatchClient())
+	_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name: "huge", Command: "sync", Resources: runner.Resources{CPU: "64", Memory: "512Gi"},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestBatchRunnerDeleteJobByIDOrName(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	var names []string
+	for _, id := range []string{"job-report-1", "job-report-2", "job-report-3"} {
+		name, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: id, Command: "buildReport"})
+		if err != nil {
+			t.Fatalf("RunJob %s: %v", id, err)
+		}
+		names = append(names, name)
+	}
+	client.jobs[names[2]].Status = &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}
+
+	if err := b.DeleteJob(context.Background(), "job-report-1"); err != nil {
+		t.Fatalf("DeleteJob by ID: %v", err)
+	}
+	if !slices.Equal(client.deleted, names[:1]) {
+		t.Fatalf("deleted %v, want only %s", client.deleted, names[0])
+	}
+
+	if err := b.DeleteJob(context.Background(), "report"); err != nil {
+		t.Fatalf("DeleteJob by name: %v", err)
+	}
+	if !slices.Equal(client.deleted, names[:2]) {
+		t.Fatalf("deleted %v, want %v; finished runs are kept", client.deleted, names[:2])
+	}
+}
+
+func TestBatchRunnerDeleteJobByNameAfterRestart(t *testing.T) {
+	client := newFakeBatchClient()
+	var names []string
+	for _, id := range []string{"job-report-1", "job-report-2"} {
+		name, err := newTestBatchRunner(client).RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: id, Command: "buildReport"})
+		if err != nil {
+			t.Fatalf("RunJob %s: %v", id, err)
+		}
+		names = append(names, name)
+	}
+	if _, err := newTestBatchRunner(client).RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "other", JobID: "job-other-1", Command: "buildReport"}); err != nil {
+		t.Fatalf("RunJob other: %v", err)
+	}
+
+	// a fresh runner has tracked none of the runs
+	if err := newTestBatchRunner(client).DeleteJob(context.Background(), "report"); err != nil {
+		t.Fatalf("DeleteJob by name: %v", err)
+	}
+	if !slices.Equal(client.deleted, names) {
+		t.Fatalf("deleted %v, want the runs of report %v", client.deleted, names)
+	}
+}
+
+func TestBatchRunnerScheduleBodyMatchesRunJob(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.Secrets = []models.Secret{{SecretKey: "API_KEY", SecretValue: "s3cret"}}
+	b.SecretRefs = map[string]string{"API_KEY": "projects/test-project/secrets/api-key/versions/latest"}
+	req := runner.JobRequest{
+		Name:         "nightly-report",
+		Command:      "buildReport",
+		Resources:    runner.Resources{CPU: "2", Memory: "4Gi"},
+		Type:         runner.JobTypeRepeatable,
+		ScheduleSpec: "0 0 2 * * *",
+		Overrides: &runner.JobOverrides{
+			Env:       []runner.EnvVar{{Name: "FORMAT", Value: "csv"}},
+			TaskCount: 3,
+		},
+	}
+	if _, err := b.RunJob(context.Background(), "/app/rover", req); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	body, err := b.ScheduleBody("/app/rover", req)
+	if err != nil {
+		t.Fatalf("ScheduleBody: %v", err)
+	}
+	var scheduled batchpb.Job
+	if err := protojson.Unmarshal(body, &scheduled); err != nil {
+		t.Fatalf("schedule body is not a Batch job: %v", err)
+	}
+	if !gproto.Equal(&scheduled, client.submitted[0]) {
+		t.Fatalf("scheduled job differs from the submitted one:\n%v\n%v", &scheduled, client.submitted[0])
+	}
+}
+
+func TestBatchRunnerScheduleBodyRefusesPlaintextSecrets(t *testing.T) {
+	b := newTestBatchRunner(newFakeBatchClient())
+	b.Secrets = []models.Secret{
+		{SecretKey: "DB_PASSWORD", SecretValue: "hunter2"},
+		{SecretKey: "API_KEY", SecretValue: "s3cret"},
+	}
+	b.SecretRefs = map[string]string{"DB_PASSWORD": "projects/test-project/secrets/db-password/versions/latest"}
+	req := runner.JobRequest{Name: "nightly-report", Command: "buildReport", Type: runner.JobTypeRepeatable, ScheduleSpec: "0 0 2 * * *"}
+
+	body, err := b.ScheduleBody("/app/rover", req)
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "API_KEY") || strings.Contains(err.Error(), "DB_PASSWORD") {
+		t.Fatalf("ScheduleBody err = %v, want FailedPrecondition naming API_KEY only", err)
+	}
+	if strings.Contains(string(body), "s3cret") || strings.Contains(string(body), "hunter2") {
+		t.Fatalf("schedule body leaks a secret: %s", body)
+	}
+}
+
+func TestBatchRunnerReadsFlaggedSecretsFromSecretManager(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.Secrets = []models.Secret{
+		{SecretKey: "DB_PASSWORD", SecretValue: "hunter2"},
+		{SecretKey: "LOG_LEVEL", SecretValue: "debug"},
+	}
+	b.SecretRefs = map[string]string{"DB_PASSWORD": "projects/test-project/secrets/db-password/versions/latest"}
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "sync", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	env := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetEnvironment()
+	if _, leaked := env.GetVariables()["DB_PASSWORD"]; leaked || env.GetVariables()["LOG_LEVEL"] != "debug" {
+		t.Fatalf("variables = %v, want only LOG_LEVEL in plaintext", env.GetVariables())
+	}
+	if got := env.GetSecretVariables()["DB_PASSWORD"]; got != "projects/test-project/secrets/db-password/versions/latest" {
+		t.Fatalf("secret variables = %v", env.GetSecretVariables())
+	}
+
+	b.SecretRefs["DB_PASSWORD"] = "db-password"
+	_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "sync", JobID: "sync-2", Command: "sync"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("RunJob with a malformed reference: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestBatchRunnerTellsJobsTheirResolvedLimits(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	_, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name:      "share",
+		Command:   "sync",
+		Resources: runner.Resources{CPU: "50%", Memory: "50%"},
+		Overrides: &runner.JobOverrides{Env: []runner.EnvVar{{Name: "APOLLO_CPU_LIMIT", Value: "64"}}},
+	})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	env := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetEnvironment().GetVariables()
+	if env["APOLLO_CPU_LIMIT"] != "0.5" || env["APOLLO_MEMORY_LIMIT"] != "1920" {
+		t.Fatalf("limits = %s cores, %s MiB, want 0.5 cores, 1920 MiB of n1-standard-1", env["APOLLO_CPU_LIMIT"], env["APOLLO_MEMORY_LIMIT"])
+	}
+}
+
+func TestBatchRunnerHardensContainers(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.Security = &runner.Security{CapDrop: []string{"ALL"}, NoNewPrivileges: true}
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "untrusted", Command: "sandbox"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	container := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetContainer()
+	if got := container.GetOptions(); got != "--cap-drop ALL --security-opt no-new-privileges" {
+		t.Fatalf("container options = %q", got)
+	}
+}
+
+func TestBatchRunnerSetsTheStopSignal(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.StopSignal = "SIGINT"
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "drain", Command: "drain", StopSignal: "SIGUSR1"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	container := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetContainer()
+	if got := container.GetOptions(); got != "--stop-signal SIGUSR1" {
+		t.Fatalf("container options = %q, want the request's stop signal", got)
+	}
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "drain", Command: "drain", StopSignal: "drain"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("RunJob with an unknown signal: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestBatchRunnerMountsVolumes(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+
+	req := runner.JobRequest{Name: "import", Command: "import", Overrides: &runner.JobOverrides{Volumes: []runner.VolumeMount{
+		{HostPath: "/mnt/disks/share", ContainerPath: "/data", ReadOnly: true},
+	}}}
+	if _, err := b.RunJob(context.Background(), "/app/rover", req); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	container := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetContainer()
+	if got := container.GetVolumes(); !slices.Equal(got, []string{"/mnt/disks/share:/data:ro"}) {
+		t.Fatalf("container volumes = %q", got)
+	}
+	req.Overrides.Volumes[0].HostPath = "share"
+	if _, err := b.RunJob(context.Background(), "/app/rover", req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("RunJob with a relative host path: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestBatchRunnerRestrictsZones(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.Zones = []string{"us-central1-a", "zones/us-central1-c"}
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "train", Command: "train"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	got := client.submitted[0].GetAllocationPolicy().GetLocation().GetAllowedLocations()
+	if want := []string{"zones/us-central1-a", "zones/us-central1-c"}; !slices.Equal(got, want) {
+		t.Fatalf("allowed locations = %v, want %v", got, want)
+	}
+
+	for _, zone := range []string{"europe-west1-b", "us-central1", "us-central1-ab"} {
+		b.Zones = []string{zone}
+		if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "train", Command: "train"}); err == nil {
+			t.Errorf("RunJob accepted zone %q outside us-central1", zone)
+		}
+	}
+	if len(client.submitted) != 1 {
+		t.Fatalf("%d jobs submitted, want only the first", len(client.submitted))
+	}
+}
+
+// finish moves the fake Batch job name to state once it has been created.
+func (f *fakeBatchClient) finish(t *testing.T, name string, state batchpb.JobStatus_State, counts map[string]int64) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		f.mu.Lock()
+		if _, ok := f.jobs[name]; ok {
+			f.jobs[name] = &batchpb.Job{Name: name, Status: &batchpb.JobStatus{
+				State:      state,
+				TaskGroups: map[string]*batchpb.JobStatus_TaskGroupStatus{"group0": {Counts: counts}},
+			}}
+			f.mu.Unlock()
+			return
+		}
+		f.mu.Unlock()
+		if time.Now().After(deadline) {
+			t.Errorf("job %s was never created", name)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBatchRunnerWaitsForTheJobToFinish(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.WaitPollInterval = 10 * time.Millisecond
+	const name = "projects/test-project/locations/us-central1/jobs/"
+
+	go client.finish(t, name+"report-1", batchpb.JobStatus_SUCCEEDED, map[string]int64{"SUCCEEDED": 3})
+	result, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: "report-1", Command: "report", Wait: true})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if want := name + "report-1\nstate: SUCCEEDED\ntasks: SUCCEEDED=3"; result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+
+	go client.finish(t, name+"report-2", batchpb.JobStatus_FAILED, map[string]int64{"SUCCEEDED": 2, "FAILED": 1})
+	result, err = b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: "report-2", Command: "report", Wait: true})
+	if err == nil || !strings.Contains(result, "tasks: FAILED=1 SUCCEEDED=2") {
+		t.Errorf("failed job = %q, %v; want its task counts and an error", result, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := b.RunJob(ctx, "/app/rover", runner.JobRequest{Name: "report", JobID: "report-3", Command: "report", Wait: true}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RunJob of a job that never finishes: err = %v, want the context's", err)
+	}
+	if result, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: "report-4", Command: "report"}); err != nil || result != name+"report-4" {
+		t.Errorf("RunJob without Wait = %q, %v; want the job name right away", result, err)
+	}
+}
+
+func TestBatchRunnerReadsTheTailOfAJobsLogs(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	logs := &fakeLogClient{lines: []string{"one", "two", "three", "four"}}
+	b.NewLogClient = func(ctx context.Context) (runner.LogClient, error) { return logs, nil }
+
+	name, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: "report-1", Command: "report"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if got, err := b.ReadLogs(context.Background(), "report-1", runner.AllTasks, 0); err != nil || got != "one\ntwo\nthree\nfour" {
+		t.Errorf("ReadLogs = %q, %v; want every line", got, err)
+	}
+	if got, err := b.ReadLogs(context.Background(), name, runner.AllTasks, 2); err != nil || got != "three\nfour" {
+		t.Errorf("ReadLogs by full name with 2 lines = %q, %v; want the last two in order", got, err)
+	}
+	if _, err := b.ReadLogs(context.Background(), "report-1", 1, 0); err != nil {
+		t.Fatalf("ReadLogs of task 1: %v", err)
+	}
+	if filter := logs.filters[len(logs.filters)-1]; !strings.Contains(filter, `labels.task_id:"-group0-1/"`) {
+		t.Errorf("task filter = %q, want it narrowed to task 1", filter)
+	}
+
+	b.WaitPollInterval = 10 * time.Millisecond
+	go client.finish(t, name[:strings.LastIndex(name, "/")+1]+"report-2", batchpb.JobStatus_SUCCEEDED, map[string]int64{"SUCCEEDED": 1})
+	result, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", JobID: "report-2", Command: "report", Wait: true})
+	if err != nil || !strings.HasSuffix(result, "tasks: SUCCEEDED=1\n\none\ntwo\nthree\nfour") {
+		t.Errorf("waited result = %q, %v; want the summary followed by the output", result, err)
+	}
+}
+
+func TestBatchRunnerTagsJobVMs(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.NetworkTags = []string{"apollo-jobs", "egress-nat"}
+
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "sync", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	if tags := client.submitted[0].GetAllocationPolicy().GetTags(); !slices.Equal(tags, b.NetworkTags) {
+		t.Fatalf("tags = %v, want %v", tags, b.NetworkTags)
+	}
+
+	for _, tag := range []string{"Apollo", "-jobs", "jobs-", "apollo_jobs", ""} {
+		b.NetworkTags = []string{tag}
+		if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "sync", Command: "sync"}); err == nil {
+			t.Errorf("RunJob accepted network tag %q", tag)
+		}
+	}
+	if len(client.submitted) != 1 {
+		t.Fatalf("submitted %d jobs, want only the validly tagged one", len(client.submitted))
+	}
+}
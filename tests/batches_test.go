@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// failingNamesRunner fails runs of the listed job names; it is safe for
+// concurrent use.
+type failingNamesRunner struct {
+	recordingRunner
+	fail map[string]bool
+}
+
+func (r *failingNamesRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	if r.fail[req.Name] {
+		return "", errors.New("shard failed")
+	}
+	return "ok", nil
+}
+
+func TestRunJobsGroupsExecutionsUnderBatch(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &failingNamesRunner{fail: map[string]bool{"shard-3": true, "shard-7": true}}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+
+	var jobs []*proto.RunJobRequest
+	for i := 0; i < 12; i++ {
+		jobs = append(jobs, &proto.RunJobRequest{Name: fmt.Sprintf("shard-%d", i), Command: "backfill"})
+	}
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "unrelated", Command: "backfill"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	resp, err := js.RunJobs(ctx, &proto.RunJobsRequest{Jobs: jobs})
+	if err != nil {
+		t.Fatalf("RunJobs: %v", err)
+	}
+	if resp.GetBatchId() == "" || len(resp.GetResults()) != 12 {
+		t.Fatalf("RunJobs = %+v", resp)
+	}
+	if resp.GetResults()[3].GetError() == "" || resp.GetResults()[4].GetId() == "" {
+		t.Fatalf("results = %+v, want shard-3 failed and shard-4 run", resp.GetResults())
+	}
+
+	listed, err := js.ListExecutions(ctx, &proto.ListExecutionsRequest{BatchId: resp.GetBatchId()})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(listed.GetItems()) != 12 {
+		t.Fatalf("batch executions = %d, want 12", len(listed.GetItems()))
+	}
+
+	got, err := js.GetBatchStatus(ctx, &proto.GetBatchStatusRequest{BatchId: resp.GetBatchId()})
+	if err != nil {
+		t.Fatalf("GetBatchStatus: %v", err)
+	}
+	if got.GetTotal() != 12 || got.GetSucceeded() != 10 || got.GetFailed() != 2 || got.GetRunning() != 0 {
+		t.Fatalf("batch status = %+v", got)
+	}
+}
+
+func TestGetStatsReportsOutstandingBatchJobs(t *testing.T) {
+	b := newTestBatchRunner(newFakeBatchClient())
+	b.MaxOutstandingJobs = 5
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "sync", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob failed: %v", err)
+	}
+	js := jobsserver.NewJobsServer(b, map[string]runner.Runner{"local": &recordingRunner{}}, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+	resp, err := js.GetStats(context.Background(), &proto.GetStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if len(resp.GetRunners()) != 1 {
+		t.Fatalf("runners = %v, want only the Batch runner", resp.GetRunners())
+	}
+	if got := resp.GetRunners()[0]; got.GetRunner() != "" || got.GetOutstandingJobs() != 1 || got.GetMaxOutstandingJobs() != 5 {
+		t.Fatalf("stats = %v, want 1 of 5 outstanding on the primary runner", got)
+	}
+}
+
+func TestOutstandingCapCountsJobsSubmittedByOtherReplicas(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.MaxOutstandingJobs = 1
+	b.OutstandingPollInterval = 10 * time.Millisecond
+	js := jobsserver.NewJobsServer(b, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+
+	// another replica's job, still running at the provider
+	const other = "projects/test-project/locations/us-central1/jobs/other-1"
+	client.mu.Lock()
+	client.jobs[other] = &batchpb.Job{Name: other, Status: &batchpb.JobStatus{State: batchpb.JobStatus_RUNNING}}
+	client.mu.Unlock()
+	if err := st.AddExecution(ctx, scheduler.ExecutionRecord{ID: "other-1", Name: "other", Status: "submitted", Result: other, StartedAt: 1}); err != nil {
+		t.Fatalf("AddExecution: %v", err)
+	}
+	if count, limit := b.OutstandingJobs(ctx); count != 1 || limit != 1 {
+		t.Fatalf("OutstandingJobs = %d, %d, want the stored job counted", count, limit)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", JobId: "report-1", Command: "report"})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("RunJob was not queued behind the other replica's job: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	client.mu.Lock()
+	client.jobs[other].Status = &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}
+	client.mu.Unlock()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("queued RunJob: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued RunJob did not go through after the other job finished")
+	}
+}
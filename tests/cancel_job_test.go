@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCancelJobStopsARunningLocalJob(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	// run records its pid and sleeps like a long job; stop ends it
+	fakeDocker(t, `case "$1" in
+run) echo $$ > "$FAKE_DOCKER_DIR/pid"; exec sleep 30 ;;
+stop) kill "$(cat "$FAKE_DOCKER_DIR/pid")" 2>/dev/null ;;
+esac
+`)
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	srv := jobsserver.NewJobsServer(runner.NewLocalRunner("apollo:latest", nil), nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := srv.RunJob(ctx, &proto.RunJobRequest{Name: "backfill", JobId: "backfill-1", Command: "backfill"})
+		done <- err
+	}()
+	waitFor(t, "the container to start", func() bool {
+		_, err := os.Stat(filepath.Join(dir, "pid"))
+		return err == nil
+	})
+
+	if _, err := srv.CancelJob(ctx, &proto.CancelJobRequest{Id: "backfill-1"}); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("RunJob succeeded, want the cancelled run to fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("CancelJob did not stop the running job")
+	}
+	rec, err := st.GetExecution(ctx, "backfill-1")
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if rec.Status != "cancelled" || !strings.HasPrefix(rec.Error, "cancelled") || rec.FinishedAt == 0 {
+		t.Errorf("execution = %+v, want it recorded as cancelled", rec)
+	}
+
+	// the run is over, so there is nothing left to cancel
+	if _, err := srv.CancelJob(ctx, &proto.CancelJobRequest{Id: "backfill-1"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CancelJob of a finished run: %v, want FailedPrecondition", err)
+	}
+	if _, err := srv.CancelJob(ctx, &proto.CancelJobRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("CancelJob of an unknown run: %v, want NotFound", err)
+	}
+}
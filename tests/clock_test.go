@@ -0,0 +1,94 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// fakeClock is a scheduler.Clock that only moves when advanced. After
+// channels fire once the clock has been advanced past their deadline.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+func TestStoreLeasesExpireByItsClock(t *testing.T) {
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	st := newTestStore(t, scheduler.Options{Clock: clock})
+	ctx := context.Background()
+
+	if ok, err := st.AcquireLease(ctx, "backup@60", "replica-a", time.Minute); err != nil || !ok {
+		t.Fatalf("replica a: ok=%v err=%v, want lease", ok, err)
+	}
+	if ok, err := st.AcquireLease(ctx, "backup@60", "replica-b", time.Minute); err != nil || ok {
+		t.Fatalf("replica b: ok=%v err=%v, want lease held by a", ok, err)
+	}
+	clock.Advance(2 * time.Minute)
+	if ok, err := st.AcquireLease(ctx, "backup@60", "replica-b", time.Minute); err != nil || !ok {
+		t.Fatalf("replica b after expiry: ok=%v err=%v, want lease", ok, err)
+	}
+}
+
+func TestServerStampsExecutionsWithItsClock(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, st, jobsserver.WithClock(clock))
+
+	resp, err := js.RunJob(context.Background(), &proto.RunJobRequest{Name: "sync", Command: "sync"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if resp.GetId() != "job-sync-1700000000" {
+		t.Fatalf("id = %q, want it derived from the clock", resp.GetId())
+	}
+	recs := waitForExecutions(t, st, "sync", 1)
+	if len(recs) != 1 || recs[0].StartedAt != 1_700_000_000 || recs[0].FinishedAt != 1_700_000_000 {
+		t.Fatalf("executions = %+v, want start and finish from the clock", recs)
+	}
+}
@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestReloadConfigAppliesToLaterRuns(t *testing.T) {
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{
+		Image: "apollo:latest",
+		Jobs:  []cfg.JobConfig{{Name: "report", Resources: cfg.ResourceConfig{CPU: "500m", Memory: "1Gi"}}},
+	}}
+	rn := &recordingRunner{}
+	js := jobsserver.NewJobsServer(rn, nil, c, nil)
+	ctx := context.Background()
+	run := func() runner.Resources {
+		t.Helper()
+		if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "report"}); err != nil {
+			t.Fatalf("RunJob: %v", err)
+		}
+		return rn.runs[len(rn.runs)-1].Resources
+	}
+	if got := run(); got != (runner.Resources{CPU: "500m", Memory: "1Gi"}) {
+		t.Fatalf("resources before reload = %+v", got)
+	}
+
+	if err := js.ReloadConfig(ctx, &cfg.JobsConfig{
+		Image: "apollo:latest",
+		Jobs:  []cfg.JobConfig{{Name: "report", Resources: cfg.ResourceConfig{CPU: "2", Memory: "4Gi"}}},
+	}); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	if got := run(); got != (runner.Resources{CPU: "2", Memory: "4Gi"}) {
+		t.Errorf("resources after reload = %+v, want the reloaded ones", got)
+	}
+
+	// an invalid config is rejected and the current one kept
+	if err := js.ReloadConfig(ctx, &cfg.JobsConfig{Jobs: []cfg.JobConfig{{Name: "report"}}}); err == nil {
+		t.Fatal("ReloadConfig accepted a config without an image")
+	}
+	if got := run(); got != (runner.Resources{CPU: "2", Memory: "4Gi"}) {
+		t.Errorf("resources after a rejected reload = %+v, want them kept", got)
+	}
+}
+
+func TestReloadConfigIsSafeDuringRuns(t *testing.T) {
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Image: "apollo:latest"}}
+	js := jobsserver.NewJobsServer(&runnerStub{}, nil, c, nil)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			js.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "report"})
+		}()
+		go func() {
+			defer wg.Done()
+			js.ReloadConfig(ctx, &cfg.JobsConfig{Image: "apollo:latest", Jobs: []cfg.JobConfig{{Name: "report", Timeout: time.Duration(i+1) * time.Minute}}})
+		}()
+	}
+	wg.Wait()
+}
+
+// runnerStub is a runner that does nothing, safe for concurrent use.
+type runnerStub struct{ recordingRunner }
+
+func (*runnerStub) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	return "ok", nil
+}
+
+func TestWatchJobsConfigReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.yml")
+	if err := os.WriteFile(path, []byte("image: apollo:1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("JOBS_CONFIG_PATH", path)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- cfg.WatchJobsConfig(ctx, 50*time.Millisecond, func(jobs *cfg.JobsConfig, err error) {
+			if err != nil {
+				changes <- "error: " + err.Error()
+				return
+			}
+			changes <- jobs.Image
+		})
+	}()
+
+	// the watch is set up asynchronously, so keep rewriting until it is seen
+	deadline := time.After(5 * time.Second)
+	for {
+		if err := os.WriteFile(path, []byte("image: apollo:2\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case got := <-changes:
+			if got != "apollo:2" {
+				t.Fatalf("reloaded image = %q, want apollo:2", got)
+			}
+			cancel()
+			if err := <-done; err != nil {
+				t.Fatalf("WatchJobsConfig: %v", err)
+			}
+			return
+		case <-time.After(200 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("the change was never reloaded")
+		}
+	}
+}
+
+// imageRunner records the image of every run, safe for concurrent use.
+type imageRunner struct {
+	recordingRunner
+	mu     sync.Mutex
+	images []string
+}
+
+func (r *imageRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.images = append(r.images, req.Image)
+	return "ok", nil
+}
+
+func (r *imageRunner) ran(image string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Contains(r.images, image)
+}
+
+func TestReloadConfigReschedulesWithoutCatchingUp(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	for _, rec := range []scheduler.JobRecord{
+		{Name: "nightly", Command: "report", CronSpec: "0 0 3 * * *", CoalesceMissed: true},
+		{Name: "tick", Command: "ping", CronSpec: "* * * * * *"},
+	} {
+		if err := st.Upsert(ctx, rec); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+	if err := st.MarkFired(ctx, "nightly", time.Now().Add(-48*time.Hour).Unix()); err != nil {
+		t.Fatalf("MarkFired: %v", err)
+	}
+	jobs := func(version string) *cfg.JobsConfig {
+		return &cfg.JobsConfig{Image: "apollo:latest", Jobs: []cfg.JobConfig{
+			{Name: "report", Image: "report:" + version},
+			{Name: "ping", Image: "ping:" + version},
+		}}
+	}
+	rn := &imageRunner{}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "local", Jobs: *jobs("1")}, st)
+	defer js.Shutdown(ctx)
+
+	if err := js.ReloadConfig(ctx, jobs("2")); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	waitFor(t, "a tick with the reloaded image", func() bool { return rn.ran("ping:2") })
+	if recs, _ := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "nightly"}); len(recs) != 0 {
+		t.Fatalf("nightly executions after the reload = %d, want its missed run left to a restart", len(recs))
+	}
+}
+
+func TestReloadConfigUpdatesChangedProviderSchedules(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	for _, rec := range []scheduler.JobRecord{
+		{Name: "daily-report", Command: "report", CronSpec: "0 3 * * *"},
+		{Name: "daily-cleanup", Command: "cleanup", CronSpec: "0 4 * * *"},
+	} {
+		if err := st.Upsert(ctx, rec); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+	rn := &cloudScheduleRunner{schedules: map[string]runner.ScheduleInfo{}}
+	c := &cfg.Config{JobsProvider: "cloudrun", GCPProjectID: "test-project", Jobs: cfg.JobsConfig{Image: "apollo:latest"}}
+	js := jobsserver.NewJobsServer(rn, nil, c, st)
+	defer js.Shutdown(ctx)
+
+	if err := js.ReloadConfig(ctx, &cfg.JobsConfig{Image: "apollo:2", Jobs: []cfg.JobConfig{{Name: "report", Timeout: time.Hour}}}); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	if _, ok := rn.schedules["daily-report"]; !ok || len(rn.schedules) != 1 {
+		t.Fatalf("provider schedules = %v, want only daily-report updated", rn.schedules)
+	}
+	if got := js.Config().Jobs.Image; got != "apollo:latest" {
+		t.Fatalf("image after reload = %q, want the startup image kept", got)
+	}
+}
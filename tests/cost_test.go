@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestBatchRunnerEstimateCost(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.Prices = []runner.MachinePrice{
+		{MachineType: "n1-standard-1", Hourly: 0.10, SpotHourly: 0.02},
+		{MachineType: "n1-standard-1", Region: "us-central1", Hourly: 0.05, SpotHourly: 0.01},
+	}
+	name := "projects/test-project/locations/us-central1/jobs/nightly-backup"
+	client.jobs[name] = &batchpb.Job{
+		Name:       name,
+		TaskGroups: []*batchpb.TaskGroup{{TaskCount: 2}},
+		AllocationPolicy: &batchpb.AllocationPolicy{Instances: []*batchpb.AllocationPolicy_InstancePolicyOrTemplate{{
+			PolicyTemplate: &batchpb.AllocationPolicy_InstancePolicyOrTemplate_Policy{
+				Policy: &batchpb.AllocationPolicy_InstancePolicy{MachineType: "n1-standard-1"},
+			},
+		}}},
+		Status: &batchpb.JobStatus{State: batchpb.JobStatus_RUNNING},
+	}
+
+	if _, done, err := b.EstimateCost(context.Background(), name); err != nil || done {
+		t.Fatalf("running job: done=%v err=%v, want not done", done, err)
+	}
+
+	client.jobs[name].Status = &batchpb.JobStatus{
+		State:       batchpb.JobStatus_SUCCEEDED,
+		RunDuration: durationpb.New(3 * time.Hour),
+	}
+	cost, done, err := b.EstimateCost(context.Background(), name)
+	if err != nil || !done {
+		t.Fatalf("finished job: done=%v err=%v", done, err)
+	}
+	// regional price, two VMs for three hours
+	if want := 0.05 * 3 * 2; math.Abs(cost-want) > 1e-9 {
+		t.Fatalf("cost = %v, want %v", cost, want)
+	}
+}
+
+func TestStoreListsExecutionsWithCost(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{CompressResults: true})
+	ctx := context.Background()
+
+	for i, name := range []string{"backup", "backup", "restore"} {
+		err := st.AddExecution(ctx, scheduler.ExecutionRecord{
+			ID:         name + string(rune('a'+i)),
+			Name:       name,
+			Command:    "handleBackupJob",
+			Status:     "success",
+			Result:     "done",
+			StartedAt:  int64(100 * (i + 1)),
+			FinishedAt: int64(100*(i+1) + 50),
+		})
+		if err != nil {
+			t.Fatalf("AddExecution: %v", err)
+		}
+	}
+	if err := st.SetExecutionCost(ctx, "backupb", 1.25); err != nil {
+		t.Fatalf("SetExecutionCost: %v", err)
+	}
+
+	recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "backup", Since: 150})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(recs) != 1 || recs[0].ID != "backupb" {
+		t.Fatalf("ListExecutions = %+v, want only backupb", recs)
+	}
+	if recs[0].EstimatedCost != 1.25 || recs[0].Result != "done" {
+		t.Fatalf("record = %+v, want cost 1.25 and decoded result", recs[0])
+	}
+}
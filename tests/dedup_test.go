@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunJobDeduplicatesConcurrentRuns(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &blockingRunner{release: make(chan struct{})}
+	srv := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	req := &proto.RunJobRequest{Name: "report", Command: "report", Deduplicate: true}
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := srv.RunJob(ctx, req)
+			errs <- err
+		}()
+	}
+	// one of the two is rejected while the other is still running
+	if err := <-errs; status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("first RunJob to return: %v, want AlreadyExists", err)
+	}
+	close(rn.release)
+	if err := <-errs; err != nil {
+		t.Fatalf("deduplicated RunJob: %v", err)
+	}
+	if recs := waitForExecutions(t, st, "report", 1); recs[0].Status != "success" {
+		t.Errorf("executions = %+v, want the one run that went ahead", recs)
+	}
+
+	// once the run is over, the name is free again
+	if _, err := srv.RunJob(ctx, req); err != nil {
+		t.Errorf("RunJob after the first run finished: %v", err)
+	}
+}
+
+func TestRunJobWithoutDeduplicateRunsConcurrently(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &blockingRunner{release: make(chan struct{})}
+	srv := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	errs := make(chan error, 2)
+	for i := range 2 {
+		go func() {
+			_, err := srv.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "report", JobId: fmt.Sprintf("report-%d", i)})
+			errs <- err
+		}()
+	}
+	waitForExecutions(t, st, "report", 2)
+	close(rn.release)
+	for range 2 {
+		if err := <-errs; err != nil {
+			t.Errorf("RunJob: %v", err)
+		}
+	}
+}
+
+func TestRunJobDeduplicatesAgainstRunsInTheStore(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	// another replica's run that has not finished yet
+	if err := st.AddExecution(ctx, scheduler.ExecutionRecord{ID: "report-elsewhere", Name: "report", Status: "submitted", StartedAt: 1}); err != nil {
+		t.Fatalf("AddExecution: %v", err)
+	}
+	req := &proto.RunJobRequest{Name: "report", Command: "report", Deduplicate: true}
+	if _, err := srv.RunJob(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("RunJob while a run is submitted: %v, want AlreadyExists", err)
+	}
+
+	if err := st.AddExecution(ctx, scheduler.ExecutionRecord{ID: "report-elsewhere", Name: "report", Status: "success", StartedAt: 1, FinishedAt: 2}); err != nil {
+		t.Fatalf("AddExecution: %v", err)
+	}
+	if _, err := srv.RunJob(ctx, req); err != nil {
+		t.Errorf("RunJob once the other run finished: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// waitForExecutions polls the store until name has n executions or a few
+// seconds pass.
+func waitForExecutions(t *testing.T, st scheduler.Store, name string, n int) []scheduler.ExecutionRecord {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		recs, err := st.ListExecutions(context.Background(), scheduler.ExecutionFilter{Name: name})
+		if err != nil {
+			t.Fatalf("ListExecutions: %v", err)
+		}
+		if len(recs) >= n || time.Now().After(deadline) {
+			return recs
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestRunAtDefersOneTimeJob(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	at := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "buildReport", RunAt: at.Unix()}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	list, err := js.ListSchedules(ctx, &proto.ListSchedulesRequest{})
+	if err != nil {
+		t.Fatalf("ListSchedules: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].RunAt != at.Unix() {
+		t.Fatalf("schedules = %+v, want the pending report run at %d", list.Items, at.Unix())
+	}
+	if recs := waitForExecutions(t, st, "report", 0); len(recs) != 0 {
+		t.Fatalf("report ran before run_at: %+v", recs)
+	}
+
+	recs := waitForExecutions(t, st, "report", 1)
+	if len(recs) != 1 || recs[0].Status != "success" || recs[0].StartedAt < at.Unix() {
+		t.Fatalf("executions = %+v, want one successful run at or after %d", recs, at.Unix())
+	}
+	if pending, _ := st.List(ctx); len(pending) != 0 {
+		t.Fatalf("pending jobs after the run = %+v, want none", pending)
+	}
+
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "late", Command: "buildReport", RunAt: time.Now().Add(-time.Minute).Unix()}); err == nil {
+		t.Fatal("RunJob with a past run_at succeeded, want an error")
+	}
+}
+
+func TestRunAtKeepsTheScheduleAndEarlierRuns(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{
+		Name: "report", Command: "buildReport", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 0 1 1 *",
+	}); err != nil {
+		t.Fatalf("schedule report: %v", err)
+	}
+	at := time.Now().Add(time.Second).Truncate(time.Second).Add(time.Second)
+	ids := map[string]bool{}
+	for _, runAt := range []time.Time{at, at.Add(time.Second)} {
+		resp, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "buildReport", RunAt: runAt.Unix()})
+		if err != nil {
+			t.Fatalf("RunJob: %v", err)
+		}
+		ids[resp.GetId()] = true
+	}
+	if len(ids) != 2 || ids["report"] {
+		t.Fatalf("deferred run IDs = %v, want two distinct IDs other than the schedule's", ids)
+	}
+	if recs, _ := st.List(ctx); len(recs) != 3 {
+		t.Fatalf("stored jobs = %+v, want the schedule and both deferred runs", recs)
+	}
+
+	if recs := waitForExecutions(t, st, "report", 2); len(recs) != 2 {
+		t.Fatalf("executions = %+v, want both deferred runs", recs)
+	}
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(recs) != 1 || recs[0].Name != "report" || recs[0].CronSpec == "" {
+		t.Fatalf("stored jobs after the runs = %+v, want only the schedule", recs)
+	}
+}
+
+func TestReloadRunsMissedOneTimeJobs(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour).Unix()
+	for _, rec := range []scheduler.JobRecord{
+		{Name: "catch-up", Command: "buildReport", RunAt: past, RunIfMissed: true},
+		{Name: "skip", Command: "buildReport", RunAt: past},
+		{Name: "later", Command: "buildReport", RunAt: time.Now().Add(time.Hour).Unix(), RunIfMissed: true},
+	} {
+		if err := st.Upsert(ctx, rec); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+	js.Reload(ctx)
+
+	if recs := waitForExecutions(t, st, "catch-up", 1); len(recs) != 1 {
+		t.Fatalf("catch-up executions = %+v, want one", recs)
+	}
+	if recs := waitForExecutions(t, st, "skip", 0); len(recs) != 0 {
+		t.Fatalf("skip executions = %+v, want none", recs)
+	}
+	pending, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Name != "later" {
+		t.Fatalf("pending jobs = %+v, want only later", pending)
+	}
+}
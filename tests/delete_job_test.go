@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type deletingRunner struct {
+	recordingRunner
+	deleted []string
+}
+
+func (r *deletingRunner) DeleteJob(ctx context.Context, name string) error {
+	r.deleted = append(r.deleted, name)
+	return nil
+}
+
+func TestDeleteJobByIDKeepsSchedule(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &deletingRunner{}
+	srv := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	if _, err := srv.RunJob(ctx, &proto.RunJobRequest{
+		Name: "report", Command: "buildReport", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 * * * *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	if _, err := srv.DeleteJob(ctx, &proto.DeleteJobRequest{JobId: "job-report-1"}); err != nil {
+		t.Fatalf("DeleteJob by ID: %v", err)
+	}
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(recs) != 1 || !slices.Equal(rn.deleted, []string{"job-report-1"}) {
+		t.Fatalf("schedules = %v, deleted = %v; want the schedule kept and only the run stopped", recs, rn.deleted)
+	}
+
+	for _, req := range []*proto.DeleteJobRequest{{}, {Name: "report", JobId: "job-report-1"}} {
+		if _, err := srv.DeleteJob(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("DeleteJob(%v): err = %v, want InvalidArgument", req, err)
+		}
+	}
+}
+
+// knownRunner is a deletingRunner that knows the status of one job ID.
+type knownRunner struct {
+	deletingRunner
+	known string
+}
+
+func (r *knownRunner) GetJobStatus(ctx context.Context, id string) (runner.JobStatus, error) {
+	if id != r.known {
+		return r.deletingRunner.GetJobStatus(ctx, id)
+	}
+	return runner.JobStatus{State: runner.JobStateRunning}, nil
+}
+
+func TestDeleteJobGoesToTheOwningRunner(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	primary, gpu := &deletingRunner{}, &knownRunner{known: "job-train-1"}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Runners: []cfg.RunnerConfig{{Name: "gpu", Provider: "local"}}}}
+	srv := jobsserver.NewJobsServer(primary, map[string]runner.Runner{"gpu": gpu}, c, st)
+	defer srv.Shutdown(ctx)
+
+	if _, err := srv.RunJob(ctx, &proto.RunJobRequest{
+		Name: "train", Command: "train", Runner: "gpu", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 * * * *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := srv.DeleteJob(ctx, &proto.DeleteJobRequest{JobId: "job-train-1"}); err != nil {
+		t.Fatalf("DeleteJob by ID: %v", err)
+	}
+	if _, err := srv.DeleteJob(ctx, &proto.DeleteJobRequest{Name: "train"}); err != nil {
+		t.Fatalf("DeleteJob by name: %v", err)
+	}
+	if len(primary.deleted) != 0 || !slices.Equal(gpu.deleted, []string{"job-train-1", "train"}) {
+		t.Fatalf("primary deleted %v, gpu deleted %v; want both deletes on the gpu runner", primary.deleted, gpu.deleted)
+	}
+}
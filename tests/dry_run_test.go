@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"github.com/infisical/go-sdk/packages/models"
+)
+
+func TestDryRunsPreviewWithoutRunning(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	fakeDocker(t, `echo "$1" >> "$FAKE_DOCKER_DIR/calls"`)
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	l := runner.NewLocalRunner("apollo:latest", []models.Secret{{SecretKey: "DB_PASSWORD", SecretValue: "hunter2"}})
+	js := jobsserver.NewJobsServer(l, nil, &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Cmd: "rover"}}, st)
+	defer js.Shutdown(ctx)
+
+	for _, req := range []*proto.RunJobRequest{
+		{Name: "report", Command: "build-report", DryRun: true},
+		{Name: "nightly", Command: "build-report", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 3 * * *", DryRun: true},
+	} {
+		resp, err := js.RunJob(ctx, req)
+		if err != nil {
+			t.Fatalf("RunJob %s: %v", req.GetName(), err)
+		}
+		if logs := resp.GetLogs(); !strings.HasPrefix(logs, "docker run ") || !strings.Contains(logs, "'DB_PASSWORD=<redacted>'") || !strings.HasSuffix(logs, "apollo:latest rover build-report") {
+			t.Errorf("%s: logs = %q, want the redacted docker command", req.GetName(), logs)
+		}
+	}
+	if calls, _ := os.ReadFile(filepath.Join(dir, "calls")); len(calls) != 0 {
+		t.Errorf("docker was called: %q", calls)
+	}
+	if execs, _ := st.ListExecutions(ctx, scheduler.ExecutionFilter{}); len(execs) != 0 {
+		t.Errorf("executions = %v, want none recorded", execs)
+	}
+	if recs, _ := st.List(ctx); len(recs) != 0 {
+		t.Errorf("schedules = %v, want none stored", recs)
+	}
+
+	// the runner honours DryRun itself too
+	got, err := l.RunJob(ctx, "rover", runner.JobRequest{Name: "report", Command: "build-report", DryRun: true})
+	if err != nil || !strings.HasPrefix(got, "docker run ") {
+		t.Errorf("LocalRunner.RunJob dry run = %q, %v", got, err)
+	}
+}
+
+func TestBatchRunnerDryRunReturnsTheJobSpec(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.SetSecrets([]models.Secret{{SecretKey: "DB_PASSWORD", SecretValue: "hunter2"}, {SecretKey: "API_KEY", SecretValue: "k"}})
+
+	got, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{
+		Name: "report", Command: "build-report", DryRun: true,
+		Overrides: &runner.JobOverrides{Env: []runner.EnvVar{{Name: "API_KEY", Value: "visible"}}},
+	})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if len(client.submitted) != 0 {
+		t.Fatalf("%d jobs submitted, want none", len(client.submitted))
+	}
+	// protojson varies its whitespace, so compare without it
+	compact := strings.Join(strings.Fields(got), "")
+	for _, want := range []string{`"taskGroups"`, `"/app/rover"`, `"DB_PASSWORD":"<redacted>"`, `"API_KEY":"visible"`} {
+		if !strings.Contains(compact, want) {
+			t.Errorf("spec does not contain %s:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "hunter2") {
+		t.Errorf("spec leaks a secret:\n%s", got)
+	}
+}
@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+)
+
+func TestEnvPolicyMergesGlobalAndJobLists(t *testing.T) {
+	c := &cfg.Config{Jobs: cfg.JobsConfig{
+		EnvPolicy: cfg.EnvPolicy{Deny: []string{"APOLLO_*"}},
+		Jobs: []cfg.JobConfig{{
+			Name:      "migrateJob",
+			EnvPolicy: cfg.EnvPolicy{Deny: []string{"DATABASE_URL"}, Allow: []string{"MIGRATION_*", "DATABASE_URL"}},
+		}},
+	}}
+
+	cases := []struct {
+		job, name string
+		ok        bool
+	}{
+		{"migrateJob", "MIGRATION_TARGET", true},
+		{"migrateJob", "APOLLO_EXECUTION_ID", false},
+		{"migrateJob", "DATABASE_URL", false},
+		{"migrateJob", "DEBUG", false},
+		{"handleBackupJob", "DEBUG", true},
+		{"handleBackupJob", "APOLLO_EXECUTION_ID", false},
+	}
+	for _, tc := range cases {
+		err := c.GetEnvPolicyFor(tc.job).Check(tc.name)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: Check(%s) = %v, want ok=%v", tc.job, tc.name, err, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc"
+)
+
+// chunkRecorder is the server side of an ExportExecutions stream that keeps
+// what was sent.
+type chunkRecorder struct {
+	grpc.ServerStream
+	data bytes.Buffer
+}
+
+func (c *chunkRecorder) Context() context.Context { return context.Background() }
+
+func (c *chunkRecorder) Send(chunk *proto.ExportExecutionsChunk) error {
+	c.data.Write(chunk.GetData())
+	return nil
+}
+
+func exportStore(t *testing.T, n int) scheduler.Store {
+	t.Helper()
+	st := newTestStore(t, scheduler.Options{})
+	for i := 0; i < n; i++ {
+		if err := st.AddExecution(context.Background(), scheduler.ExecutionRecord{
+			ID: fmt.Sprintf("job-sync-%04d", i), Name: "sync", Command: "sync", Status: "success",
+			Result: "rows synced", StartedAt: 1_700_000_000 + int64(i/2), FinishedAt: 1_700_000_100,
+			Labels: map[string]string{"team": "data"},
+		}); err != nil {
+			t.Fatalf("AddExecution: %v", err)
+		}
+	}
+	return st
+}
+
+func TestIterateExecutionsPagesThroughEveryRecord(t *testing.T) {
+	st := exportStore(t, 1203)
+	seen := map[string]bool{}
+	var last scheduler.ExecutionRecord
+	err := st.IterateExecutions(context.Background(), scheduler.ExecutionFilter{Name: "sync"}, func(e scheduler.ExecutionRecord) error {
+		if seen[e.ID] {
+			t.Fatalf("execution %s visited twice", e.ID)
+		}
+		if last.ID != "" && e.StartedAt > last.StartedAt {
+			t.Fatalf("%s visited after %s, want most recent first", e.ID, last.ID)
+		}
+		seen[e.ID], last = true, e
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateExecutions: %v", err)
+	}
+	if len(seen) != 1203 {
+		t.Fatalf("visited %d executions, want 1203", len(seen))
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	err = st.IterateExecutions(context.Background(), scheduler.ExecutionFilter{Until: 1_700_000_010}, func(e scheduler.ExecutionRecord) error {
+		if n++; n == 5 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || n != 5 {
+		t.Fatalf("IterateExecutions = %v after %d records, want to stop at the callback's error", err, n)
+	}
+}
+
+func TestExportExecutionsStreamsCSV(t *testing.T) {
+	st := exportStore(t, 3)
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+
+	stream := &chunkRecorder{}
+	if err := js.ExportExecutions(&proto.ExportExecutionsRequest{Name: "sync", ExcludeResult: true}, stream); err != nil {
+		t.Fatalf("ExportExecutions: %v", err)
+	}
+	rows, err := csv.NewReader(&stream.data).ReadAll()
+	if err != nil {
+		t.Fatalf("parse CSV: %v", err)
+	}
+	if len(rows) != 4 || rows[0][0] != "id" || rows[0][len(rows[0])-1] != "labels" {
+		t.Fatalf("rows = %q, want a header without result and 3 executions", rows)
+	}
+	if rows[1][0] != "job-sync-0002" || rows[1][10] != "team=data" {
+		t.Fatalf("first row = %q, want the most recent execution with its labels", rows[1])
+	}
+}
+
+func TestExportEndpointNegotiatesJSON(t *testing.T) {
+	st := exportStore(t, 2)
+	c := &cfg.Config{JobsProvider: "cloudrun", AuthAPIKeys: map[string]string{"analyst": "analyst-token"}}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	// a Cloud Scheduler token must not open the export
+	srv := httptest.NewServer(js.HTTPHandler(func(context.Context, string) error { return nil }))
+	defer srv.Close()
+
+	get := func(token, accept string) *http.Response {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/executions/export?name=sync&label=team=data", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", accept)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("GET export: %v", err)
+		}
+		return resp
+	}
+
+	resp := get("forged", "application/json")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("forged token: status %d, want 401", resp.StatusCode)
+	}
+
+	resp = get("analyst-token", "application/json")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("status %d, content type %q, want JSON", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	body, _ := io.ReadAll(resp.Body)
+	var items []struct {
+		ID     string  `json:"id"`
+		Result *string `json:"result"`
+	}
+	if err := json.Unmarshal(body, &items); err != nil {
+		t.Fatalf("parse JSON %q: %v", body, err)
+	}
+	if len(items) != 2 || items[0].Result == nil || *items[0].Result != "rows synced" {
+		t.Fatalf("items = %+v, want both executions with their results", items)
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetExecutionByID(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	resp, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", JobId: "report-1", Command: "report"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	e, err := st.GetExecution(ctx, resp.GetId())
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if e.ID != "report-1" || e.Name != "report" || e.Status != "success" || e.StartedAt == 0 || e.FinishedAt == 0 {
+		t.Errorf("execution = %+v, want report-1's successful run", e)
+	}
+	if _, err := st.GetExecution(ctx, "missing"); !errors.Is(err, scheduler.ErrExecutionNotFound) {
+		t.Errorf("GetExecution(missing) err = %v, want ErrExecutionNotFound", err)
+	}
+
+	item, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: "report-1"})
+	if err != nil || item.GetStatus() != "success" || item.GetCommand() != "report" {
+		t.Fatalf("GetExecution RPC = %v (%v), want report-1's successful run", item, err)
+	}
+	if _, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetExecution RPC of an unknown id: err = %v, want NotFound", err)
+	}
+}
+
+func TestCompressedResultsReadBackUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	ctx := context.Background()
+	result := strings.Repeat("synced 1000 rows of orders\n", 500)
+
+	for i, compress := range []bool{true, false} {
+		st, err := scheduler.OpenStore("sqlite", path, scheduler.Options{CompressResults: compress})
+		if err != nil {
+			t.Fatalf("OpenStore: %v", err)
+		}
+		id := []string{"compressed", "plain"}[i]
+		if err := st.AddExecution(ctx, scheduler.ExecutionRecord{ID: id, Name: "sync", Status: "success", Result: result, StartedAt: int64(i + 1)}); err != nil {
+			t.Fatalf("AddExecution: %v", err)
+		}
+		st.Close()
+	}
+
+	// either setting reads both rows back as written
+	for _, compress := range []bool{true, false} {
+		st, err := scheduler.OpenStore("sqlite", path, scheduler.Options{CompressResults: compress})
+		if err != nil {
+			t.Fatalf("OpenStore: %v", err)
+		}
+		recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "sync"})
+		st.Close()
+		if err != nil {
+			t.Fatalf("ListExecutions: %v", err)
+		}
+		if len(recs) != 2 {
+			t.Fatalf("listed %d executions, want 2", len(recs))
+		}
+		for _, rec := range recs {
+			if rec.Result != result {
+				t.Errorf("compress=%v: %s result is %d bytes, want the %d written", compress, rec.ID, len(rec.Result), len(result))
+			}
+		}
+	}
+}
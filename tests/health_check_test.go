@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// warmingRunner reports its job healthy part way through the run and captures
+// the execution status seen before and after.
+type warmingRunner struct {
+	recordingRunner
+	store       *scheduler.SQLStore
+	healthyAt   time.Time
+	statusSeen  []string
+	healthCheck *runner.HealthCheck
+}
+
+func (w *warmingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	w.healthCheck = req.HealthCheck
+	w.statusSeen = append(w.statusSeen, w.status(ctx, req.JobID))
+	if req.OnStarted != nil {
+		req.OnStarted(w.healthyAt)
+	}
+	w.statusSeen = append(w.statusSeen, w.status(ctx, req.JobID))
+	return "ok", nil
+}
+
+func (w *warmingRunner) status(ctx context.Context, id string) string {
+	recs, _ := w.store.ListExecutions(ctx, scheduler.ExecutionFilter{})
+	for _, r := range recs {
+		if r.ID == id {
+			return r.Status
+		}
+	}
+	return ""
+}
+
+func TestHealthCheckedRunStartsOnceHealthy(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	rn := &warmingRunner{store: st, healthyAt: time.Unix(1_700_000_000, 0)}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{
+		Name:        "warmJob",
+		HealthCheck: &cfg.HealthCheckConfig{Command: []string{"test", "-f", "/tmp/ready"}, Timeout: time.Minute},
+	}}}}
+	js := jobsserver.NewJobsServer(rn, nil, c, st)
+	ctx := context.Background()
+	defer js.Shutdown(ctx)
+
+	resp, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "warm", Command: "warmJob"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if rn.healthCheck == nil || rn.healthCheck.Timeout != time.Minute {
+		t.Fatalf("health check = %+v, want the configured one", rn.healthCheck)
+	}
+	if len(rn.statusSeen) != 2 || rn.statusSeen[0] != "starting" || rn.statusSeen[1] != "running" {
+		t.Fatalf("statuses = %v, want starting then running", rn.statusSeen)
+	}
+
+	recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(recs) != 1 || recs[0].ID != resp.GetId() || recs[0].StartedAt != rn.healthyAt.Unix() {
+		t.Fatalf("executions = %+v, want start at the healthy time", recs)
+	}
+}
@@ -0,0 +1,20 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// newTestStore opens a sqlite store in a temp dir that is closed when the
+// test ends.
+func newTestStore(t *testing.T, opts scheduler.Options) *scheduler.SQLStore {
+	t.Helper()
+	st, err := scheduler.OpenStore("sqlite", filepath.Join(t.TempDir(), "jobs.db"), opts)
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
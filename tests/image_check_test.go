@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateImagesRejectsUnknownTagBeforeLaunch(t *testing.T) {
+	registry := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && r.URL.Path == "/v2/team/app/manifests/v1" {
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer registry.Close()
+	host := strings.TrimPrefix(registry.URL, "https://")
+
+	for _, tc := range []struct {
+		image string
+		want  codes.Code
+	}{
+		{host + "/team/app:v1", codes.OK},
+		{host + "/team/app:v1-typo", codes.FailedPrecondition},
+	} {
+		client := newFakeBatchClient()
+		b := newTestBatchRunner(client)
+		b.Image = tc.image
+		b.RegistryClient = registry.Client()
+		js := jobsserver.NewJobsServer(b, nil, &cfg.Config{JobsProvider: "cloudrun", ValidateImages: true}, nil)
+
+		_, err := js.RunJob(context.Background(), &proto.RunJobRequest{Name: "export", Command: "export"})
+		if status.Code(err) != tc.want {
+			t.Fatalf("%s: RunJob err = %v, want %s", tc.image, err, tc.want)
+		}
+		if launched := len(client.createdIDs) > 0; launched != (tc.want == codes.OK) {
+			t.Fatalf("%s: launched = %v", tc.image, launched)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestJobEnvIsMergedUnderClientOverrides(t *testing.T) {
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{
+		Name: "export",
+		Env:  []cfg.EnvConfig{{Name: "LOG_LEVEL", Value: "info"}, {Name: "BUCKET", Value: "exports-prod"}},
+	}}}}
+	for _, tc := range []struct {
+		name     string
+		override []*proto.EnvVar
+		want     []runner.EnvVar
+	}{
+		{
+			name: "job env only",
+			want: []runner.EnvVar{{Name: "LOG_LEVEL", Value: "info"}, {Name: "BUCKET", Value: "exports-prod"}},
+		},
+		{
+			name:     "client override wins",
+			override: []*proto.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "DRY", Value: "1"}},
+			want:     []runner.EnvVar{{Name: "BUCKET", Value: "exports-prod"}, {Name: "LOG_LEVEL", Value: "debug"}, {Name: "DRY", Value: "1"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rn := &recordingRunner{}
+			js := jobsserver.NewJobsServer(rn, nil, c, nil)
+			req := &proto.RunJobRequest{Name: "export", Command: "export"}
+			if tc.override != nil {
+				req.Overrides = &proto.JobOverrides{Env: tc.override}
+			}
+			if _, err := js.RunJob(context.Background(), req); err != nil {
+				t.Fatalf("RunJob: %v", err)
+			}
+			if len(rn.runs) != 1 || rn.runs[0].Overrides == nil {
+				t.Fatalf("runs = %+v, want one run with env overrides", rn.runs)
+			}
+			if got := rn.runs[0].Overrides.Env; !slices.Equal(got, tc.want) {
+				t.Errorf("env = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetEnvFor(t *testing.T) {
+	c := &cfg.Config{Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{Name: "export", Env: []cfg.EnvConfig{{Name: "BUCKET", Value: "exports"}}}}}}
+	if got := c.GetEnvFor("export"); len(got) != 1 || got[0].Name != "BUCKET" {
+		t.Errorf("GetEnvFor(export) = %v, want BUCKET", got)
+	}
+	if got := c.GetEnvFor("unknown"); got != nil {
+		t.Errorf("GetEnvFor(unknown) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestValidateJobIDTemplate(t *testing.T) {
+	for _, tmpl := range []string{cfg.DefaultJobIDTemplate, "acme-{name}-{date}-{uuid}", "{name}-{unix}", "{name}-{rand}"} {
+		if err := cfg.ValidateJobIDTemplate(tmpl); err != nil {
+			t.Errorf("ValidateJobIDTemplate(%q) = %v, want valid", tmpl, err)
+		}
+	}
+	for _, tmpl := range []string{"", "job-{name}-{date}", "job-{name}-{hour}-{unix}", "Job_{name}-{rand}", "{rand}-{name}", "{name}-{uuid}-{uuid}"} {
+		if err := cfg.ValidateJobIDTemplate(tmpl); err == nil {
+			t.Errorf("ValidateJobIDTemplate(%q) succeeded, want an error", tmpl)
+		}
+	}
+}
+
+func TestRunJobGeneratesIDsFromTemplate(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC))
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{
+		JobsProvider:  "cloudrun",
+		JobIDTemplate: "acme-{name}-{date}-{uuid}",
+	}, nil, jobsserver.WithClock(clock))
+
+	resp, err := js.RunJob(context.Background(), &proto.RunJobRequest{Name: "sync", Command: "sync"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if !regexp.MustCompile(`^acme-sync-20240309-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`).MatchString(resp.GetId()) {
+		t.Fatalf("id = %q, want it formatted by the template", resp.GetId())
+	}
+
+	js = jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil, jobsserver.WithClock(clock))
+	resp, err = js.RunJob(context.Background(), &proto.RunJobRequest{Name: "sync", Command: "sync"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if resp.GetId() != "job-sync-1709985600" {
+		t.Fatalf("id = %q, want the default format", resp.GetId())
+	}
+	again, err := js.RunJob(context.Background(), &proto.RunJobRequest{Name: "sync", Command: "sync"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if again.GetId() != "job-sync-1709985600-2" {
+		t.Fatalf("id = %q for a second run in the same second, want job-sync-1709985600-2", again.GetId())
+	}
+}
@@ -0,0 +1,58 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestRunJobUsesTheJobsImage(t *testing.T) {
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{
+		Image: "apollo:latest",
+		Jobs:  []cfg.JobConfig{{Name: "report", Image: "reports:2"}, {Name: "export"}},
+	}}
+	rn := &recordingRunner{}
+	js := jobsserver.NewJobsServer(rn, nil, c, nil)
+	for _, command := range []string{"report", "export"} {
+		if _, err := js.RunJob(context.Background(), &proto.RunJobRequest{Name: command, Command: command}); err != nil {
+			t.Fatalf("RunJob(%s): %v", command, err)
+		}
+	}
+	if len(rn.runs) != 2 || rn.runs[0].Image != "reports:2" || rn.runs[1].Image != "" {
+		t.Errorf("runs = %+v, want report on reports:2 and export on the runner's image", rn.runs)
+	}
+}
+
+func TestLocalRunnerRunsTheRequestImage(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	args, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "report", Command: "report", Image: "reports:2"})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if slices.Contains(args, "apollo:latest") || !slices.Contains(args, "reports:2") {
+		t.Errorf("args = %q, want the request's image instead of the runner's", args)
+	}
+}
+
+func TestBatchRunnerRunsTheRequestImage(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	for i, tc := range []struct{ image, want string }{
+		{"reports:2", "reports:2"},
+		{"", "example/image:latest"},
+	} {
+		if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: fmt.Sprintf("report-%d", i), Command: "report", Image: tc.image}); err != nil {
+			t.Fatalf("RunJob: %v", err)
+		}
+		job := client.submitted[i]
+		if got := job.GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetContainer().GetImageUri(); got != tc.want {
+			t.Errorf("image of a run with Image %q = %q, want %q", tc.image, got, tc.want)
+		}
+	}
+}
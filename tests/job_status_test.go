@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetJobStatusReportsBatchJobState(t *testing.T) {
+	client := newFakeBatchClient()
+	created := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	name := "projects/test-project/locations/us-central1/jobs/export-1"
+	client.jobs[name] = &batchpb.Job{
+		Name:       name,
+		CreateTime: timestamppb.New(created),
+		UpdateTime: timestamppb.New(created.Add(time.Hour)),
+		Status: &batchpb.JobStatus{
+			State: batchpb.JobStatus_FAILED,
+			StatusEvents: []*batchpb.StatusEvent{{
+				TaskExecution: &batchpb.TaskExecution{ExitCode: 3},
+			}},
+		},
+	}
+	js := jobsserver.NewJobsServer(newTestBatchRunner(client), nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+
+	resp, err := js.GetJobStatus(context.Background(), &proto.GetJobStatusRequest{Name: "export-1"})
+	if err != nil {
+		t.Fatalf("GetJobStatus: %v", err)
+	}
+	if resp.GetState() != proto.JobState_JOB_STATE_FAILED || resp.GetExitCode() != 3 ||
+		resp.GetStartedAt() != created.Unix() || resp.GetFinishedAt() != created.Add(time.Hour).Unix() {
+		t.Fatalf("status = %+v", resp)
+	}
+
+	if _, err := js.GetJobStatus(context.Background(), &proto.GetJobStatusRequest{Name: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown job: err = %v, want NotFound", err)
+	}
+}
+
+func TestLocalRunnerTracksJobsItStarted(t *testing.T) {
+	l := runner.NewLocalRunner("apollo-test-image-that-does-not-exist:latest", nil)
+	ctx := context.Background()
+
+	// either docker is missing or the image is, so the run fails
+	if _, err := l.RunJob(ctx, "rover", runner.JobRequest{Name: "apollo-status-test", JobID: "status-test-1", Command: "noop"}); err == nil {
+		t.Fatal("RunJob succeeded without an image")
+	}
+	st, err := l.GetJobStatus(ctx, "status-test-1")
+	if err != nil {
+		t.Fatalf("GetJobStatus: %v", err)
+	}
+	if st.State != runner.JobStateFailed || st.FinishedAt.IsZero() {
+		t.Fatalf("status = %+v, want a finished failed job", st)
+	}
+}
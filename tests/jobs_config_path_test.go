@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+)
+
+func TestLoadReadsJobsConfigPath(t *testing.T) {
+	dir := writeJobsFiles(t, map[string]string{
+		"jobs.yml":  "image: apollo:yml\njobs:\n  - name: report\n    timeout: 30m\n",
+		"jobs.yaml": "image: apollo:yaml\n",
+		"jobs.json": `{"image": "apollo:json", "jobs": [{"name": "report", "timeout": "30m", "success_exit_codes": [0, 3]}]}`,
+	})
+	for file, image := range map[string]string{"jobs.yml": "apollo:yml", "jobs.yaml": "apollo:yaml", "jobs.json": "apollo:json"} {
+		t.Run(file, func(t *testing.T) {
+			t.Setenv("JOBS_CONFIG_PATH", filepath.Join(dir, file))
+			c, err := cfg.Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if c.Jobs.Image != image {
+				t.Errorf("image = %q, want %q", c.Jobs.Image, image)
+			}
+		})
+	}
+
+	jobs, err := cfg.LoadJobsFile(filepath.Join(dir, "jobs.json"))
+	if err != nil {
+		t.Fatalf("LoadJobsFile: %v", err)
+	}
+	job, ok := (&cfg.Config{Jobs: *jobs}).GetJobConfig("report")
+	if !ok || job.Timeout != 30*time.Minute || len(job.SuccessExitCodes) != 2 {
+		t.Errorf("JSON job = %+v, want the same keys and durations as jobs.yml", job)
+	}
+}
+
+func TestLoadFailsOnABadJobsConfigPath(t *testing.T) {
+	dir := writeJobsFiles(t, map[string]string{
+		"broken.json": `{"image": "apollo:json",}`,
+		"broken.yml":  "jobs: [\n",
+		"jobs.toml":   "image = 'apollo'\n",
+	})
+	for _, tc := range []struct{ file, want string }{
+		{"missing.yml", "no such file"},
+		{"broken.json", "invalid character"},
+		{"broken.yml", "broken.yml"},
+		{"jobs.toml", "unsupported config file type"},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			t.Setenv("JOBS_CONFIG_PATH", filepath.Join(dir, tc.file))
+			_, err := cfg.Load()
+			if err == nil || !strings.Contains(err.Error(), "JOBS_CONFIG_PATH") || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Load error = %v, want a JOBS_CONFIG_PATH error mentioning %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsBothJobsConfigPathAndDir(t *testing.T) {
+	dir := writeJobsFiles(t, map[string]string{"jobs.yml": "image: apollo\n"})
+	t.Setenv("JOBS_CONFIG_PATH", filepath.Join(dir, "jobs.yml"))
+	t.Setenv("JOBS_CONFIG_DIR", dir)
+	if _, err := cfg.Load(); err == nil {
+		t.Fatal("Load accepted both JOBS_CONFIG_PATH and JOBS_CONFIG_DIR")
+	}
+}
+
+func TestLoadWithoutJobsConfigFallsBackToEmpty(t *testing.T) {
+	// the tests directory has no jobs.yml, and the implicit lookup is lenient
+	t.Setenv("JOBS_IMAGE", "apollo:env")
+	c, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Jobs.Image != "apollo:env" || len(c.Jobs.Jobs) != 0 {
+		t.Errorf("jobs config = %+v, want an empty one with the image from JOBS_IMAGE", c.Jobs)
+	}
+}
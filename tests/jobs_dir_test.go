@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+)
+
+func writeJobsFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestLoadJobsDirMergesFiles(t *testing.T) {
+	dir := writeJobsFiles(t, map[string]string{
+		"a.yml": "cmd: apollo-job\njobs:\n  - name: report\n    resources: {cpu: \"1\", memory: 1Gi}\n",
+		"b.yml": "image: gcr.io/p/jobs\njobs:\n  - name: cleanup\nrunners:\n  - name: gpu\n    provider: cloudrun\n",
+		"c.txt": "jobs: [{name: ignored}]\n",
+	})
+	jobs, err := cfg.LoadJobsDir(dir, false)
+	if err != nil {
+		t.Fatalf("LoadJobsDir: %v", err)
+	}
+	if jobs.Cmd != "apollo-job" || jobs.Image != "gcr.io/p/jobs" {
+		t.Fatalf("cmd, image = %q, %q", jobs.Cmd, jobs.Image)
+	}
+	if len(jobs.Jobs) != 2 || jobs.Jobs[0].Name != "report" || jobs.Jobs[1].Name != "cleanup" {
+		t.Fatalf("jobs = %+v", jobs.Jobs)
+	}
+	if len(jobs.Runners) != 1 || jobs.Runners[0].Name != "gpu" {
+		t.Fatalf("runners = %+v", jobs.Runners)
+	}
+}
+
+func TestLoadJobsDirMergesSecretPrefixes(t *testing.T) {
+	dir := writeJobsFiles(t, map[string]string{
+		"a.yml": "secret_prefixes:\n  production: [PROD_, LIVE_]\n",
+		"b.yml": "secret_prefixes:\n  production: [LIVE_, EU_PROD_]\n  staging: [STAGING_]\n",
+	})
+	jobs, err := cfg.LoadJobsDir(dir, false)
+	if err != nil {
+		t.Fatalf("LoadJobsDir: %v", err)
+	}
+	if got := jobs.SecretPrefixes["production"]; !slices.Equal(got, []string{"PROD_", "LIVE_", "EU_PROD_"}) {
+		t.Fatalf("production prefixes = %v, want both files' in order without duplicates", got)
+	}
+	if got := jobs.SecretPrefixes["staging"]; !slices.Equal(got, []string{"STAGING_"}) {
+		t.Fatalf("staging prefixes = %v", got)
+	}
+}
+
+func TestLoadJobsDirDuplicateNames(t *testing.T) {
+	dir := writeJobsFiles(t, map[string]string{
+		"a.yml": "jobs:\n  - name: report\n    resources: {cpu: \"1\"}\n  - name: cleanup\n",
+		"b.yml": "jobs:\n  - name: report\n    resources: {cpu: \"2\"}\n",
+	})
+	_, err := cfg.LoadJobsDir(dir, false)
+	if err == nil || !strings.Contains(err.Error(), `job "report"`) {
+		t.Fatalf("err = %v, want duplicate job error", err)
+	}
+
+	jobs, err := cfg.LoadJobsDir(dir, true)
+	if err != nil {
+		t.Fatalf("LoadJobsDir with override: %v", err)
+	}
+	if len(jobs.Jobs) != 2 || jobs.Jobs[0].Name != "report" || jobs.Jobs[0].Resources.CPU != "2" {
+		t.Fatalf("jobs = %+v, want report overridden by b.yml", jobs.Jobs)
+	}
+}
+
+func TestLoadReadsJobsConfigDir(t *testing.T) {
+	t.Setenv("JOBS_CONFIG_DIR", writeJobsFiles(t, map[string]string{
+		"a.yml": "jobs:\n  - name: report\n",
+		"b.yml": "jobs:\n  - name: report\n",
+	}))
+	if _, err := cfg.Load(); err == nil {
+		t.Fatal("Load accepted duplicate job names")
+	}
+
+	t.Setenv("JOBS_CONFIG_ALLOW_OVERRIDE", "true")
+	c, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, ok := c.GetJobConfig("report"); !ok || len(c.Jobs.Jobs) != 1 {
+		t.Fatalf("jobs = %+v", c.Jobs.Jobs)
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadDefaultLabels(t *testing.T) {
+	t.Setenv("DEFAULT_LABELS", "cluster=eu-1, environment=prod,region=europe-west1")
+	c, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(c.DefaultLabels) != 3 || c.DefaultLabels["environment"] != "prod" || c.DefaultLabels["region"] != "europe-west1" {
+		t.Fatalf("default labels = %v", c.DefaultLabels)
+	}
+
+	t.Setenv("DEFAULT_LABELS", "cluster")
+	if _, err := cfg.Load(); err == nil {
+		t.Fatal("Load accepted a label without a value")
+	}
+}
+
+func TestExecutionsCarryDefaultLabels(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{
+		JobsProvider:  "cloudrun",
+		DefaultLabels: map[string]string{"cluster": "eu-1", "environment": "prod"},
+	}, st)
+	defer js.Shutdown(ctx)
+
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "a", JobId: "a-1", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "b", JobId: "b-1", Command: "sync", Labels: map[string]string{"environment": "staging", "team": "data"}}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	exec, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: "b-1"})
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if l := exec.GetLabels(); len(l) != 3 || l["cluster"] != "eu-1" || l["environment"] != "staging" || l["team"] != "data" {
+		t.Fatalf("labels = %v, want request labels over the defaults", l)
+	}
+
+	for _, tc := range []struct {
+		labels map[string]string
+		want   []string
+	}{
+		{map[string]string{"cluster": "eu-1"}, []string{"a-1", "b-1"}},
+		{map[string]string{"cluster": "eu-1", "environment": "prod"}, []string{"a-1"}},
+		{map[string]string{"team": "ml"}, nil},
+	} {
+		resp, err := js.ListExecutions(ctx, &proto.ListExecutionsRequest{Labels: tc.labels})
+		if err != nil {
+			t.Fatalf("ListExecutions: %v", err)
+		}
+		var got []string
+		for _, item := range resp.GetItems() {
+			got = append(got, item.GetId())
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("executions labelled %v = %v, want %v", tc.labels, got, tc.want)
+		}
+	}
+}
+
+func TestListExecutionsLoadsLabelsInBatchesAndCapsFilters(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+
+	const n = 1200
+	for i := range n {
+		err := st.AddExecution(ctx, scheduler.ExecutionRecord{
+			ID: fmt.Sprintf("run-%04d", i), Name: "sync", Status: "success", StartedAt: int64(i),
+			Labels: map[string]string{"shard": fmt.Sprint(i % 7)},
+		})
+		if err != nil {
+			t.Fatalf("AddExecution: %v", err)
+		}
+	}
+	resp, err := js.ListExecutions(ctx, &proto.ListExecutionsRequest{Name: "sync"})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(resp.GetItems()) != n {
+		t.Fatalf("listed %d executions, want %d", len(resp.GetItems()), n)
+	}
+	for _, item := range resp.GetItems() {
+		var i int
+		fmt.Sscanf(item.GetId(), "run-%d", &i)
+		if item.GetLabels()["shard"] != fmt.Sprint(i%7) {
+			t.Fatalf("%s labels = %v", item.GetId(), item.GetLabels())
+		}
+	}
+
+	labels := map[string]string{}
+	for i := range scheduler.MaxFilters + 1 {
+		labels[fmt.Sprintf("k%d", i)] = "v"
+	}
+	_, err = js.ListExecutions(ctx, &proto.ListExecutionsRequest{Labels: labels})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("ListExecutions with %d label filters: err = %v, want InvalidArgument", len(labels), err)
+	}
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+func TestLeaseIsHeldByOneReplica(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	a, err := scheduler.OpenStore("sqlite", path, scheduler.Options{})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	defer a.Close()
+	b, err := scheduler.OpenStore("sqlite", path, scheduler.Options{})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	defer b.Close()
+	ctx := context.Background()
+
+	if ok, err := a.AcquireLease(ctx, "backup@60", "replica-a", time.Hour); err != nil || !ok {
+		t.Fatalf("replica a: ok=%v err=%v, want lease", ok, err)
+	}
+	if ok, err := b.AcquireLease(ctx, "backup@60", "replica-b", time.Hour); err != nil || ok {
+		t.Fatalf("replica b: ok=%v err=%v, want lease held by a", ok, err)
+	}
+	if ok, err := b.AcquireLease(ctx, "backup@120", "replica-b", time.Hour); err != nil || !ok {
+		t.Fatalf("replica b next tick: ok=%v err=%v, want lease", ok, err)
+	}
+}
+
+func TestStorePersistsSingletonFlag(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+
+	if err := st.Upsert(ctx, scheduler.JobRecord{Name: "backup", Command: "handleBackupJob", CronSpec: "0 * * * * *", Singleton: true}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(recs) != 1 || !recs[0].Singleton {
+		t.Fatalf("List = %+v, want singleton backup", recs)
+	}
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListJobsReportsBatchJobs(t *testing.T) {
+	client := newFakeBatchClient()
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client.jobs["projects/test-project/locations/us-central1/jobs/job-report-1"] = &batchpb.Job{
+		Name:       "projects/test-project/locations/us-central1/jobs/job-report-1",
+		Status:     &batchpb.JobStatus{State: batchpb.JobStatus_RUNNING},
+		CreateTime: timestamppb.New(created),
+	}
+	client.jobs["projects/test-project/locations/us-central1/jobs/manual-backfill"] = &batchpb.Job{
+		Name:   "projects/test-project/locations/us-central1/jobs/manual-backfill",
+		Status: &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED},
+	}
+	client.jobs["projects/test-project/locations/europe-west1/jobs/elsewhere"] = &batchpb.Job{
+		Name: "projects/test-project/locations/europe-west1/jobs/elsewhere",
+	}
+	srv := jobsserver.NewJobsServer(newTestBatchRunner(client), nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+
+	resp, err := srv.ListJobs(context.Background(), &proto.ListJobsRequest{})
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	items := resp.GetItems()
+	if len(items) != 2 {
+		t.Fatalf("items = %v, want the two jobs in the runner's region", items)
+	}
+	if items[0].GetName() != "job-report-1" || items[0].GetState() != proto.JobState_JOB_STATE_RUNNING || items[0].GetCreatedAt() != created.Unix() {
+		t.Errorf("items[0] = %v", items[0])
+	}
+	if items[1].GetName() != "manual-backfill" || items[1].GetState() != proto.JobState_JOB_STATE_SUCCEEDED {
+		t.Errorf("items[1] = %v", items[1])
+	}
+}
+
+func TestLocalRunnerListJobsReadsLabelledContainers(t *testing.T) {
+	fakeDocker(t, `[ "$1 $4" = "ps label=apollo.job-id" ] || exit 1
+echo 'job-report-1|2026-03-01 12:00:00 +0000 UTC'
+echo 'job-sync-7|2026-03-01 12:05:30 +0000 UTC'
+`)
+	jobs, err := runner.NewLocalRunner("apollo:latest", nil).ListJobs(context.Background())
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	want := []runner.JobInfo{
+		{Name: "job-report-1", State: runner.JobStateRunning, CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{Name: "job-sync-7", State: runner.JobStateRunning, CreatedAt: time.Date(2026, 3, 1, 12, 5, 30, 0, time.UTC)},
+	}
+	if len(jobs) != len(want) {
+		t.Fatalf("jobs = %v, want %v", jobs, want)
+	}
+	for i := range want {
+		if jobs[i].Name != want[i].Name || jobs[i].State != want[i].State || !jobs[i].CreatedAt.Equal(want[i].CreatedAt) {
+			t.Errorf("jobs[%d] = %+v, want %+v", i, jobs[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,551 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/infisical/go-sdk/packages/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLocalRunnerRenderCommandRedactsSecrets(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", []models.Secret{{SecretKey: "DB_PASSWORD", SecretValue: "hunter2"}})
+	req := runner.JobRequest{
+		Name:      "report",
+		Command:   "build-report",
+		Resources: runner.Resources{CPU: "1", Memory: "512m"},
+		Overrides: &runner.JobOverrides{Env: []runner.EnvVar{{Name: "GREETING", Value: "hello world"}}},
+	}
+
+	got, err := l.RenderCommand(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("RenderCommand: %v", err)
+	}
+	want := "docker run --rm --name apollo-report --label apollo.job-id=report -e 'DB_PASSWORD=<redacted>' -e 'GREETING=hello world' --memory 512m --cpus 1 -e APOLLO_CPU_LIMIT=1 -e APOLLO_MEMORY_LIMIT=512 apollo:latest rover build-report"
+	if got != want {
+		t.Fatalf("RenderCommand:\n got  %s\n want %s", got, want)
+	}
+}
+
+func TestLocalRunnerPlacesResourceFlagsBeforeImage(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	args, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{
+		Name:           "report",
+		Command:        "build-report",
+		ArgsJSONBase64: "e30=",
+		Resources:      runner.Resources{CPU: "1", Memory: "512m"},
+		Overrides:      &runner.JobOverrides{Args: []string{"--verbose"}},
+	})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	image := slices.Index(args, "apollo:latest")
+	memory := slices.Index(args, "--memory")
+	cpus := slices.Index(args, "--cpus")
+	if image < 0 || memory < 0 || cpus < 0 || memory > image || cpus > image {
+		t.Fatalf("args = %q, want --memory and --cpus before the image", args)
+	}
+	if got := args[image:]; !slices.Equal(got, []string{"apollo:latest", "rover", "build-report", "e30=", "--verbose"}) {
+		t.Fatalf("container argv = %q", got)
+	}
+}
+
+func TestLocalRunnerTellsJobsTheirLimits(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	for _, tc := range []struct {
+		res         runner.Resources
+		cpu, memory string
+	}{
+		{runner.Resources{CPU: "1.5", Memory: "2g"}, "1.5", "2048"},
+		{runner.Resources{CPU: "250m", Memory: "256Mi"}, "0.25", "256"},
+		{runner.Resources{CPU: "", Memory: ""}, "", ""},
+	} {
+		args, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{
+			Name:      "report",
+			Command:   "build-report",
+			Resources: runner.Resources{CPU: "1", Memory: "512m"},
+			Overrides: &runner.JobOverrides{Resources: &tc.res},
+		})
+		if err != nil {
+			t.Fatalf("BuildArgs: %v", err)
+		}
+		env := map[string]string{}
+		for i, arg := range args {
+			if i > 0 && args[i-1] == "-e" {
+				k, v, _ := strings.Cut(arg, "=")
+				env[k] = v
+			}
+		}
+		if env["APOLLO_CPU_LIMIT"] != tc.cpu || env["APOLLO_MEMORY_LIMIT"] != tc.memory {
+			t.Errorf("resources %+v: limits = %q cores, %q MiB, want %q, %q", tc.res, env["APOLLO_CPU_LIMIT"], env["APOLLO_MEMORY_LIMIT"], tc.cpu, tc.memory)
+		}
+	}
+}
+
+func TestLocalRunnerRejectsPercentResources(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	for _, res := range []runner.Resources{{CPU: "50%", Memory: "512m"}, {CPU: "1", Memory: "25%"}} {
+		_, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{
+			Name:      "report",
+			Command:   "build-report",
+			Resources: res,
+		})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "Batch") {
+			t.Errorf("resources %+v: BuildArgs error = %v, want InvalidArgument pointing at the Batch runner", res, err)
+		}
+	}
+}
+
+func TestLocalRunnerSwapsSecretsForLaterJobs(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", []models.Secret{{SecretKey: "DB_PASSWORD", SecretValue: "old"}})
+	var _ runner.SecretSwapper = l
+	req := runner.JobRequest{Name: "report", Command: "build-report"}
+
+	before, err := l.BuildArgs(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	l.SetSecrets([]models.Secret{{SecretKey: "DB_PASSWORD", SecretValue: "new"}})
+	after, err := l.BuildArgs(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if !slices.Contains(before, "DB_PASSWORD=old") || !slices.Contains(after, "DB_PASSWORD=new") || slices.Contains(after, "DB_PASSWORD=old") {
+		t.Fatalf("args before swap = %q, after = %q", before, after)
+	}
+}
+
+func TestLocalRunnerHardensContainers(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	l.Security = &runner.Security{CapDrop: []string{"ALL"}, CapAdd: []string{"cap_net_bind_service"}, NoNewPrivileges: true}
+	args, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "untrusted", Command: "sandbox"})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	image := slices.Index(args, "apollo:latest")
+	want := []string{"--cap-drop", "ALL", "--cap-add", "NET_BIND_SERVICE", "--security-opt", "no-new-privileges"}
+	if got := strings.Join(args[:image], " "); !strings.HasSuffix(got, strings.Join(want, " ")) {
+		t.Fatalf("args = %q, want %q before the image", args, want)
+	}
+
+	// a request's own security replaces the runner's
+	args, err = l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "trusted", Command: "sync", Security: &runner.Security{}})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if slices.Contains(args, "--cap-drop") || slices.Contains(args, "--security-opt") {
+		t.Fatalf("args = %q, want the runner's security replaced", args)
+	}
+
+	_, err = l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "bad", Command: "sync", Security: &runner.Security{CapAdd: []string{"GOD_MODE"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("BuildArgs with an unknown capability: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestLocalRunnerDeleteJobStopsRunningContainer(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	// run records its pid and sleeps like a long job; stop ends it
+	fakeDocker(t, `case "$1" in
+run) echo $$ > "$FAKE_DOCKER_DIR/pid"; exec sleep 30 ;;
+stop) echo "$2" >> "$FAKE_DOCKER_DIR/stopped"; kill "$(cat "$FAKE_DOCKER_DIR/pid")" ;;
+esac
+`)
+	l := runner.NewLocalRunner("apollo:latest", nil)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "backfill", JobID: "job-backfill-1", Command: "backfill"})
+		done <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "pid")); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("container never started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := l.DeleteJob(context.Background(), "backfill"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("RunJob succeeded, want the killed run to fail")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("DeleteJob did not stop the running container")
+	}
+	stopped, _ := os.ReadFile(filepath.Join(dir, "stopped"))
+	if strings.TrimSpace(string(stopped)) != "apollo-job-backfill-1" {
+		t.Fatalf("stopped containers = %q, want apollo-job-backfill-1", stopped)
+	}
+}
+
+func TestLocalRunnerEnforcesTimeout(t *testing.T) {
+	fakeDocker(t, "case \"$1\" in run) exec sleep 30 ;; esac\n")
+	l := runner.NewLocalRunner("apollo:latest", nil)
+
+	start := time.Now()
+	_, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "slow", Command: "sync", Timeout: 200 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RunJob err = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("RunJob returned after %s, want the timeout to stop it", elapsed)
+	}
+}
+
+func TestLocalRunnerRetriesTransientDockerErrors(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	// the first run finds the daemon unreachable, the second succeeds
+	fakeDocker(t, `echo run >> "$FAKE_DOCKER_DIR/runs"
+if [ "$(wc -l < "$FAKE_DOCKER_DIR/runs")" -eq 1 ]; then
+  echo "docker: Cannot connect to the Docker daemon at unix:///var/run/docker.sock." >&2
+  exit 125
+fi
+echo synced
+`)
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	l.TransientRetryDelay = time.Millisecond
+
+	result, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if result != "synced\n" {
+		t.Fatalf("result = %q, want the retried run's output", result)
+	}
+}
+
+func TestLocalRunnerDoesNotRetryContainerExit(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	fakeDocker(t, `echo run >> "$FAKE_DOCKER_DIR/runs"
+echo "connection refused" >&2
+exit 1
+`)
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	l.TransientRetryDelay = time.Millisecond
+
+	_, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"})
+	if code, ok := runner.ExitCode(err); !ok || code != 1 {
+		t.Fatalf("RunJob err = %v, want the container's exit code 1", err)
+	}
+	runs, _ := os.ReadFile(filepath.Join(dir, "runs"))
+	if n := strings.Count(string(runs), "run"); n != 1 {
+		t.Fatalf("docker run invoked %d times, want no retry", n)
+	}
+}
+
+func TestLocalRunnerSetsTheStopSignal(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	args, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "drain", Command: "drain"})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if slices.Contains(args, "--stop-signal") {
+		t.Fatalf("args = %q, want the image's stop signal kept by default", args)
+	}
+
+	l.StopSignal, l.StopTimeout = "SIGINT", 45*time.Second
+	args, err = l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "drain", Command: "drain", StopSignal: "usr1"})
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	image := slices.Index(args, "apollo:latest")
+	if want := "--stop-signal SIGUSR1 --stop-timeout 45"; !strings.HasSuffix(strings.Join(args[:image], " "), want) {
+		t.Fatalf("args = %q, want %q before the image", args, want)
+	}
+	args, _ = l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "drain", Command: "drain"})
+	if !strings.Contains(strings.Join(args, " "), "--stop-signal SIGINT") {
+		t.Fatalf("args = %q, want the runner's stop signal", args)
+	}
+
+	_, err = l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "drain", Command: "drain", StopSignal: "SIGDRAIN"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("BuildArgs with an unknown signal: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestLocalRunnerLogsInToItsRegistryOnce(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	// login records its arguments and stdin and rejects "wrong"; run records
+	// that it ran
+	fakeDocker(t, `case "$1" in
+login) echo "$@" >> "$FAKE_DOCKER_DIR/logins"; pw=$(cat); echo "$pw" >> "$FAKE_DOCKER_DIR/passwords"
+  if [ "$pw" = wrong ]; then echo "login with $pw: unauthorized" >&2; exit 1; fi ;;
+run) echo run >> "$FAKE_DOCKER_DIR/runs" ;;
+esac
+`)
+	read := func(name string) []string {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		return strings.Fields(strings.ReplaceAll(string(data), " ", "_"))
+	}
+	l := runner.NewLocalRunner("ghcr.io/synehq/apollo:latest", nil)
+	l.RegistryAuth = &runner.RegistryAuth{Username: "bot", Password: "wrong"}
+
+	_, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"})
+	if err == nil || strings.Contains(err.Error(), "wrong") || !strings.Contains(err.Error(), "<redacted>") {
+		t.Fatalf("RunJob with bad credentials: err = %v, want a login error with the password masked", err)
+	}
+	if runs := read("runs"); len(runs) != 0 {
+		t.Fatalf("runs = %q, want none after a failed login", runs)
+	}
+
+	l.RegistryAuth.Password = "s3cret"
+	for range 2 {
+		if _, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"}); err != nil {
+			t.Fatalf("RunJob: %v", err)
+		}
+	}
+	if logins := read("logins"); !slices.Equal(logins, []string{"login_--username_bot_--password-stdin_ghcr.io", "login_--username_bot_--password-stdin_ghcr.io"}) {
+		t.Fatalf("logins = %q, want the failed one and one more for the image's registry", logins)
+	}
+	if passwords := read("passwords"); !slices.Equal(passwords, []string{"wrong", "s3cret"}) {
+		t.Fatalf("passwords = %q, want them passed on stdin", passwords)
+	}
+	if runs := read("runs"); len(runs) != 2 {
+		t.Fatalf("runs = %q, want both runs", runs)
+	}
+}
+
+func TestLocalRunnerLogsInPerRegistryAndAgainWhenRefused(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	// the first run is refused by the registry, as after the login expired
+	fakeDocker(t, `case "$1" in
+login) cat > /dev/null; echo "$@" >> "$FAKE_DOCKER_DIR/logins" ;;
+run) if [ ! -e "$FAKE_DOCKER_DIR/refused" ]; then touch "$FAKE_DOCKER_DIR/refused"
+    echo "Error response from daemon: unauthorized: authentication required" >&2; exit 125; fi
+  echo run >> "$FAKE_DOCKER_DIR/runs" ;;
+esac
+`)
+	read := func(name string) []string {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		return strings.Fields(strings.ReplaceAll(string(data), " ", "_"))
+	}
+	l := runner.NewLocalRunner("ghcr.io/synehq/apollo:latest", nil)
+	l.RegistryAuth = &runner.RegistryAuth{Username: "bot", Password: "s3cret"}
+
+	if _, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob refused once: %v", err)
+	}
+	if _, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync", Image: "registry.example.com/etl:1"}); err != nil {
+		t.Fatalf("RunJob with another registry's image: %v", err)
+	}
+	want := []string{
+		"login_--username_bot_--password-stdin_ghcr.io",
+		"login_--username_bot_--password-stdin_ghcr.io",
+		"login_--username_bot_--password-stdin_registry.example.com",
+	}
+	if logins := read("logins"); !slices.Equal(logins, want) {
+		t.Fatalf("logins = %q, want %q", logins, want)
+	}
+	if runs := read("runs"); len(runs) != 2 {
+		t.Fatalf("runs = %q, want both runs", runs)
+	}
+
+	// credentials for one registry are not given to another
+	l.RegistryAuth.Server = "ghcr.io"
+	if _, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync", Image: "quay.io/etl:1"}); err != nil {
+		t.Fatalf("RunJob with an image of an unrelated registry: %v", err)
+	}
+	if logins := read("logins"); len(logins) != len(want) {
+		t.Fatalf("logins = %q, want none to quay.io", logins)
+	}
+}
+
+func TestLocalRunnerPullPolicy(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	fakeDocker(t, `[ "$1" = run ] && echo "$@" > "$FAKE_DOCKER_DIR/args"; exit 0`)
+	for _, tc := range []struct {
+		policy string
+		pull   string // the --pull value, "" for none
+	}{
+		{runner.PullIfNotPresent, ""},
+		{runner.PullAlways, "always"},
+		{runner.PullNever, "never"},
+	} {
+		l := runner.NewLocalRunner("apollo:latest", nil)
+		l.PullPolicy = tc.policy
+		if _, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"}); err != nil {
+			t.Fatalf("%s: RunJob: %v", tc.policy, err)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "args"))
+		args := strings.Fields(string(data))
+		image := slices.Index(args, "apollo:latest")
+		pull := slices.Index(args, "--pull")
+		switch {
+		case tc.pull == "" && pull >= 0:
+			t.Errorf("%s: args = %q, want docker's default pull", tc.policy, args)
+		case tc.pull != "" && (pull < 0 || pull > image || args[pull+1] != tc.pull):
+			t.Errorf("%s: args = %q, want --pull %s before the image", tc.policy, args, tc.pull)
+		}
+	}
+
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	l.PullPolicy = "sometimes"
+	if _, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("RunJob with an unknown pull policy: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestLocalRunnerMountsVolumes(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	req := runner.JobRequest{Name: "import", Command: "import", Overrides: &runner.JobOverrides{Volumes: []runner.VolumeMount{
+		{HostPath: "/srv/input/", ContainerPath: "/data/in", ReadOnly: true},
+		{HostPath: "/tmp/out", ContainerPath: "/data/out"},
+	}}}
+	args, err := l.BuildArgs(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	image := slices.Index(args, "apollo:latest")
+	joined := strings.Join(args[:image], " ")
+	if !strings.Contains(joined, "-v /srv/input:/data/in:ro -v /tmp/out:/data/out") {
+		t.Fatalf("args = %q, want both volumes before the image", args)
+	}
+
+	for _, v := range []runner.VolumeMount{
+		{HostPath: "input", ContainerPath: "/data/in"},
+		{HostPath: "/srv/input", ContainerPath: "data"},
+		{HostPath: "/srv/a:b", ContainerPath: "/data"},
+	} {
+		req.Overrides.Volumes = []runner.VolumeMount{v}
+		if _, err := l.BuildArgs(context.Background(), "rover", req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("BuildArgs with volume %+v: err = %v, want InvalidArgument", v, err)
+		}
+	}
+}
+
+func TestLocalRunnerJoinsTheConfiguredNetwork(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	req := runner.JobRequest{Name: "analytics", Command: "analytics"}
+	args, err := l.BuildArgs(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if slices.Contains(args, "--network") {
+		t.Fatalf("args = %q, want no --network by default", args)
+	}
+
+	l.Network = "host"
+	args, err = l.BuildArgs(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if i := slices.Index(args, "--network"); i < 0 || args[i+1] != "host" || i > slices.Index(args, "apollo:latest") {
+		t.Fatalf("args = %q, want --network host before the image", args)
+	}
+
+	req.Overrides = &runner.JobOverrides{Network: "analytics_default"}
+	if _, err := l.BuildArgs(context.Background(), "rover", req); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("BuildArgs with a network not allowed: err = %v, want PermissionDenied", err)
+	}
+	l.AllowedNetworks = []string{"analytics_default"}
+	args, _ = l.BuildArgs(context.Background(), "rover", req)
+	if i := slices.Index(args, "--network"); i < 0 || args[i+1] != "analytics_default" {
+		t.Fatalf("args = %q, want the request's network", args)
+	}
+
+	req.Overrides.Network = "--privileged"
+	if _, err := l.BuildArgs(context.Background(), "rover", req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("BuildArgs with a flag as network: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRunnersCheckEnvNames(t *testing.T) {
+	var logs strings.Builder
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	l := runner.NewLocalRunner("apollo:latest", []models.Secret{{SecretKey: "DB_PASSWORD", SecretValue: "hunter2"}})
+	req := runner.JobRequest{Name: "report", Command: "report", Overrides: &runner.JobOverrides{Env: []runner.EnvVar{{Name: "DB_PASSWORD", Value: "local"}}}}
+	args, err := l.BuildArgs(context.Background(), "rover", req)
+	if err != nil {
+		t.Fatalf("BuildArgs: %v", err)
+	}
+	if slices.Index(args, "DB_PASSWORD=local") < slices.Index(args, "DB_PASSWORD=hunter2") {
+		t.Fatalf("args = %q, want the override after the secret", args)
+	}
+	if !strings.Contains(logs.String(), "override of DB_PASSWORD replaces the secret") || strings.Contains(logs.String(), "local") {
+		t.Fatalf("logs = %q, want the replaced secret named without values", logs.String())
+	}
+
+	l.SetSecrets([]models.Secret{{SecretKey: "db-password", SecretValue: "hunter2"}})
+	req.Overrides.Env = []runner.EnvVar{{Name: "1ST", Value: "x"}, {Name: "", Value: "y"}, {Name: "OK_NAME", Value: "z"}}
+	_, err = l.BuildArgs(context.Background(), "rover", req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("BuildArgs with bad names: err = %v, want InvalidArgument", err)
+	}
+	for _, want := range []string{`secret "db-password"`, `override "1ST"`, `override ""`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err = %v, want it to list %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "OK_NAME") {
+		t.Errorf("err = %v, want valid names left out", err)
+	}
+
+	b := newTestBatchRunner(newFakeBatchClient())
+	if _, err := b.RunJob(context.Background(), "/app/rover", runner.JobRequest{Name: "report", Command: "report",
+		Overrides: &runner.JobOverrides{Env: []runner.EnvVar{{Name: "MY-VAR", Value: "x"}}}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Batch RunJob with a bad name: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestLocalRunnerUsesTheConfiguredRuntime(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FAKE_DOCKER_DIR", dir)
+	fakeDocker(t, `echo "docker $1" >> "$FAKE_DOCKER_DIR/calls"`)
+	// podman answers rm of a missing container in lower case
+	bin := t.TempDir()
+	podman := `#!/bin/sh
+echo "podman $1" >> "$FAKE_DOCKER_DIR/calls"
+case "$1" in
+run) echo "ran with podman" ;;
+rm) echo 'Error: no container with name or ID "x" found: no such container' >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(filepath.Join(bin, "podman"), []byte(podman), 0o755); err != nil {
+		t.Fatalf("write fake podman: %v", err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	l.Runtime = "podman"
+	req := runner.JobRequest{Name: "sync", Command: "sync", Resources: runner.Resources{CPU: "1", Memory: "512m"}}
+	out, err := l.RunJob(context.Background(), "rover", req)
+	if err != nil || !strings.Contains(out, "ran with podman") {
+		t.Fatalf("RunJob = %q, %v; want podman's output", out, err)
+	}
+	if err := l.DeleteJob(context.Background(), "sync"); err != nil {
+		t.Fatalf("DeleteJob of a gone container: %v", err)
+	}
+	rendered, err := l.RenderCommand(context.Background(), "rover", req)
+	if err != nil || !strings.HasPrefix(rendered, "podman run --rm ") {
+		t.Fatalf("RenderCommand = %q, %v; want a podman command", rendered, err)
+	}
+	calls, _ := os.ReadFile(filepath.Join(dir, "calls"))
+	if got := strings.Split(strings.TrimSpace(string(calls)), "\n"); !slices.Equal(got, []string{"podman run", "podman stop", "podman rm"}) {
+		t.Fatalf("calls = %q, want every one made to podman", got)
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SyneHQ/apollo/runner"
+)
+
+// fakeDocker puts a `docker` executable running script first on PATH.
+func fakeDocker(t *testing.T, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatalf("write fake docker: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// lineRecorder records when each streamed write arrived.
+type lineRecorder struct {
+	mu    sync.Mutex
+	lines []string
+	at    []time.Time
+}
+
+func (r *lineRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, string(p))
+	r.at = append(r.at, time.Now())
+	return len(p), nil
+}
+
+func TestLocalRunnerStreamsOutputAsItIsPrinted(t *testing.T) {
+	fakeDocker(t, "echo 'loading rows'\nsleep 1\necho 'slow query' >&2\nsleep 0.2\necho 'done'\n")
+	l := runner.NewLocalRunner("apollo:latest", nil)
+	rec := &lineRecorder{}
+
+	result, err := l.RunJobStream(context.Background(), "rover", runner.JobRequest{Name: "analytics", Command: "aggregate"}, rec)
+	finished := time.Now()
+	if err != nil {
+		t.Fatalf("RunJobStream: %v", err)
+	}
+	if result != "loading rows\nslow query\ndone\n" {
+		t.Fatalf("result = %q", result)
+	}
+	if len(rec.lines) != 3 || rec.lines[0] != "loading rows\n" {
+		t.Fatalf("streamed lines = %q", rec.lines)
+	}
+	if finished.Sub(rec.at[0]) < 500*time.Millisecond {
+		t.Fatal("first line was only streamed when the container exited")
+	}
+}
+
+func TestLocalRunnerCapturesStderrOfFailedRuns(t *testing.T) {
+	fakeDocker(t, "echo 'connecting'\necho 'read: connection reset by peer' >&2\nexit 3\n")
+	l := runner.NewLocalRunner("apollo:latest", nil)
+
+	result, err := l.RunJob(context.Background(), "rover", runner.JobRequest{Name: "sync", Command: "sync"})
+	if err == nil {
+		t.Fatal("RunJob succeeded, want the exit status")
+	}
+	if !strings.Contains(result, "connecting") || !strings.Contains(result, "connection reset") {
+		t.Fatalf("result = %q, want both streams", result)
+	}
+	if stderr, ok := runner.Stderr(err); !ok || stderr != "read: connection reset by peer\n" {
+		t.Fatalf("stderr = %q, %v", stderr, ok)
+	}
+	if code, ok := runner.ExitCode(err); !ok || code != 3 {
+		t.Fatalf("exit code = %d, %v; want 3", code, ok)
+	}
+}
@@ -0,0 +1,32 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestRunJobInjectsProviderAndRegion(t *testing.T) {
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	srv := jobsserver.NewJobsServer(b, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+
+	_, err := srv.RunJob(context.Background(), &proto.RunJobRequest{
+		Name:      "report",
+		Command:   "buildReport",
+		Overrides: &proto.JobOverrides{Env: []*proto.EnvVar{{Name: "FORMAT", Value: "csv"}}},
+	})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if len(client.submitted) != 1 {
+		t.Fatalf("submitted %d jobs, want 1", len(client.submitted))
+	}
+	env := client.submitted[0].GetTaskGroups()[0].GetTaskSpec().GetRunnables()[0].GetEnvironment().GetVariables()
+	if env["APOLLO_PROVIDER"] != "cloudrun" || env["APOLLO_REGION"] != "us-central1" || env["FORMAT"] != "csv" {
+		t.Fatalf("env = %v", env)
+	}
+}
@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestStreamLogsFollowsARunningJob(t *testing.T) {
+	fakeDocker(t, "echo 'loading rows'\nsleep 1\necho 'done'\n")
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(runner.NewLocalRunner("apollo:latest", nil), nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	proto.RegisterJobsServiceServer(srv, js)
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewJobsServiceClient(conn)
+
+	finished := make(chan time.Time, 1)
+	go func() {
+		if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "analytics", JobId: "analytics-1", Command: "aggregate"}); err != nil {
+			t.Errorf("RunJob: %v", err)
+		}
+		finished <- time.Now()
+	}()
+	// the stream only exists once the run has started
+	var stream proto.JobsService_StreamLogsClient
+	var first *proto.LogChunk
+	waitFor(t, "the run to be streamed", func() bool {
+		stream, err = client.StreamLogs(ctx, &proto.StreamLogsRequest{JobId: "analytics-1"})
+		if err != nil {
+			return false
+		}
+		first, err = stream.Recv()
+		return err == nil
+	})
+	firstAt := time.Now()
+
+	got := string(first.GetData())
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		got += string(chunk.GetData())
+	}
+	if got != "loading rows\ndone\n" {
+		t.Errorf("streamed %q, want the job's whole output", got)
+	}
+	if (<-finished).Sub(firstAt) < 500*time.Millisecond {
+		t.Error("first chunk only arrived when the job finished")
+	}
+
+	// once the job is done its recorded output is streamed instead
+	stream, err = client.StreamLogs(ctx, &proto.StreamLogsRequest{JobId: "analytics-1"})
+	if err != nil {
+		t.Fatalf("StreamLogs: %v", err)
+	}
+	if chunk, err := stream.Recv(); err != nil || string(chunk.GetData()) != "loading rows\ndone\n" {
+		t.Errorf("recorded output = %q (%v)", chunk.GetData(), err)
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		t.Errorf("stream after the recorded output: err = %v, want EOF", err)
+	}
+
+	stream, err = client.StreamLogs(ctx, &proto.StreamLogsRequest{JobId: "missing"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("StreamLogs of an unknown job: err = %v, want NotFound", err)
+	}
+}
+
+// logReadingRunner serves canned logs and records what GetLogs asked for.
+type logReadingRunner struct {
+	recordingRunner
+	name      string
+	taskIndex int32
+	maxLines  int
+}
+
+func (r *logReadingRunner) ReadLogs(ctx context.Context, name string, taskIndex int32, maxLines int) (string, error) {
+	r.name, r.taskIndex, r.maxLines = name, taskIndex, maxLines
+	return "task output", nil
+}
+
+func TestGetLogsPassesTheTaskFilterToTheRunner(t *testing.T) {
+	ctx := context.Background()
+	rn := &logReadingRunner{}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+
+	resp, err := js.GetLogs(ctx, &proto.GetLogsRequest{Name: "sync-1"})
+	if err != nil {
+		t.Fatalf("GetLogs: %v", err)
+	}
+	if resp.GetLogs() != "task output" || rn.name != "sync-1" || rn.taskIndex != runner.AllTasks {
+		t.Fatalf("logs %q read for %s task %d, want every task of sync-1", resp.GetLogs(), rn.name, rn.taskIndex)
+	}
+
+	task := int32(2)
+	if _, err := js.GetLogs(ctx, &proto.GetLogsRequest{Name: "sync-1", TaskIndex: &task, MaxLines: 50}); err != nil {
+		t.Fatalf("GetLogs: %v", err)
+	}
+	if rn.taskIndex != 2 || rn.maxLines != 50 {
+		t.Fatalf("read task %d, %d lines, want task 2, 50 lines", rn.taskIndex, rn.maxLines)
+	}
+
+	negative := int32(-1)
+	for _, req := range []*proto.GetLogsRequest{{}, {Name: "sync-1", TaskIndex: &negative}, {Name: "sync-1", MaxLines: -1}} {
+		if _, err := js.GetLogs(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetLogs(%v) err = %v, want InvalidArgument", req, err)
+		}
+	}
+
+	plain := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+	if _, err := plain.GetLogs(ctx, &proto.GetLogsRequest{Name: "sync-1"}); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("GetLogs on a runner without logs: err = %v, want Unimplemented", err)
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMaintenanceModeSkipsTicksAndRefusesRuns(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local"}
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+
+	if _, err := srv.RunJob(ctx, &proto.RunJobRequest{
+		Name: "tick", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "* * * * * *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if resp, err := srv.SetMaintenanceMode(ctx, &proto.SetMaintenanceModeRequest{On: true}); err != nil || !resp.GetOn() {
+		t.Fatalf("SetMaintenanceMode = %v, %v", resp, err)
+	}
+	if _, err := srv.RunJob(ctx, &proto.RunJobRequest{Name: "once", Command: "sync"}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("RunJob in maintenance: err = %v, want Unavailable", err)
+	}
+	waitFor(t, "a tick skipped for maintenance", func() bool {
+		recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "tick", Status: "skipped"})
+		return err == nil && len(recs) > 0
+	})
+	stats, err := srv.GetStats(ctx, &proto.GetStatsRequest{})
+	if err != nil || !stats.GetMaintenanceMode() {
+		t.Fatalf("GetStats = %v (%v), want maintenance mode on", stats, err)
+	}
+
+	// a restart on the same store comes back in maintenance mode
+	restarted := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer restarted.Shutdown(ctx)
+	if stats, err := restarted.GetStats(ctx, &proto.GetStatsRequest{}); err != nil || !stats.GetMaintenanceMode() {
+		t.Fatalf("GetStats after restart = %v (%v), want maintenance mode on", stats, err)
+	}
+	if _, err := restarted.SetMaintenanceMode(ctx, &proto.SetMaintenanceModeRequest{On: false}); err != nil {
+		t.Fatalf("SetMaintenanceMode off: %v", err)
+	}
+	if on, err := st.Maintenance(ctx); err != nil || on {
+		t.Fatalf("stored maintenance mode = %v (%v), want off", on, err)
+	}
+	if _, err := restarted.RunJob(ctx, &proto.RunJobRequest{Name: "once", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob after maintenance: %v", err)
+	}
+}
+
+func TestMaintenanceModeReachesOtherReplicas(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	clock := newFakeClock(time.Unix(1_700_000_000, 0))
+	c := &cfg.Config{JobsProvider: "cloudrun"}
+	a := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st, jobsserver.WithClock(clock))
+	b := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st, jobsserver.WithClock(clock))
+
+	if _, err := a.SetMaintenanceMode(ctx, &proto.SetMaintenanceModeRequest{On: true}); err != nil {
+		t.Fatalf("SetMaintenanceMode: %v", err)
+	}
+	clock.Advance(10 * time.Second)
+	if _, err := b.RunJob(ctx, &proto.RunJobRequest{Name: "once", Command: "sync"}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("RunJob on another replica: err = %v, want Unavailable", err)
+	}
+	if _, err := a.SetMaintenanceMode(ctx, &proto.SetMaintenanceModeRequest{On: false}); err != nil {
+		t.Fatalf("SetMaintenanceMode off: %v", err)
+	}
+	clock.Advance(10 * time.Second)
+	if _, err := b.RunJob(ctx, &proto.RunJobRequest{Name: "once", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob on another replica after maintenance: %v", err)
+	}
+}
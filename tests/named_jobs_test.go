@@ -0,0 +1,102 @@
+package tests
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordingRunner is a runner.Runner that records the requests it is given.
+type recordingRunner struct {
+	mu   sync.Mutex
+	runs []runner.JobRequest
+}
+
+func (r *recordingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs = append(r.runs, req)
+	return "ok", nil
+}
+
+func (r *recordingRunner) DeleteJob(ctx context.Context, name string) error { return nil }
+
+func (r *recordingRunner) UpdateSchedule(ctx context.Context, name string, spec string) error {
+	return nil
+}
+
+func (r *recordingRunner) GetJobStatus(ctx context.Context, name string) (runner.JobStatus, error) {
+	return runner.JobStatus{}, status.Errorf(codes.NotFound, "job %s not found", name)
+}
+
+func (r *recordingRunner) ListJobs(ctx context.Context) ([]runner.JobInfo, error) { return nil, nil }
+
+func TestRunNamedJobMergesParamsOverDefaults(t *testing.T) {
+	rn := &recordingRunner{}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Catalog: []cfg.NamedJobConfig{{
+		Name:      "nightly-report",
+		Command:   "buildReport",
+		Args:      map[string]string{"format": "csv", "days": "7"},
+		Resources: cfg.ResourceConfig{CPU: "500m", Memory: "1Gi"},
+	}}}}
+	js := jobsserver.NewJobsServer(rn, nil, c, nil)
+
+	_, err := js.RunNamedJob(context.Background(), &proto.RunNamedJobRequest{
+		Name:   "nightly-report",
+		Params: map[string]string{"days": "30"},
+	})
+	if err != nil {
+		t.Fatalf("RunNamedJob: %v", err)
+	}
+	if len(rn.runs) != 1 {
+		t.Fatalf("runner got %d runs, want 1", len(rn.runs))
+	}
+	got := rn.runs[0]
+	if got.Command != "buildReport" || got.Resources.Memory != "1Gi" {
+		t.Fatalf("run = %+v", got)
+	}
+	raw, err := base64.StdEncoding.DecodeString(got.ArgsJSONBase64)
+	if err != nil {
+		t.Fatalf("decode args: %v", err)
+	}
+	var args map[string]string
+	if err := json.Unmarshal(raw, &args); err != nil {
+		t.Fatalf("unmarshal args: %v", err)
+	}
+	if args["format"] != "csv" || args["days"] != "30" {
+		t.Fatalf("args = %v, want format=csv days=30", args)
+	}
+
+	_, err = js.RunNamedJob(context.Background(), &proto.RunNamedJobRequest{Name: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("unknown job: err = %v, want NotFound", err)
+	}
+}
+
+func TestScheduledNamedJobGetsAnIDPerTick(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Catalog: []cfg.NamedJobConfig{{
+		Name: "heartbeat", Command: "ping", Schedule: "* * * * * *",
+	}}}}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer js.Shutdown(ctx)
+
+	if _, err := js.RunNamedJob(ctx, &proto.RunNamedJobRequest{Name: "heartbeat", JobId: "fixed"}); err != nil {
+		t.Fatalf("RunNamedJob: %v", err)
+	}
+	recs := waitForExecutions(t, st, "heartbeat", 2)
+	if len(recs) < 2 || recs[0].ID == recs[1].ID || recs[0].ID == "fixed" || recs[1].ID == "fixed" {
+		t.Fatalf("executions = %+v, want ticks with IDs of their own", recs)
+	}
+}
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestReconcileOrphansSettlesRunningExecutions(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+
+	client := newFakeBatchClient()
+	states := map[string]batchpb.JobStatus_State{
+		"job-done":    batchpb.JobStatus_SUCCEEDED,
+		"job-failed":  batchpb.JobStatus_FAILED,
+		"job-running": batchpb.JobStatus_RUNNING,
+	}
+	for id, state := range states {
+		name := "projects/test-project/locations/us-central1/jobs/" + id
+		client.jobs[name] = &batchpb.Job{Name: name, Status: &batchpb.JobStatus{State: state}}
+	}
+	for _, id := range []string{"job-done", "job-failed", "job-running", "job-gone"} {
+		if err := st.AddExecution(ctx, scheduler.ExecutionRecord{ID: id, Name: id, Command: "handleBackupJob", Status: "running", StartedAt: 1}); err != nil {
+			t.Fatalf("AddExecution: %v", err)
+		}
+	}
+
+	js := jobsserver.NewJobsServer(newTestBatchRunner(client), nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+	js.ReconcileOrphans(ctx)
+
+	recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	got := map[string]string{}
+	for _, r := range recs {
+		got[r.ID] = r.Status
+	}
+	want := map[string]string{"job-done": "success", "job-failed": "error", "job-running": "running", "job-gone": "interrupted"}
+	for id, status := range want {
+		if got[id] != status {
+			t.Errorf("%s: status %q, want %q", id, got[id], status)
+		}
+	}
+}
+
+func TestReconcileOrphansAsksProfileRunners(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+
+	client := newFakeBatchClient()
+	name := "projects/test-project/locations/us-central1/jobs/job-done"
+	client.jobs[name] = &batchpb.Job{Name: name, Status: &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}}
+	if err := st.AddExecution(ctx, scheduler.ExecutionRecord{ID: "job-done", Name: "report", Command: "buildReport", Status: "running", StartedAt: 1}); err != nil {
+		t.Fatalf("AddExecution: %v", err)
+	}
+
+	profiles := map[string]runner.Runner{"batch": newTestBatchRunner(client)}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, profiles, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+	js.ReconcileOrphans(ctx)
+
+	rec, err := st.GetExecution(ctx, "job-done")
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if rec.Status != "success" {
+		t.Fatalf("status = %q, want the profile runner's success", rec.Status)
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestSchedulerSkipsTicksWhileRunning(t *testing.T) {
+	sched := scheduler.New()
+	defer sched.Stop()
+
+	var running, maxRunning, runs, skips atomic.Int32
+	release := make(chan struct{})
+	err := sched.Schedule("slow", "* * * * * *", func(ctx context.Context) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		runs.Add(1)
+		<-release
+	}, scheduler.SkipIfRunning(func() { skips.Add(1) }))
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for skips.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("only %d ticks skipped", skips.Load())
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	close(release)
+
+	if maxRunning.Load() != 1 || runs.Load() != 1 {
+		t.Fatalf("%d runs, at most %d at once; want a single run while it blocks", runs.Load(), maxRunning.Load())
+	}
+}
+
+func TestSchedulerSkipsTicksWhileRunningAcrossReregistration(t *testing.T) {
+	sched := scheduler.New()
+	defer sched.Stop()
+
+	var runs, skips atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	register := func() {
+		err := sched.Schedule("slow", "* * * * * *", func(ctx context.Context) {
+			runs.Add(1)
+			<-release
+		}, scheduler.SkipIfRunning(func() { skips.Add(1) }))
+		if err != nil {
+			t.Fatalf("Schedule: %v", err)
+		}
+	}
+	register()
+	waitFor(t, "the first run", func() bool { return runs.Load() == 1 })
+	// e.g. a config reload registers the schedule again mid-run
+	register()
+	waitFor(t, "a skipped tick", func() bool { return skips.Load() > 0 })
+	if runs.Load() != 1 {
+		t.Fatalf("%d runs, want the re-registered schedule to wait for the first", runs.Load())
+	}
+}
+
+// blockingRunner holds every run until release is closed or the run is
+// stopped.
+type blockingRunner struct {
+	recordingRunner
+	release chan struct{}
+}
+
+func (r *blockingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	select {
+	case <-r.release:
+		return "ok", nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestRunJobRecordsSkippedTicks(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &blockingRunner{release: make(chan struct{})}
+	srv := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+	defer close(rn.release)
+
+	if _, err := srv.RunJob(ctx, &proto.RunJobRequest{
+		Name: "slow", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "* * * * * *", SkipIfRunning: true,
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	recs, err := st.List(ctx)
+	if err != nil || len(recs) != 1 || !recs[0].SkipIfRunning {
+		t.Fatalf("stored schedules = %v (%v), want skip_if_running kept", recs, err)
+	}
+
+	execs := waitForExecutions(t, st, "slow", 2)
+	statuses := map[string]int{}
+	for _, e := range execs {
+		statuses[e.Status]++
+	}
+	if statuses["running"] != 1 || statuses["skipped"] == 0 {
+		t.Fatalf("execution statuses = %v, want one running and skipped ticks", statuses)
+	}
+}
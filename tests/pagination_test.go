@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// sqlStores opens a sqlite store and, for each of APOLLO_TEST_POSTGRES_DSN
+// and APOLLO_TEST_MYSQL_DSN naming a disposable database, a postgres or mysql
+// one, so queries are checked in every driver's dialect. Schedules already in
+// those databases are removed.
+func sqlStores(t *testing.T) map[string]*scheduler.SQLStore {
+	t.Helper()
+	stores := map[string]*scheduler.SQLStore{}
+	stores["sqlite"] = newTestStore(t, scheduler.Options{})
+	for driver, env := range map[string]string{"postgres": "APOLLO_TEST_POSTGRES_DSN", "mysql": "APOLLO_TEST_MYSQL_DSN"} {
+		dsn := os.Getenv(env)
+		if dsn == "" {
+			continue
+		}
+		db, err := scheduler.OpenStore(driver, dsn, scheduler.Options{})
+		if err != nil {
+			t.Fatalf("OpenStore %s: %v", driver, err)
+		}
+		recs, err := db.List(context.Background())
+		if err != nil {
+			t.Fatalf("List %s: %v", driver, err)
+		}
+		for _, r := range recs {
+			if err := db.Delete(context.Background(), r.Name); err != nil {
+				t.Fatalf("Delete %s: %v", r.Name, err)
+			}
+		}
+		stores[driver] = db
+	}
+	for _, s := range stores {
+		t.Cleanup(func() { s.Close() })
+	}
+	return stores
+}
+
+func TestListPagesSchedules(t *testing.T) {
+	for driver, st := range sqlStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			for _, name := range []string{"e", "c", "a", "d", "b"} {
+				if err := st.Upsert(ctx, scheduler.JobRecord{Name: name, Command: "sync", CronSpec: "0 3 * * *"}); err != nil {
+					t.Fatalf("Upsert: %v", err)
+				}
+			}
+			for _, tc := range []struct {
+				limit, offset int
+				want          []string
+			}{
+				{0, 0, []string{"a", "b", "c", "d", "e"}},
+				{2, 1, []string{"b", "c"}},
+				{0, 3, []string{"d", "e"}},
+				{2, 10, nil},
+			} {
+				recs, err := st.ListPage(ctx, tc.limit, tc.offset)
+				if err != nil {
+					t.Fatalf("ListPage(%d, %d): %v", tc.limit, tc.offset, err)
+				}
+				var got []string
+				for _, r := range recs {
+					got = append(got, r.Name)
+				}
+				if !slices.Equal(got, tc.want) {
+					t.Errorf("ListPage(%d, %d) = %v, want %v", tc.limit, tc.offset, got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListExecutionsFiltersAndPages(t *testing.T) {
+	for driver, st := range sqlStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			// a name of its own keeps earlier runs against postgres out
+			name := fmt.Sprintf("report-%d", time.Now().UnixNano())
+			for i := int64(1); i <= 6; i++ {
+				status := "succeeded"
+				if i%2 == 0 {
+					status = "failed"
+				}
+				if err := st.AddExecution(ctx, scheduler.ExecutionRecord{
+					ID: fmt.Sprintf("%s-%d", name, i), Name: name, Command: "report", Status: status, StartedAt: i * 100,
+				}); err != nil {
+					t.Fatalf("AddExecution: %v", err)
+				}
+			}
+			for _, tc := range []struct {
+				filter scheduler.ExecutionFilter
+				want   []int64 // started_at of the expected executions
+			}{
+				{scheduler.ExecutionFilter{Status: "failed"}, []int64{600, 400, 200}},
+				{scheduler.ExecutionFilter{Since: 200, Until: 500}, []int64{400, 300, 200}},
+				{scheduler.ExecutionFilter{Limit: 2, Offset: 1}, []int64{500, 400}},
+				{scheduler.ExecutionFilter{Offset: 4}, []int64{200, 100}},
+				{scheduler.ExecutionFilter{Status: "succeeded", Since: 200, Limit: 1, Offset: 1}, []int64{300}},
+			} {
+				f := tc.filter
+				f.Name = name
+				recs, err := st.ListExecutions(ctx, f)
+				if err != nil {
+					t.Fatalf("ListExecutions(%+v): %v", f, err)
+				}
+				var got []int64
+				for _, e := range recs {
+					got = append(got, e.StartedAt)
+				}
+				if !slices.Equal(got, tc.want) {
+					t.Errorf("ListExecutions(%+v) = %v, want %v", f, got, tc.want)
+				}
+
+				got = nil
+				if err := st.IterateExecutions(ctx, f, func(e scheduler.ExecutionRecord) error {
+					got = append(got, e.StartedAt)
+					return nil
+				}); err != nil {
+					t.Fatalf("IterateExecutions(%+v): %v", f, err)
+				}
+				if !slices.Equal(got, tc.want) {
+					t.Errorf("IterateExecutions(%+v) = %v, want %v", f, got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSchedulerPauseStopsFiringUntilResumed(t *testing.T) {
+	sched := scheduler.New()
+	defer sched.Stop()
+
+	var runs atomic.Int32
+	if err := sched.Schedule("tick", "* * * * * *", func(context.Context) { runs.Add(1) }); err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	waitFor(t, "first tick", func() bool { return runs.Load() > 0 })
+
+	if !sched.Pause("tick") || !sched.Paused("tick") || !sched.Next("tick").IsZero() {
+		t.Fatal("Pause did not pause the entry")
+	}
+	paused := runs.Load()
+	time.Sleep(1500 * time.Millisecond)
+	if got := runs.Load(); got != paused {
+		t.Fatalf("%d ticks fired while paused", got-paused)
+	}
+
+	if !sched.Resume("tick") || sched.Paused("tick") {
+		t.Fatal("Resume did not resume the entry")
+	}
+	waitFor(t, "a tick after resuming", func() bool { return runs.Load() > paused })
+
+	if sched.Pause("missing") || sched.Resume("missing") {
+		t.Fatal("Pause or Resume reported an unknown entry as scheduled")
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestPausedSchedulesStayPausedAcrossReload(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local"}
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	if _, err := srv.RunJob(ctx, &proto.RunJobRequest{
+		Name: "tick", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 0 1 1 *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := srv.PauseSchedule(ctx, &proto.PauseScheduleRequest{Name: "tick"}); err != nil {
+		t.Fatalf("PauseSchedule: %v", err)
+	}
+	if _, err := srv.PauseSchedule(ctx, &proto.PauseScheduleRequest{Name: "missing"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("PauseSchedule of an unknown schedule: err = %v, want NotFound", err)
+	}
+	resp, err := srv.ListSchedules(ctx, &proto.ListSchedulesRequest{})
+	if err != nil || len(resp.GetItems()) != 1 || !resp.GetItems()[0].GetPaused() {
+		t.Fatalf("ListSchedules = %v (%v), want tick paused", resp.GetItems(), err)
+	}
+
+	// make the stored schedule fire every second, then restart from the store
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	recs[0].CronSpec = "* * * * * *"
+	if err := st.Upsert(ctx, recs[0]); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	reloaded := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer reloaded.Shutdown(ctx)
+	reloaded.Reload(ctx)
+	time.Sleep(1500 * time.Millisecond)
+	if execs := waitForExecutions(t, st, "tick", 0); len(execs) != 0 {
+		t.Fatalf("paused schedule fired %d time(s) after reload", len(execs))
+	}
+
+	if _, err := reloaded.ResumeSchedule(ctx, &proto.ResumeScheduleRequest{Name: "tick"}); err != nil {
+		t.Fatalf("ResumeSchedule: %v", err)
+	}
+	if execs := waitForExecutions(t, st, "tick", 1); len(execs) == 0 {
+		t.Fatal("resumed schedule did not fire")
+	}
+	if recs, err := st.List(ctx); err != nil || recs[0].Paused {
+		t.Fatalf("stored schedule = %v (%v), want it resumed", recs, err)
+	}
+}
+
+func TestPauseAppliesToEveryReplica(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local"}
+	a := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer a.Shutdown(ctx)
+	if _, err := a.RunJob(ctx, &proto.RunJobRequest{
+		Name: "tick", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 0 1 1 *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	recs[0].CronSpec = "* * * * * *"
+	if err := st.Upsert(ctx, recs[0]); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	if _, err := a.PauseSchedule(ctx, &proto.PauseScheduleRequest{Name: "tick"}); err != nil {
+		t.Fatalf("PauseSchedule: %v", err)
+	}
+
+	// a replica that has the schedule running reads the pause from the store
+	b := jobsserver.NewJobsServer(&recordingRunner{}, nil, c, st)
+	defer b.Shutdown(ctx)
+	b.Reload(ctx)
+	time.Sleep(1500 * time.Millisecond)
+	if execs := waitForExecutions(t, st, "tick", 0); len(execs) != 0 {
+		t.Fatalf("paused schedule fired %d time(s) on another replica", len(execs))
+	}
+
+	if _, err := a.ResumeSchedule(ctx, &proto.ResumeScheduleRequest{Name: "tick"}); err != nil {
+		t.Fatalf("ResumeSchedule: %v", err)
+	}
+	if execs := waitForExecutions(t, st, "tick", 1); len(execs) == 0 {
+		t.Fatal("schedule resumed on one replica did not fire on the other")
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// progressRunner reports progress through the callback injected into the
+// run's environment, the way a job in a container would.
+type progressRunner struct {
+	recordingRunner
+	baseURL string
+	status  []int
+}
+
+func (r *progressRunner) post(url, token, body string) int {
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func (r *progressRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	env := map[string]string{}
+	if req.Overrides != nil {
+		for _, e := range req.Overrides.Env {
+			env[e.Name] = e.Value
+		}
+	}
+	url := strings.Replace(env["APOLLO_PROGRESS_URL"], "http://apollo.internal", r.baseURL, 1)
+	r.status = append(r.status,
+		r.post(url, "forged", `{"percent": 90}`),
+		r.post(url, env["APOLLO_PROGRESS_TOKEN"], `{"percent": 140}`),
+		r.post(url, env["APOLLO_PROGRESS_TOKEN"], `{"percent": 40, "message": "processed 4/10 shards"}`),
+	)
+	return "ok", nil
+}
+
+func TestJobsReportProgress(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &progressRunner{}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun", ProgressURL: "http://apollo.internal/"}, st)
+	defer js.Shutdown(ctx)
+	srv := httptest.NewServer(js.HTTPHandler(nil))
+	defer srv.Close()
+	rn.baseURL = srv.URL
+
+	resp, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "reindex", JobId: "reindex-1", Command: "reindex"})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	want := []int{http.StatusUnauthorized, http.StatusBadRequest, http.StatusNoContent}
+	if len(rn.status) != len(want) || rn.status[0] != want[0] || rn.status[1] != want[1] || rn.status[2] != want[2] {
+		t.Fatalf("progress responses = %v, want %v", rn.status, want)
+	}
+
+	exec, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: resp.GetId()})
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if exec.GetStatus() != "success" || exec.GetProgress() != 40 || exec.GetProgressMessage() != "processed 4/10 shards" || exec.GetProgressUpdatedAt() == 0 {
+		t.Fatalf("execution = %+v, want a successful run that reported 40%%", exec)
+	}
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestReportingReadsUseTheReadReplica(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	// stands in for a replica that has not caught up with the primary
+	replica, err := scheduler.OpenStore("sqlite", filepath.Join(dir, "replica.db"), scheduler.Options{})
+	if err != nil {
+		t.Fatalf("OpenStore replica: %v", err)
+	}
+	if err := replica.AddExecution(ctx, scheduler.ExecutionRecord{ID: "old-1", Name: "report", Status: "success", StartedAt: 1}); err != nil {
+		t.Fatalf("AddExecution: %v", err)
+	}
+	replica.Close()
+
+	st, err := scheduler.OpenStore("sqlite", filepath.Join(dir, "primary.db"), scheduler.Options{ReadPath: filepath.Join(dir, "replica.db")})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	defer st.Close()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", JobId: "new-1", Command: "report"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	listed, err := js.ListExecutions(ctx, &proto.ListExecutionsRequest{})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if items := listed.GetItems(); len(items) != 1 || items[0].GetId() != "old-1" {
+		t.Errorf("ListExecutions RPC = %v, want the replica's execution", items)
+	}
+	// reads that did not opt in, and lookups by ID, see the run just recorded
+	if recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{}); err != nil || len(recs) != 1 || recs[0].ID != "new-1" {
+		t.Errorf("ListExecutions = %v (%v), want the primary's execution", recs, err)
+	}
+	if e, err := st.GetExecution(scheduler.WithReplica(ctx), "new-1"); err != nil || e.Status != "success" {
+		t.Errorf("GetExecution from a replica context = %+v (%v), want the primary's record", e, err)
+	}
+}
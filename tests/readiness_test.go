@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func servingStatus(t *testing.T, hs *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q): %v", service, err)
+	}
+	return resp.Status
+}
+
+func TestHealthServingUntilShutdown(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	hs := health.NewServer()
+	srv.StartHealth(hs, time.Hour)
+
+	for _, service := range []string{"", "jobs.JobsService"} {
+		if got := servingStatus(t, hs, service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("status of %q = %v, want SERVING", service, got)
+		}
+	}
+
+	srv.Shutdown(context.Background())
+	waitFor(t, "NOT_SERVING after shutdown", func() bool {
+		return servingStatus(t, hs, "") == healthpb.HealthCheckResponse_NOT_SERVING
+	})
+}
+
+func TestHealthNotServingWhenStorePingFails(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(context.Background())
+	hs := health.NewServer()
+	srv.StartHealth(hs, 10*time.Millisecond)
+	if got := servingStatus(t, hs, ""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("status = %v, want SERVING", got)
+	}
+
+	st.Close()
+	waitFor(t, "NOT_SERVING once the store is closed", func() bool {
+		return servingStatus(t, hs, "jobs.JobsService") == healthpb.HealthCheckResponse_NOT_SERVING
+	})
+}
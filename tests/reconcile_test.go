@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cloudScheduleRunner keeps schedules the way a provider would, so they can be
+// edited behind the server's back.
+type cloudScheduleRunner struct {
+	recordingRunner
+	schedules map[string]runner.ScheduleInfo
+}
+
+func (c *cloudScheduleRunner) UpdateSchedule(ctx context.Context, name, spec string) error {
+	c.schedules[name] = c.DesiredSchedule(name, spec)
+	return nil
+}
+
+func (c *cloudScheduleRunner) DesiredSchedule(name, spec string) runner.ScheduleInfo {
+	return runner.ScheduleInfo{Spec: spec, TimeZone: "UTC", Target: "https://batch.example/jobs"}
+}
+
+func (c *cloudScheduleRunner) GetSchedule(ctx context.Context, name string) (runner.ScheduleInfo, error) {
+	info, ok := c.schedules[name]
+	if !ok {
+		return runner.ScheduleInfo{}, status.Error(codes.NotFound, "no such job")
+	}
+	return info, nil
+}
+
+func TestReconcileSchedulesDetectsAndFixesDrift(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	rn := &cloudScheduleRunner{schedules: map[string]runner.ScheduleInfo{}}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(context.Background())
+	ctx := context.Background()
+
+	for _, name := range []string{"backup", "cleanup"} {
+		if _, err := js.UpdateSchedule(ctx, &proto.UpdateScheduleRequest{Name: name, Schedule: "0 3 * * *"}); err != nil {
+			t.Fatalf("UpdateSchedule(%s): %v", name, err)
+		}
+	}
+	// edited and deleted out of band
+	rn.schedules["backup"] = runner.ScheduleInfo{Spec: "0 4 * * *", TimeZone: "UTC", Target: "https://batch.example/jobs"}
+	delete(rn.schedules, "cleanup")
+
+	resp, err := js.ReconcileSchedules(ctx, &proto.ReconcileSchedulesRequest{})
+	if err != nil {
+		t.Fatalf("ReconcileSchedules: %v", err)
+	}
+	got := map[string]string{}
+	for _, d := range resp.GetDrifts() {
+		got[d.GetName()] = d.GetField()
+	}
+	if len(got) != 2 || got["backup"] != "spec" || got["cleanup"] != "missing" {
+		t.Fatalf("drifts = %v, want backup spec and cleanup missing", resp.GetDrifts())
+	}
+
+	if _, err := js.ReconcileSchedules(ctx, &proto.ReconcileSchedulesRequest{Fix: true}); err != nil {
+		t.Fatalf("ReconcileSchedules(fix): %v", err)
+	}
+	resp, err = js.ReconcileSchedules(ctx, &proto.ReconcileSchedulesRequest{})
+	if err != nil {
+		t.Fatalf("ReconcileSchedules: %v", err)
+	}
+	if len(resp.GetDrifts()) != 0 {
+		t.Fatalf("drifts after fix = %v, want none", resp.GetDrifts())
+	}
+}
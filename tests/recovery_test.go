@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// panickingRunner panics on every run.
+type panickingRunner struct{ recordingRunner }
+
+func (p *panickingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	var counts map[string]int
+	counts[req.Name]++
+	return "", nil
+}
+
+func TestRecoveryInterceptorReturnsInternalAndKeepsServing(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&panickingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(jobsserver.RecoveryInterceptor()))
+	proto.RegisterJobsServiceServer(srv, js)
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewJobsServiceClient(conn)
+
+	_, err = client.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "report"})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("RunJob error = %v, want Internal", err)
+	}
+
+	// the server is still up and the run was recorded as failed
+	resp, err := client.ListExecutions(ctx, &proto.ListExecutionsRequest{Name: "report"})
+	if err != nil {
+		t.Fatalf("ListExecutions after the panic: %v", err)
+	}
+	items := resp.GetItems()
+	if len(items) != 1 || items[0].GetStatus() != "error" || !strings.Contains(items[0].GetError(), "panic") {
+		t.Errorf("executions = %v, want one failed run mentioning the panic", items)
+	}
+}
+
+func TestRecoveryStreamInterceptorReturnsInternal(t *testing.T) {
+	intercept := jobsserver.RecoveryStreamInterceptor()
+	err := intercept(nil, nil, &grpc.StreamServerInfo{FullMethod: "/jobs.JobsService/StreamLogs"}, func(any, grpc.ServerStream) error {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("error = %v, want Internal", err)
+	}
+}
+
+func TestScheduledRunPanicsAreRecordedAndTheScheduleKeepsRunning(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&panickingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", Command: "report", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "@every 1s"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	// the latest tick may still be running; the two before it have ended
+	recs := waitForExecutions(t, st, "report", 3)
+	if len(recs) < 3 {
+		t.Fatalf("%d executions, want the schedule to keep running", len(recs))
+	}
+	for _, e := range recs[1:3] {
+		if e.Status != "error" || !strings.Contains(e.Error, "panic") {
+			t.Errorf("execution = %+v, want a failed run mentioning the panic", e)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestScheduledLocalJobSurvivesReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	ctx := context.Background()
+	st, err := scheduler.OpenStore("sqlite", path, scheduler.Options{})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{
+		Name: "heartbeat", Command: "ping", ArgsBase64: "e30=",
+		Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "* * * * * *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	js.Shutdown(ctx)
+	st.Close()
+
+	st, err = scheduler.OpenStore("sqlite", path, scheduler.Options{})
+	if err != nil {
+		t.Fatalf("reopen store: %v", err)
+	}
+	defer st.Close()
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(recs) != 1 || recs[0].CronSpec != "* * * * * *" || recs[0].ArgsBase64 != "e30=" {
+		t.Fatalf("stored schedules = %+v, want heartbeat with its spec and args", recs)
+	}
+
+	before, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "heartbeat"})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	js = jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+	js.Reload(ctx)
+	after := waitForExecutions(t, st, "heartbeat", len(before)+1)
+	if len(after) <= len(before) {
+		t.Fatal("reloaded schedule never ran")
+	}
+	if after[0].ArgsBase64 != "e30=" {
+		t.Fatalf("reloaded run args = %q, want e30=", after[0].ArgsBase64)
+	}
+}
+
+func TestReloadCatchesUpMissedRuns(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	now := time.Date(2024, 3, 9, 12, 30, 0, 0, time.UTC)
+	// fired last at 07:00, so the 08:00 to 12:00 runs were missed
+	lastFired := time.Date(2024, 3, 9, 7, 0, 0, 0, time.UTC).Unix()
+	for _, rec := range []scheduler.JobRecord{
+		{Name: "coalesced", CoalesceMissed: true},
+		{Name: "capped", MaxCatchup: 2},
+		{Name: "uncapped", MaxCatchup: 10},
+		{Name: "dropped"},
+		{Name: "new", CoalesceMissed: true},
+	} {
+		rec.Command, rec.CronSpec = "sync", "0 0 * * * *"
+		if err := st.Upsert(ctx, rec); err != nil {
+			t.Fatalf("Upsert %s: %v", rec.Name, err)
+		}
+		if rec.Name != "new" {
+			if err := st.MarkFired(ctx, rec.Name, lastFired); err != nil {
+				t.Fatalf("MarkFired %s: %v", rec.Name, err)
+			}
+		}
+	}
+
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st, jobsserver.WithClock(newFakeClock(now)))
+	defer js.Shutdown(ctx)
+	js.Reload(ctx)
+
+	want := map[string]int{"coalesced": 1, "capped": 2, "uncapped": 5, "dropped": 0, "new": 0}
+	for name, n := range want {
+		waitForExecutions(t, st, name, n)
+	}
+	// give runs beyond the expected ones a chance to show up
+	time.Sleep(200 * time.Millisecond)
+	for name, n := range want {
+		recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: name})
+		if err != nil {
+			t.Fatalf("ListExecutions: %v", err)
+		}
+		if len(recs) != n {
+			t.Errorf("%s caught up %d runs, want %d", name, len(recs), n)
+		}
+	}
+}
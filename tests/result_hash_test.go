@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// outputRunner is a recordingRunner whose runs print output.
+type outputRunner struct {
+	recordingRunner
+	output string
+}
+
+func (r *outputRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	r.runs = append(r.runs, req)
+	return r.output, nil
+}
+
+func TestExecutionsRecordWhetherTheResultChanged(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &outputRunner{}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{Name: "export", HashResult: true}}}}
+	js := jobsserver.NewJobsServer(rn, nil, c, st)
+	defer js.Shutdown(ctx)
+
+	for i, run := range []struct {
+		id, command, output string
+		unchanged           bool
+	}{
+		{"export-1", "export", "rows: 10\n", false},
+		{"export-2", "export", "rows: 10\n", true},
+		{"export-3", "export", "rows: 11\n", false},
+		{"other-1", "other", "rows: 11\n", false},
+	} {
+		rn.output = run.output
+		if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: run.command, JobId: run.id, Command: run.command}); err != nil {
+			t.Fatalf("RunJob %s: %v", run.id, err)
+		}
+		e, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: run.id})
+		if err != nil {
+			t.Fatalf("GetExecution %s: %v", run.id, err)
+		}
+		if e.GetResultUnchanged() != run.unchanged {
+			t.Errorf("run %d: result_unchanged = %v, want %v", i, e.GetResultUnchanged(), run.unchanged)
+		}
+		if hashed := e.GetResultHash() != ""; hashed != (run.command == "export") {
+			t.Errorf("run %d: result_hash = %q, want one only for jobs configured with hash_result", i, e.GetResultHash())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExecutionsCarryMetricsFromTheOutput(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &outputRunner{}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{
+		{Name: "export", Metrics: &cfg.MetricsConfig{JSON: true, Patterns: map[string]string{"tables": `exported (\d+) tables`}}},
+	}}}
+	js := jobsserver.NewJobsServer(rn, nil, c, st)
+	defer js.Shutdown(ctx)
+
+	for _, run := range []struct{ id, command, output string }{
+		{"export-1", "export", "exported 2 tables\n{\"rows\": 100}\n{\"rows\": 1000, \"bytes\": 5000000, \"table\": \"users\"}\ndone\n"},
+		{"export-2", "export", "exported 3 tables\n{\"rows\": 50}\n"},
+		{"export-3", "export", "nothing to do\n"},
+		{"other-1", "other", "{\"rows\": 5000}\n"},
+	} {
+		rn.output = run.output
+		if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: run.command, JobId: run.id, Command: run.command}); err != nil {
+			t.Fatalf("RunJob %s: %v", run.id, err)
+		}
+	}
+
+	e, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: "export-1"})
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if m := e.GetMetrics(); len(m) != 3 || m["rows"] != 1000 || m["bytes"] != 5000000 || m["tables"] != 2 {
+		t.Errorf("metrics = %v, want rows and bytes of the last JSON line and the captured tables", m)
+	}
+	for _, id := range []string{"export-3", "other-1"} {
+		if e, _ := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: id}); len(e.GetMetrics()) != 0 {
+			t.Errorf("%s: metrics = %v, want none", id, e.GetMetrics())
+		}
+	}
+
+	resp, err := js.ListExecutions(ctx, &proto.ListExecutionsRequest{Metrics: []string{"rows>=100", "tables != 2"}})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(resp.GetItems()) != 0 {
+		t.Errorf("filtered executions = %v, want none", resp.GetItems())
+	}
+	resp, err = js.ListExecutions(ctx, &proto.ListExecutionsRequest{Metrics: []string{"rows<=1000"}})
+	if err != nil || len(resp.GetItems()) != 2 {
+		t.Errorf("executions with rows<=1000 = %v, %v; want export-1 and export-2", resp.GetItems(), err)
+	}
+	if _, err := js.ListExecutions(ctx, &proto.ListExecutionsRequest{Metrics: []string{"rows~1"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListExecutions with a bad metric filter: err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestListExecutionsFiltersOnMetrics(t *testing.T) {
+	for driver, st := range sqlStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			// a name of its own keeps earlier runs against shared databases out
+			name := fmt.Sprintf("metrics-%d", time.Now().UnixNano())
+			now := time.Now().Unix()
+			for i, rows := range []float64{10, 20, 30} {
+				rec := scheduler.ExecutionRecord{ID: fmt.Sprintf("%s-%d", name, i), Name: name, Status: "success",
+					StartedAt: now + int64(i), FinishedAt: now + int64(i) + 1, Metrics: map[string]float64{"rows": rows, "bytes": rows * 100}}
+				if err := st.AddExecution(ctx, rec); err != nil {
+					t.Fatalf("AddExecution: %v", err)
+				}
+			}
+			for _, tc := range []struct {
+				filters []string
+				want    int
+			}{
+				{[]string{"rows=20"}, 1},
+				{[]string{"rows!=20"}, 2},
+				{[]string{"rows>10"}, 2},
+				{[]string{"rows>10", "bytes<3000"}, 1},
+				{[]string{"missing>0"}, 0},
+			} {
+				var filters []scheduler.MetricFilter
+				for _, s := range tc.filters {
+					f, err := scheduler.ParseMetricFilter(s)
+					if err != nil {
+						t.Fatalf("ParseMetricFilter(%q): %v", s, err)
+					}
+					filters = append(filters, f)
+				}
+				recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: name, Metrics: filters})
+				if err != nil {
+					t.Fatalf("ListExecutions %v: %v", tc.filters, err)
+				}
+				if len(recs) != tc.want {
+					t.Errorf("%v matched %d executions, want %d", tc.filters, len(recs), tc.want)
+				}
+				for _, r := range recs {
+					if r.Metrics["bytes"] != r.Metrics["rows"]*100 {
+						t.Errorf("metrics of %s = %v", r.ID, r.Metrics)
+					}
+				}
+			}
+		})
+	}
+}
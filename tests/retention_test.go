@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestPurgeExecutionsRemovesOldFinishedRuns(t *testing.T) {
+	for driver, st := range sqlStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			name := fmt.Sprintf("report-%d", time.Now().UnixNano())
+			now := time.Now().Unix()
+			day := int64(24 * 60 * 60)
+			for id, e := range map[string]scheduler.ExecutionRecord{
+				"old":     {Status: "success", StartedAt: now - 10*day, FinishedAt: now - 10*day + 60},
+				"running": {Status: "running", StartedAt: now - 10*day},
+				"recent":  {Status: "error", StartedAt: now - day, FinishedAt: now - day + 60},
+			} {
+				e.ID, e.Name, e.Command = name+"-"+id, name, "report"
+				e.Labels = map[string]string{"env": driver}
+				if err := st.AddExecution(ctx, e); err != nil {
+					t.Fatalf("AddExecution: %v", err)
+				}
+			}
+
+			n, err := st.PurgeExecutions(ctx, time.Unix(now-7*day, 0))
+			if err != nil {
+				t.Fatalf("PurgeExecutions: %v", err)
+			}
+			if n != 1 {
+				t.Errorf("PurgeExecutions removed %d execution(s), want 1", n)
+			}
+			recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: name, Labels: map[string]string{"env": driver}})
+			if err != nil {
+				t.Fatalf("ListExecutions: %v", err)
+			}
+			var got []string
+			for _, e := range recs {
+				got = append(got, e.ID)
+			}
+			slices.Sort(got)
+			if want := []string{name + "-recent", name + "-running"}; !slices.Equal(got, want) {
+				t.Errorf("executions left = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestStartRetentionPurgesInTheBackground(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour).Unix()
+	if err := st.AddExecution(ctx, scheduler.ExecutionRecord{
+		ID: "old", Name: "report", Command: "report", Status: "success", StartedAt: old, FinishedAt: old,
+	}); err != nil {
+		t.Fatalf("AddExecution: %v", err)
+	}
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	srv.StartRetention(24 * time.Hour)
+	waitFor(t, "the old execution to be purged", func() bool {
+		recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "report"})
+		return err == nil && len(recs) == 0
+	})
+}
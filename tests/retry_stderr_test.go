@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// stderrRunner fails with the queued stderr outputs in order, then succeeds.
+type stderrRunner struct {
+	recordingRunner
+	stderr []string
+}
+
+func (r *stderrRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	r.runs = append(r.runs, req)
+	if len(r.runs) > len(r.stderr) {
+		return "ok", nil
+	}
+	stderr := r.stderr[len(r.runs)-1]
+	return stderr, &runner.RunError{Err: errors.New("exit status 1"), Stderr: stderr}
+}
+
+func TestRetryIfStderrMatches(t *testing.T) {
+	cases := []struct {
+		name         string
+		stderr       []string
+		wantRuns     int
+		wantDecision string
+	}{
+		{"transient failure is retried", []string{"read: connection reset by peer"}, 2, "stderr matches"},
+		{"other failure is not retried", []string{"TypeError: undefined is not a function"}, 1, "stderr does not match"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := newTestStore(t, scheduler.Options{})
+			ctx := context.Background()
+			if err := st.Upsert(ctx, scheduler.JobRecord{Name: "sync", Command: "handleSync", CronSpec: "0 * * * *"}); err != nil {
+				t.Fatalf("Upsert: %v", err)
+			}
+			rn := &stderrRunner{stderr: tc.stderr}
+			js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{
+				Name: "handleSync",
+				Retry: cfg.RetryConfig{
+					MaxRetries:           3,
+					InitialDelay:         time.Millisecond,
+					RetryIfStderrMatches: "connection reset",
+				},
+			}}}}, st)
+			defer js.Shutdown(ctx)
+			srv := httptest.NewServer(js.HTTPHandler(func(context.Context, string) error { return nil }))
+			defer srv.Close()
+
+			req, _ := http.NewRequest(http.MethodPost, srv.URL+"/schedules/sync/trigger", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("trigger: %v", err)
+			}
+			resp.Body.Close()
+
+			if len(rn.runs) != tc.wantRuns {
+				t.Fatalf("runs = %d, want %d", len(rn.runs), tc.wantRuns)
+			}
+			recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "sync", Status: "error"})
+			if err != nil {
+				t.Fatalf("ListExecutions: %v", err)
+			}
+			if len(recs) != 1 || !strings.Contains(recs[0].RetryDecision, tc.wantDecision) {
+				t.Fatalf("failed executions = %+v, want one with retry decision containing %q", recs, tc.wantDecision)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestRunJobAppliesPerRunnerResourceDefaults(t *testing.T) {
+	primary, batch := &recordingRunner{}, &recordingRunner{}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{
+		Jobs: []cfg.JobConfig{{Name: "tuned", Resources: cfg.ResourceConfig{CPU: "500m", Memory: "1Gi"}}},
+		Runners: []cfg.RunnerConfig{
+			{Name: "batch", Provider: "cloudrun", Resources: cfg.ResourceConfig{CPU: "2", Memory: "8Gi"}},
+			{Name: "spare", Provider: "cloudrun"},
+		},
+		ProviderResources: map[string]cfg.ResourceConfig{
+			"local":    {CPU: "100m", Memory: "128Mi"},
+			"cloudrun": {CPU: "1", Memory: "4Gi"},
+		},
+	}}
+	js := jobsserver.NewJobsServer(primary, map[string]runner.Runner{"batch": batch, "spare": batch}, c, nil)
+
+	ctx := context.Background()
+	for _, tc := range []struct {
+		req  *proto.RunJobRequest
+		rn   *recordingRunner
+		want runner.Resources
+	}{
+		{&proto.RunJobRequest{Command: "analytics"}, primary, runner.Resources{CPU: "100m", Memory: "128Mi"}},
+		{&proto.RunJobRequest{Command: "analytics", Runner: "batch"}, batch, runner.Resources{CPU: "2", Memory: "8Gi"}},
+		{&proto.RunJobRequest{Command: "analytics", Runner: "spare"}, batch, runner.Resources{CPU: "1", Memory: "4Gi"}},
+		{&proto.RunJobRequest{Command: "tuned", Runner: "batch"}, batch, runner.Resources{CPU: "500m", Memory: "1Gi"}},
+		{&proto.RunJobRequest{Command: "analytics", Runner: "batch", Resources: &proto.Resources{Cpu: "4", Memory: "16Gi"}}, batch, runner.Resources{CPU: "4", Memory: "16Gi"}},
+	} {
+		tc.req.Name = "analytics"
+		if _, err := js.RunJob(ctx, tc.req); err != nil {
+			t.Fatalf("RunJob: %v", err)
+		}
+		if got := tc.rn.runs[len(tc.rn.runs)-1].Resources; got != tc.want {
+			t.Errorf("RunJob(%s on %q) resources = %+v, want %+v", tc.req.GetCommand(), tc.req.GetRunner(), got, tc.want)
+		}
+	}
+
+	eff, err := js.GetEffectiveJobConfig(ctx, &proto.GetEffectiveJobConfigRequest{Name: "analytics"})
+	if err != nil {
+		t.Fatalf("GetEffectiveJobConfig: %v", err)
+	}
+	if eff.GetResources().GetCpu() != "100m" || eff.GetResources().GetMemory() != "128Mi" {
+		t.Errorf("effective resources = %v, want the local provider's", eff.GetResources())
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunJobEnforcesMaxSchedules(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local", MaxSchedules: 2}, st)
+	defer srv.Shutdown(ctx)
+
+	schedule := func(name, spec string) error {
+		_, err := srv.RunJob(ctx, &proto.RunJobRequest{
+			Name: name, Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: spec,
+		})
+		return err
+	}
+	for _, name := range []string{"a", "b"} {
+		if err := schedule(name, "0 0 * * * *"); err != nil {
+			t.Fatalf("schedule %s: %v", name, err)
+		}
+	}
+	if err := schedule("c", "0 0 * * * *"); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("third schedule: err = %v, want ResourceExhausted", err)
+	}
+	if err := schedule("a", "0 30 * * * *"); err != nil {
+		t.Fatalf("updating a stored schedule at the limit: %v", err)
+	}
+
+	stats, err := srv.GetStats(ctx, &proto.GetStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	if stats.GetSchedules() != 2 || stats.GetMaxSchedules() != 2 {
+		t.Fatalf("stats = %d of %d schedules, want 2 of 2", stats.GetSchedules(), stats.GetMaxSchedules())
+	}
+}
+
+func TestConcurrentScheduleCreationsStayWithinMaxSchedules(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local", MaxSchedules: 3}, st)
+	defer srv.Shutdown(ctx)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = srv.RunJob(ctx, &proto.RunJobRequest{
+				Name: fmt.Sprintf("job-%d", i), Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 * * * *",
+			})
+		}()
+	}
+	wg.Wait()
+
+	count, _, err := st.CountSchedules(ctx, "")
+	if err != nil {
+		t.Fatalf("CountSchedules: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("stored %d schedules, want 3", count)
+	}
+}
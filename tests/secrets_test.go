@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/runner"
+	_secrets "github.com/SyneHQ/apollo/secrets"
+	"github.com/infisical/go-sdk/packages/models"
+)
+
+func TestFilterSecretsStripsEnvironmentPrefixes(t *testing.T) {
+	loaded := []models.Secret{
+		{SecretKey: "DATABASE_URL", SecretValue: "shared-db"},
+		{SecretKey: "PROD_DATABASE_URL", SecretValue: "prod-db"},
+		{SecretKey: "STAGING_DATABASE_URL", SecretValue: "staging-db"},
+		{SecretKey: "SHARED_API_KEY", SecretValue: "shared-key"},
+		{SecretKey: "PROD_API_KEY", SecretValue: "prod-key"},
+		{SecretKey: "SHARED_REDIS_URL", SecretValue: "shared-redis"},
+	}
+	wanted := []cfg.SecretConfig{
+		{Name: "DATABASE_URL", Value: "$DATABASE_URL"},
+		{Name: "API_KEY", Value: "$API_KEY"},
+		{Name: "REDIS_URL", Value: "$REDIS_URL"},
+	}
+
+	got := map[string]string{}
+	for _, s := range _secrets.FilterSecrets(loaded, wanted, "PROD_", "SHARED_") {
+		got[s.SecretKey] = s.SecretValue
+	}
+	want := map[string]string{"DATABASE_URL": "prod-db", "API_KEY": "prod-key", "REDIS_URL": "shared-redis"}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestFilterSecretsIsSortedAndDeduplicated(t *testing.T) {
+	t.Setenv("LOCAL_ONLY", "from-env")
+	loaded := []models.Secret{
+		{SecretKey: "ZETA", SecretValue: "z"},
+		{SecretKey: "API_KEY", SecretValue: "provider-key"},
+		{SecretKey: "MIDDLE", SecretValue: "m"},
+	}
+	wanted := []cfg.SecretConfig{
+		{Name: "ZETA", Value: "$ZETA"},
+		{Name: "LOCAL_ONLY", Value: "$LOCAL_ONLY"},
+		{Name: "API_KEY", Value: "literal-key"},
+		{Name: "MIDDLE", Value: "$MIDDLE"},
+		{Name: "API_KEY", Value: "$API_KEY"},
+		{Name: "MIDDLE", Value: "$MIDDLE"},
+		{Name: "LOCAL_ONLY", Value: "literal-local"},
+	}
+	want := []models.Secret{
+		{SecretKey: "API_KEY", SecretValue: "provider-key"},
+		{SecretKey: "LOCAL_ONLY", SecretValue: "literal-local"},
+		{SecretKey: "MIDDLE", SecretValue: "m"},
+		{SecretKey: "ZETA", SecretValue: "z"},
+	}
+	for i := 0; i < 20; i++ {
+		got := _secrets.FilterSecrets(loaded, wanted)
+		if !slices.EqualFunc(got, want, func(a, b models.Secret) bool {
+			return a.SecretKey == b.SecretKey && a.SecretValue == b.SecretValue
+		}) {
+			t.Fatalf("FilterSecrets = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFilterSecretsDropsInvalidEnvNames(t *testing.T) {
+	var logs strings.Builder
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	loaded := []models.Secret{
+		{SecretKey: "db-password", SecretValue: "hunter2"},
+		{SecretKey: "API_KEY", SecretValue: "key"},
+	}
+	wanted := []cfg.SecretConfig{{Name: "db-password", Value: "$db-password"}, {Name: "API_KEY", Value: "$API_KEY"}}
+	got := _secrets.FilterSecrets(loaded, wanted)
+	if len(got) != 1 || got[0].SecretKey != "API_KEY" {
+		t.Fatalf("FilterSecrets = %v, want only API_KEY", got)
+	}
+	if !strings.Contains(logs.String(), `dropping secret "db-password"`) || strings.Contains(logs.String(), "hunter2") {
+		t.Errorf("logs = %q, want a warning naming the dropped secret without its value", logs.String())
+	}
+}
+
+func TestLocalRunnerGivesJobsOnlyTheirTaggedSecrets(t *testing.T) {
+	l := runner.NewLocalRunner("apollo:latest", []models.Secret{
+		{SecretKey: "DATABASE_URL", SecretValue: "db", SecretMetadata: []models.SecretMetadata{{Key: "tier", Value: "db"}}},
+		{SecretKey: "WAREHOUSE_URL", SecretValue: "dwh", SecretMetadata: []models.SecretMetadata{{Key: "service", Value: "analytics"}}},
+		{SecretKey: "STRIPE_KEY", SecretValue: "sk", SecretMetadata: []models.SecretMetadata{{Key: "service", Value: "billing"}}},
+		{SecretKey: "SENTRY_DSN", SecretValue: "dsn"},
+		{SecretKey: "SLACK_TOKEN", SecretValue: "xoxb"},
+	})
+	secretEnv := func(sel *runner.SecretSelection) []string {
+		args, err := l.BuildArgs(context.Background(), "rover", runner.JobRequest{Name: "report", Command: "report", Secrets: sel})
+		if err != nil {
+			t.Fatalf("BuildArgs: %v", err)
+		}
+		var names []string
+		for i, arg := range args {
+			if name, _, ok := strings.Cut(arg, "="); ok && i > 0 && args[i-1] == "-e" && !strings.HasPrefix(name, "APOLLO_") {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	got := secretEnv(&runner.SecretSelection{Tags: []string{"tier:db", "service:analytics"}, Names: []string{"SENTRY_DSN"}})
+	if want := []string{"DATABASE_URL", "WAREHOUSE_URL", "SENTRY_DSN"}; !slices.Equal(got, want) {
+		t.Errorf("secrets by tag = %v, want %v", got, want)
+	}
+	if got := secretEnv(&runner.SecretSelection{Tags: []string{"service"}}); !slices.Equal(got, []string{"WAREHOUSE_URL", "STRIPE_KEY"}) {
+		t.Errorf("secrets by tag key = %v, want every service secret", got)
+	}
+	if got := secretEnv(nil); len(got) != 5 {
+		t.Errorf("secrets without a selection = %v, want all of them", got)
+	}
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunJobAppliesConfiguredSecurity(t *testing.T) {
+	rn := &recordingRunner{}
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{
+		Name:     "sandbox",
+		Security: &cfg.SecurityConfig{CapDrop: []string{"ALL"}, CapAdd: []string{"NET_BIND_SERVICE", "CHOWN"}, NoNewPrivileges: true},
+	}}}}
+	js := jobsserver.NewJobsServer(rn, nil, c, nil)
+
+	ctx := context.Background()
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "configured", Command: "sandbox"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "tightened", Command: "sandbox", Security: &proto.Security{CapDrop: []string{"cap_chown"}}}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "default", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	if sec := rn.runs[0].Security; sec == nil || !slices.Equal(sec.CapDrop, []string{"ALL"}) || !sec.NoNewPrivileges {
+		t.Errorf("configured run security = %+v, want the job's", sec)
+	}
+	if sec := rn.runs[1].Security; sec == nil || !slices.Equal(sec.CapDrop, []string{"ALL", "cap_chown"}) || !slices.Equal(sec.CapAdd, []string{"NET_BIND_SERVICE"}) || !sec.NoNewPrivileges {
+		t.Errorf("tightened run security = %+v, want the job's without CHOWN", sec)
+	}
+	if sec := rn.runs[2].Security; sec != nil {
+		t.Errorf("unconfigured run security = %+v, want the runner's", sec)
+	}
+}
+
+func TestRunJobSecurityCannotLoosenTheConfig(t *testing.T) {
+	rn := &recordingRunner{}
+	c := &cfg.Config{
+		JobsProvider:      "local",
+		ContainerSecurity: &cfg.SecurityConfig{CapDrop: []string{"NET_RAW"}, NoNewPrivileges: true},
+	}
+	js := jobsserver.NewJobsServer(rn, nil, c, nil)
+	ctx := context.Background()
+
+	// an empty block used to replace the defaults with docker's
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "empty", Command: "sync", Security: &proto.Security{}}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if sec := rn.runs[0].Security; sec == nil || !slices.Equal(sec.CapDrop, []string{"NET_RAW"}) || !sec.NoNewPrivileges {
+		t.Errorf("run security = %+v, want the runner's defaults kept", sec)
+	}
+
+	_, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "widened", Command: "sync", Security: &proto.Security{CapAdd: []string{"NET_RAW"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("cap_add past the config: err = %v, want InvalidArgument", err)
+	}
+	if len(rn.runs) != 1 {
+		t.Fatalf("%d runs, want the widened one refused", len(rn.runs))
+	}
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestShutdownWaitsForInFlightRuns(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		timeout time.Duration
+		want    string
+	}{
+		{"finishes", time.Minute, "success"},
+		{"times out", 50 * time.Millisecond, "interrupted"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jobs.db")
+			st, err := scheduler.OpenStore("sqlite", path, scheduler.Options{})
+			if err != nil {
+				t.Fatalf("OpenStore: %v", err)
+			}
+			rn := &blockingRunner{release: make(chan struct{})}
+			js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "local"}, st)
+			go js.RunJob(context.Background(), &proto.RunJobRequest{Name: "report", Command: "report"})
+			waitForExecutions(t, st, "report", 1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+			shutdown := make(chan error, 1)
+			go func() { shutdown <- js.Shutdown(ctx) }()
+			if tc.want == "success" {
+				select {
+				case <-shutdown:
+					t.Fatal("Shutdown returned while a run was in flight")
+				case <-time.After(50 * time.Millisecond):
+				}
+				close(rn.release)
+			}
+			if err := <-shutdown; err != nil {
+				t.Fatalf("Shutdown: %v", err)
+			}
+			if tc.want != "success" {
+				close(rn.release)
+			}
+
+			// Shutdown closed the store, so reopen it to read what was recorded
+			if st, err = scheduler.OpenStore("sqlite", path, scheduler.Options{}); err != nil {
+				t.Fatalf("OpenStore: %v", err)
+			}
+			defer st.Close()
+			recs, err := st.ListExecutions(context.Background(), scheduler.ExecutionFilter{Name: "report"})
+			if err != nil {
+				t.Fatalf("ListExecutions: %v", err)
+			}
+			if len(recs) != 1 || recs[0].Status != tc.want {
+				t.Errorf("executions = %+v, want one %s run", recs, tc.want)
+			}
+		})
+	}
+}
+
+// waitingRunner waits on a cloud job until the run is stopped, as
+// BatchRunner does.
+type waitingRunner struct{ recordingRunner }
+
+func (r *waitingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	<-ctx.Done()
+	return "projects/p/locations/r/jobs/" + req.JobID, fmt.Errorf("wait for %s: %w: %w", req.JobID, runner.ErrStoppedWaiting, ctx.Err())
+}
+
+func TestShutdownLeavesCloudRunsItStopsWaitingOnSubmitted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	st, err := scheduler.OpenStore("sqlite", path, scheduler.Options{})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	js := jobsserver.NewJobsServer(&waitingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	go js.RunJob(context.Background(), &proto.RunJobRequest{Name: "export", JobId: "export-1", Command: "export"})
+	waitForExecutions(t, st, "export", 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := js.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if st, err = scheduler.OpenStore("sqlite", path, scheduler.Options{}); err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	defer st.Close()
+	rec, err := st.GetExecution(context.Background(), "export-1")
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if rec.Status != "submitted" {
+		t.Fatalf("status = %s, want submitted so the job is settled later", rec.Status)
+	}
+}
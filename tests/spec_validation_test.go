@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpsertRejectsInvalidSpecs(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t, scheduler.Options{})
+	err := st.Upsert(ctx, scheduler.JobRecord{Name: "broken", Command: "sync", CronSpec: "0 61 * * *"})
+	if err == nil || !strings.Contains(err.Error(), "61") {
+		t.Fatalf("Upsert err = %v, want the parse error", err)
+	}
+	for _, spec := range []string{"0 3 * * *", "0 0 3 * * *", "@every 5m"} {
+		if err := st.Upsert(ctx, scheduler.JobRecord{Name: "ok", Command: "sync", CronSpec: spec}); err != nil {
+			t.Fatalf("Upsert %q: %v", spec, err)
+		}
+	}
+
+	local, err := scheduler.OpenStore("sqlite", filepath.Join(t.TempDir(), "local.db"), scheduler.Options{SpecParser: scheduler.SecondsParser})
+	if err != nil {
+		t.Fatalf("OpenStore: %v", err)
+	}
+	if err := local.Upsert(ctx, scheduler.JobRecord{Name: "unix", Command: "sync", CronSpec: "0 3 * * *"}); err == nil {
+		t.Fatal("store for the in-process scheduler accepted a spec without seconds")
+	}
+}
+
+func TestValidateStoredReportsSpecsTheSchedulerRejects(t *testing.T) {
+	ctx := context.Background()
+	st := newTestStore(t, scheduler.Options{})
+	for name, spec := range map[string]string{"unix": "0 3 * * *", "seconds": "0 0 3 * * *"} {
+		if err := st.Upsert(ctx, scheduler.JobRecord{Name: name, Command: "sync", CronSpec: spec}); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+	}
+	invalid, err := scheduler.ValidateStored(ctx, st, scheduler.SecondsParser)
+	if err != nil {
+		t.Fatalf("ValidateStored: %v", err)
+	}
+	if len(invalid) != 1 || invalid[0].Name != "unix" || invalid[0].Err == nil {
+		t.Fatalf("invalid = %+v, want only unix", invalid)
+	}
+}
+
+func TestRunJobRejectsInvalidSchedule(t *testing.T) {
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+	_, err := js.RunJob(context.Background(), &proto.RunJobRequest{
+		Name: "sync", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "every day",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("RunJob err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestCloudSchedulesRejectSubMinuteSpecs(t *testing.T) {
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+	for _, spec := range []string{"*/10 * * * * *", "30 0 3 * * *"} {
+		_, err := js.RunJob(context.Background(), &proto.RunJobRequest{
+			Name: "sync", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: spec,
+		})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "once a minute") {
+			t.Fatalf("RunJob %q err = %v, want InvalidArgument about minute granularity", spec, err)
+		}
+	}
+	if _, err := js.RunJob(context.Background(), &proto.RunJobRequest{
+		Name: "sync", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 3 * * *",
+	}); err != nil {
+		t.Fatalf("RunJob with a zero seconds field: %v", err)
+	}
+	for _, spec := range []string{"@every 30s", "@every 5m", "CRON_TZ=Europe/Paris @every 1h"} {
+		_, err := js.RunJob(context.Background(), &proto.RunJobRequest{
+			Name: "sync", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: spec,
+		})
+		if status.Code(err) != codes.InvalidArgument || !strings.Contains(err.Error(), "interval") {
+			t.Fatalf("RunJob %q err = %v, want InvalidArgument about intervals", spec, err)
+		}
+	}
+}
+
+func TestMinuteCronConvertsWholeMinuteSpecs(t *testing.T) {
+	for spec, want := range map[string]string{
+		"0 0 3 * * *": "0 3 * * *",
+		"0 3 * * *":   "0 3 * * *",
+	} {
+		got, err := runner.MinuteCron(spec)
+		if err != nil || got != want {
+			t.Fatalf("MinuteCron(%q) = %q, %v, want %q", spec, got, err, want)
+		}
+	}
+	for _, spec := range []string{"15 * * * * *", "@every 5m"} {
+		if _, err := runner.MinuteCron(spec); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("MinuteCron(%q) err = %v, want InvalidArgument", spec, err)
+		}
+	}
+}
+
+func TestRunJobRejectsInvalidTimeout(t *testing.T) {
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "cloudrun"}, nil)
+	negative := int32(-1)
+	for _, req := range []*proto.RunJobRequest{
+		{Name: "sync", Command: "sync", Timeout: "soon"},
+		{Name: "sync", Command: "sync", Timeout: "-5m"},
+		{Name: "sync", Command: "sync", MaxRetries: &negative},
+	} {
+		if _, err := js.RunJob(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("RunJob(%v) err = %v, want InvalidArgument", req, err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+// TestStoreWritesInEveryDialect exercises the queries whose syntax differs
+// between drivers: upserts, leases, labels and settings.
+func TestStoreWritesInEveryDialect(t *testing.T) {
+	for driver, st := range sqlStores(t) {
+		t.Run(driver, func(t *testing.T) {
+			ctx := context.Background()
+			rec := scheduler.JobRecord{Name: "Sync", Command: "sync", CronSpec: "0 3 * * *", Labels: map[string]string{"team": "data"}}
+			for _, spec := range []string{"0 3 * * *", "0 4 * * *"} {
+				rec.CronSpec = spec
+				if err := st.Upsert(ctx, rec); err != nil {
+					t.Fatalf("Upsert: %v", err)
+				}
+			}
+			// names are case-sensitive in every driver
+			if err := st.Upsert(ctx, scheduler.JobRecord{Name: "sync", Command: "sync", CronSpec: "0 5 * * *"}); err != nil {
+				t.Fatalf("Upsert: %v", err)
+			}
+			recs, err := st.List(ctx)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(recs) != 2 || recs[0].Name != "Sync" || recs[0].CronSpec != "0 4 * * *" || recs[0].Labels["team"] != "data" {
+				t.Fatalf("schedules = %+v, want Sync updated in place and sync beside it", recs)
+			}
+
+			id := fmt.Sprintf("sync-%d", time.Now().UnixNano())
+			e := scheduler.ExecutionRecord{ID: id, Name: "Sync", Command: "sync", Status: "running", StartedAt: 100, Labels: map[string]string{"env": "prod"}}
+			if err := st.AddExecution(ctx, e); err != nil {
+				t.Fatalf("AddExecution: %v", err)
+			}
+			e.Status, e.FinishedAt, e.Result = "success", 160, "done"
+			e.Labels = map[string]string{"env": "staging"}
+			if err := st.AddExecution(ctx, e); err != nil {
+				t.Fatalf("AddExecution update: %v", err)
+			}
+			got, err := st.GetExecution(ctx, id)
+			if err != nil {
+				t.Fatalf("GetExecution: %v", err)
+			}
+			if got.Status != "success" || got.FinishedAt != 160 || got.Result != "done" || got.Labels["env"] != "staging" {
+				t.Fatalf("execution = %+v, want the finished run labelled staging", got)
+			}
+
+			key := fmt.Sprintf("tick-%d", time.Now().UnixNano())
+			if ok, err := st.AcquireLease(ctx, key, "a", time.Minute); err != nil || !ok {
+				t.Fatalf("AcquireLease a = %v, %v, want acquired", ok, err)
+			}
+			if ok, err := st.AcquireLease(ctx, key, "b", time.Minute); err != nil || ok {
+				t.Fatalf("AcquireLease b = %v, %v, want refused", ok, err)
+			}
+
+			for _, on := range []bool{true, false} {
+				if err := st.SetMaintenance(ctx, on); err != nil {
+					t.Fatalf("SetMaintenance(%v): %v", on, err)
+				}
+				if got, err := st.Maintenance(ctx); err != nil || got != on {
+					t.Fatalf("Maintenance = %v, %v, want %v", got, err, on)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/scheduler"
+)
+
+func TestLoadStorePoolSettings(t *testing.T) {
+	t.Setenv("STORE_MAX_OPEN_CONNS", "20")
+	t.Setenv("STORE_CONN_MAX_LIFETIME", "30m")
+	c, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Store.MaxOpenConns != 20 || c.Store.MaxIdleConns != 0 || c.Store.ConnMaxLifetime != 30*time.Minute {
+		t.Fatalf("store config = %+v", c.Store)
+	}
+
+	t.Setenv("STORE_MAX_IDLE_CONNS", "-1")
+	if _, err := cfg.Load(); err == nil {
+		t.Fatal("Load accepted a negative STORE_MAX_IDLE_CONNS")
+	}
+}
+
+func TestOpenStoreRejectsNegativePoolSettings(t *testing.T) {
+	_, err := scheduler.OpenStore("sqlite", filepath.Join(t.TempDir(), "jobs.db"), scheduler.Options{MaxOpenConns: -1})
+	if err == nil {
+		t.Fatal("OpenStore accepted a negative MaxOpenConns")
+	}
+}
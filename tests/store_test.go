@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// countingStore is a scheduler.Store that counts the writes passed through
+// to the store it wraps.
+type countingStore struct {
+	scheduler.Store
+	mu         sync.Mutex
+	upserts    int
+	executions int
+}
+
+func (c *countingStore) Upsert(ctx context.Context, r scheduler.JobRecord) error {
+	c.mu.Lock()
+	c.upserts++
+	c.mu.Unlock()
+	return c.Store.Upsert(ctx, r)
+}
+
+func (c *countingStore) AddExecution(ctx context.Context, e scheduler.ExecutionRecord) error {
+	c.mu.Lock()
+	c.executions++
+	c.mu.Unlock()
+	return c.Store.AddExecution(ctx, e)
+}
+
+func TestJobsServerPersistsThroughTheInjectedStore(t *testing.T) {
+	st := &countingStore{Store: newTestStore(t, scheduler.Options{})}
+	ctx := context.Background()
+	js := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer js.Shutdown(ctx)
+
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "sync", JobId: "sync-1", Command: "sync"}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{
+		Name: "nightly", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 3 * * *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	st.mu.Lock()
+	upserts, executions := st.upserts, st.executions
+	st.mu.Unlock()
+	if upserts != 1 || executions == 0 {
+		t.Fatalf("store saw %d upserts and %d execution writes, want the schedule and the run", upserts, executions)
+	}
+	if _, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: "sync-1"}); err != nil {
+		t.Fatalf("GetExecution through the injected store: %v", err)
+	}
+}
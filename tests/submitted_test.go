@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	batchpb "cloud.google.com/go/batch/apiv1/batchpb"
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCloudRunsStaySubmittedUntilTheProviderFinishesThem(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.WaitPollInterval = 10 * time.Millisecond
+	js := jobsserver.NewJobsServer(b, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+
+	for _, id := range []string{"report-1", "report-2", "report-3"} {
+		if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", JobId: id, Command: "report"}); err != nil {
+			t.Fatalf("RunJob %s: %v", id, err)
+		}
+	}
+	e, err := st.GetExecution(ctx, "report-1")
+	if err != nil {
+		t.Fatalf("GetExecution: %v", err)
+	}
+	if e.Status != "submitted" || e.Result != "projects/test-project/locations/us-central1/jobs/report-1" {
+		t.Fatalf("execution after RunJob = %+v, want it submitted with the Batch job name", e)
+	}
+
+	client.mu.Lock()
+	client.jobs["projects/test-project/locations/us-central1/jobs/report-1"].Status = &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}
+	client.jobs["projects/test-project/locations/us-central1/jobs/report-2"].Status = &batchpb.JobStatus{State: batchpb.JobStatus_FAILED}
+	delete(client.jobs, "projects/test-project/locations/us-central1/jobs/report-3")
+	client.mu.Unlock()
+	js.SettleSubmitted(ctx)
+
+	go client.finish(t, "projects/test-project/locations/us-central1/jobs/report-4", batchpb.JobStatus_SUCCEEDED, nil)
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{Name: "report", JobId: "report-4", Command: "report", Wait: true}); err != nil {
+		t.Fatalf("RunJob with wait: %v", err)
+	}
+
+	for id, want := range map[string]string{"report-1": "success", "report-2": "error", "report-3": "error", "report-4": "success"} {
+		e, err := st.GetExecution(ctx, id)
+		if err != nil {
+			t.Fatalf("GetExecution %s: %v", id, err)
+		}
+		if e.Status != want {
+			t.Errorf("%s after settling = %+v, want %s", id, e, want)
+		}
+	}
+}
+
+func TestRunJobLeavesAWaitedRunSubmittedWhenTheCallerGoesAway(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	client := newFakeBatchClient()
+	b := newTestBatchRunner(client)
+	b.WaitPollInterval = 10 * time.Millisecond
+	js := jobsserver.NewJobsServer(b, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+
+	gone, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := js.RunJob(gone, &proto.RunJobRequest{Name: "report", JobId: "report-1", Command: "report", Wait: true}); status.Code(err) != codes.Canceled {
+		t.Fatalf("RunJob with wait of a caller that went away: %v, want Canceled", err)
+	}
+	if e, err := st.GetExecution(ctx, "report-1"); err != nil || e.Status != "submitted" {
+		t.Fatalf("execution = %+v (%v), want it left submitted", e, err)
+	}
+
+	client.mu.Lock()
+	client.jobs["projects/test-project/locations/us-central1/jobs/report-1"].Status = &batchpb.JobStatus{State: batchpb.JobStatus_SUCCEEDED}
+	client.mu.Unlock()
+	js.SettleSubmitted(ctx)
+	if e, err := st.GetExecution(ctx, "report-1"); err != nil || e.Status != "success" {
+		t.Errorf("execution after settling = %+v (%v), want the job's real outcome", e, err)
+	}
+}
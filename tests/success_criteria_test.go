@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// exitingRunner prints output and exits with the code a job's name asks for,
+// e.g. "exit-3".
+type exitingRunner struct {
+	recordingRunner
+	output string
+}
+
+func (r *exitingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	var code int
+	fmt.Sscanf(req.Name, "exit-%d", &code)
+	return r.output, exec.CommandContext(ctx, "sh", "-c", fmt.Sprintf("exit %d", code)).Run()
+}
+
+func TestRunJobAppliesSuccessCriteria(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	rn := &exitingRunner{output: "rows synced: 12\nall done"}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{
+		JobsProvider: "local",
+		Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{
+			{Name: "sync", SuccessExitCodes: []int{0, 3}},
+			{Name: "report", SuccessOutputRegex: `all done$`},
+			{Name: "export", SuccessOutputRegex: `^exported`},
+		}},
+	}, st)
+	defer js.Shutdown(ctx)
+
+	for _, tc := range []struct {
+		name, command string
+		ok            bool
+	}{
+		{"exit-0", "sync", true},
+		{"exit-3", "sync", true},
+		{"exit-4", "sync", false},
+		{"exit-0", "report", true},
+		{"exit-3", "report", false},
+		{"exit-0", "export", false},
+		{"exit-3", "unconfigured", false},
+	} {
+		id := tc.command + "-" + tc.name
+		_, err := js.RunJob(ctx, &proto.RunJobRequest{Name: tc.name, JobId: id, Command: tc.command})
+		if (err == nil) != tc.ok {
+			t.Errorf("%s exiting %s: err = %v, want success %v", tc.command, tc.name, err, tc.ok)
+		}
+		rec, err := js.GetExecution(ctx, &proto.GetExecutionRequest{Id: id})
+		if err != nil {
+			t.Fatalf("GetExecution %s: %v", id, err)
+		}
+		if want := map[bool]string{true: "success", false: "error"}[tc.ok]; rec.GetStatus() != want {
+			t.Errorf("%s recorded as %s, want %s", id, rec.GetStatus(), want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+// hangingRunner runs every job until its context is done.
+type hangingRunner struct {
+	recordingRunner
+}
+
+func (r *hangingRunner) RunJob(ctx context.Context, prefix string, req runner.JobRequest) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestScheduledRunPastItsTimeoutIsRecorded(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	c := &cfg.Config{JobsProvider: "local", Jobs: cfg.JobsConfig{Jobs: []cfg.JobConfig{{Name: "sync", Timeout: 100 * time.Millisecond}}}}
+	js := jobsserver.NewJobsServer(&hangingRunner{}, nil, c, st)
+	defer js.Shutdown(ctx)
+
+	if _, err := js.RunJob(ctx, &proto.RunJobRequest{
+		Name: "nightly-sync", Command: "sync", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "* * * * * *",
+	}); err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	waitFor(t, "a timed out execution", func() bool {
+		recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "nightly-sync"})
+		if err != nil {
+			t.Fatalf("ListExecutions: %v", err)
+		}
+		for _, rec := range recs {
+			if rec.Status == "timeout" && rec.FinishedAt != 0 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestScheduleKeepsItsTimeoutAndRetriesInTheStore(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	none := int32(0)
+	for _, req := range []*proto.RunJobRequest{
+		{Name: "backfill", Timeout: "90m", MaxRetries: &none},
+		{Name: "sync"},
+	} {
+		req.Command, req.Type, req.Schedule = "sync", proto.JobType_JOB_TYPE_REPEATABLE, "0 0 * * * *"
+		if _, err := srv.RunJob(ctx, req); err != nil {
+			t.Fatalf("RunJob %s: %v", req.Name, err)
+		}
+	}
+	recs, err := st.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("stored %d schedules, want 2", len(recs))
+	}
+	backfill, sync := recs[0], recs[1]
+	if backfill.Timeout != 90*time.Minute || backfill.MaxRetries == nil || *backfill.MaxRetries != 0 {
+		t.Fatalf("backfill timeout %s, retries %v, want 1h30m and 0", backfill.Timeout, backfill.MaxRetries)
+	}
+	if sync.Timeout != 0 || sync.MaxRetries != nil {
+		t.Fatalf("sync timeout %s, retries %v, want neither set", sync.Timeout, sync.MaxRetries)
+	}
+}
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	"github.com/SyneHQ/apollo/runner"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSchedulerRunsSpecsInTheirTimeZone(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skipf("no time zone database: %v", err)
+	}
+	sched := scheduler.New()
+	defer sched.Stop()
+
+	noop := func(context.Context) {}
+	if err := sched.Schedule("option", "0 0 9 * * *", noop, scheduler.WithTimeZone("Asia/Kolkata")); err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if err := sched.Schedule("prefix", "CRON_TZ=Asia/Kolkata 0 0 9 * * *", noop); err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	for _, name := range []string{"option", "prefix"} {
+		if next := sched.Next(name).In(kolkata); next.Hour() != 9 || next.Minute() != 0 {
+			t.Errorf("%s fires next at %s, want 09:00 in Asia/Kolkata", name, next)
+		}
+	}
+	if err := sched.Schedule("bad", "0 0 9 * * *", noop, scheduler.WithTimeZone("Mars/Olympus_Mons")); err == nil {
+		t.Fatal("Schedule accepted an unknown time zone")
+	}
+}
+
+func TestRunJobStoresScheduleTimeZone(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	srv := jobsserver.NewJobsServer(&recordingRunner{}, nil, &cfg.Config{JobsProvider: "local"}, st)
+	defer srv.Shutdown(ctx)
+
+	for name, req := range map[string]*proto.RunJobRequest{
+		"field":  {Schedule: "0 0 9 * * *", TimeZone: "Asia/Kolkata"},
+		"prefix": {Schedule: "CRON_TZ=Asia/Kolkata 0 0 9 * * *"},
+	} {
+		req.Name, req.Command, req.Type = name, "standup", proto.JobType_JOB_TYPE_REPEATABLE
+		if _, err := srv.RunJob(ctx, req); err != nil {
+			t.Fatalf("RunJob %s: %v", name, err)
+		}
+	}
+	resp, err := srv.ListSchedules(ctx, &proto.ListSchedulesRequest{})
+	if err != nil {
+		t.Fatalf("ListSchedules: %v", err)
+	}
+	if len(resp.GetItems()) != 2 {
+		t.Fatalf("schedules = %v, want 2", resp.GetItems())
+	}
+	for _, item := range resp.GetItems() {
+		if item.GetTimeZone() != "Asia/Kolkata" || item.GetCron() != "0 0 9 * * *" {
+			t.Errorf("schedule %s = %q in %q, want 0 0 9 * * * in Asia/Kolkata", item.GetName(), item.GetCron(), item.GetTimeZone())
+		}
+	}
+
+	for _, req := range []*proto.RunJobRequest{
+		{Name: "bad", Command: "standup", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "0 0 9 * * *", TimeZone: "Mars/Olympus_Mons"},
+		{Name: "clash", Command: "standup", Type: proto.JobType_JOB_TYPE_REPEATABLE, Schedule: "CRON_TZ=UTC 0 0 9 * * *", TimeZone: "Asia/Kolkata"},
+	} {
+		if _, err := srv.RunJob(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("RunJob %s err = %v, want InvalidArgument", req.GetName(), err)
+		}
+	}
+}
+
+func TestBatchRunnerSchedulesInTimeZone(t *testing.T) {
+	b := newTestBatchRunner(newFakeBatchClient())
+	for spec, want := range map[string]runner.ScheduleInfo{
+		"CRON_TZ=Asia/Kolkata 0 0 9 * * *": {Spec: "0 9 * * *", TimeZone: "Asia/Kolkata"},
+		"0 9 * * *":                        {Spec: "0 9 * * *", TimeZone: "UTC"},
+		"TZ=America/New_York 0 9 * * *":    {Spec: "0 9 * * *", TimeZone: "America/New_York"},
+	} {
+		got := b.DesiredSchedule("standup", spec)
+		if got.Spec != want.Spec || got.TimeZone != want.TimeZone {
+			t.Errorf("DesiredSchedule(%q) = %q in %q, want %q in %q", spec, got.Spec, got.TimeZone, want.Spec, want.TimeZone)
+		}
+	}
+	if got, err := runner.MinuteCron("CRON_TZ=Asia/Kolkata 0 0 9 * * *"); err != nil || got != "CRON_TZ=Asia/Kolkata 0 9 * * *" {
+		t.Fatalf("MinuteCron = %q, %v, want the time zone kept", got, err)
+	}
+	for _, spec := range []string{"CRON_TZ=Mars/Olympus_Mons 0 9 * * *", "TZ=Local 0 9 * * *"} {
+		if _, err := runner.MinuteCron(spec); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("MinuteCron(%q) err = %v, want InvalidArgument", spec, err)
+		}
+	}
+}
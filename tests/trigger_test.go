@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/scheduler"
+	jobsserver "github.com/SyneHQ/apollo/server"
+)
+
+func TestScheduleTriggerRunsStoredSchedule(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	if err := st.Upsert(ctx, scheduler.JobRecord{Name: "nightly-backup", Command: "handleBackupJob", CronSpec: "0 3 * * *"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	rn := &recordingRunner{}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+
+	verify := func(ctx context.Context, token string) error {
+		if token != "scheduler-token" {
+			return errors.New("bad token")
+		}
+		return nil
+	}
+	srv := httptest.NewServer(js.HTTPHandler(verify))
+	defer srv.Close()
+
+	trigger := func(name, token string) int {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/schedules/"+name+"/trigger", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("trigger %s: %v", name, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := trigger("nightly-backup", "forged"); code != http.StatusUnauthorized {
+		t.Fatalf("forged token: status %d, want 401", code)
+	}
+	if code := trigger("unknown", "scheduler-token"); code != http.StatusNotFound {
+		t.Fatalf("unknown schedule: status %d, want 404", code)
+	}
+	if code := trigger("nightly-backup", "scheduler-token"); code != http.StatusNoContent {
+		t.Fatalf("trigger: status %d, want 204", code)
+	}
+	if len(rn.runs) != 1 || rn.runs[0].Command != "handleBackupJob" {
+		t.Fatalf("runs = %+v, want one handleBackupJob run", rn.runs)
+	}
+
+	recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "nightly-backup"})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(recs) != 1 || recs[0].Status != "success" {
+		t.Fatalf("executions = %+v, want one successful run", recs)
+	}
+}
+
+func TestScheduleTriggerLeavesFailedRunsToTheRetryPolicy(t *testing.T) {
+	st := newTestStore(t, scheduler.Options{})
+	ctx := context.Background()
+	if err := st.Upsert(ctx, scheduler.JobRecord{Name: "nightly-backup", Command: "handleBackupJob", CronSpec: "0 3 * * *"}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	rn := &failingNamesRunner{fail: map[string]bool{"nightly-backup": true}}
+	js := jobsserver.NewJobsServer(rn, nil, &cfg.Config{JobsProvider: "cloudrun"}, st)
+	defer js.Shutdown(ctx)
+	srv := httptest.NewServer(js.HTTPHandler(func(ctx context.Context, token string) error { return nil }))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/schedules/nightly-backup/trigger", nil)
+	req.Header.Set("Authorization", "Bearer scheduler-token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("trigger: %v", err)
+	}
+	resp.Body.Close()
+	// a 5xx would have Cloud Scheduler run the tick again on top of Apollo's retries
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("trigger of a failing run: status %d, want 204", resp.StatusCode)
+	}
+	recs, err := st.ListExecutions(ctx, scheduler.ExecutionFilter{Name: "nightly-backup"})
+	if err != nil {
+		t.Fatalf("ListExecutions: %v", err)
+	}
+	if len(recs) != 1 || recs[0].Status != "error" {
+		t.Fatalf("executions = %+v, want one failed run", recs)
+	}
+}
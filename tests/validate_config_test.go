@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+)
+
+func TestValidateRequiresAnImage(t *testing.T) {
+	t.Setenv("JOBS_IMAGE", "")
+	c, err := cfg.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "JOBS_IMAGE") {
+		t.Fatalf("Validate without a jobs config: err = %v, want it to name JOBS_IMAGE", err)
+	}
+
+	t.Setenv("JOBS_IMAGE", "ghcr.io/synehq/rover:latest")
+	t.Setenv("JOBS_CMD", "/app/rover")
+	if c, err = cfg.Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.Jobs.Image != "ghcr.io/synehq/rover:latest" || c.Jobs.Cmd != "/app/rover" {
+		t.Fatalf("image, cmd = %q, %q; want them from the environment", c.Jobs.Image, c.Jobs.Cmd)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateChecksEveryRunner(t *testing.T) {
+	c := &cfg.Config{
+		JobsProvider: "cloudrun",
+		Jobs: cfg.JobsConfig{
+			Image: "ghcr.io/synehq/rover:latest",
+			Runners: []cfg.RunnerConfig{
+				{Name: "eu", Provider: "cloudrun", GCPProjectID: "apollo-eu"},
+				{Name: "gpu", Provider: "kubernetes"},
+			},
+		},
+	}
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("Validate accepted a cloud runner without a project and an unknown provider")
+	}
+	for _, want := range []string{"primary runner (JOBS_PROVIDER): the cloudrun provider needs a GCP project", `runner "gpu": unknown provider "kubernetes"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err = %v, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), `"eu"`) {
+		t.Errorf("err = %v, want the eu runner accepted", err)
+	}
+
+	c.GCPProjectID = "apollo"
+	c.Jobs.Runners = []cfg.RunnerConfig{c.Jobs.Runners[0], {Name: "ci", Provider: "local", PullPolicy: "sometimes"}}
+	if err := c.Validate(); err == nil || !strings.Contains(err.Error(), `runner "ci": unknown pull policy "sometimes"`) {
+		t.Fatalf("Validate of an unknown pull policy: err = %v", err)
+	}
+
+	c.Jobs.Runners[1].PullPolicy = "always"
+	c.Jobs.Runners[1].Runtime = "lxc"
+	if err := c.Validate(); err == nil || !strings.Contains(err.Error(), `runner "ci": unknown container runtime "lxc"`) {
+		t.Fatalf("Validate of an unknown runtime: err = %v", err)
+	}
+
+	c.Jobs.Runners[1].Runtime = "podman"
+	if err := c.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	valid := func() *cfg.Config {
+		return &cfg.Config{
+			JobsProvider: "local",
+			Jobs:         cfg.JobsConfig{Image: "ghcr.io/synehq/rover:latest"},
+			Store:        cfg.StoreConfig{Driver: "sqlite", Path: "jobs.db"},
+		}
+	}
+	for _, tc := range []struct {
+		name   string
+		change func(c *cfg.Config)
+		want   []string // empty when the config is valid
+		hide   []string // must not appear in the errors, e.g. passwords
+	}{
+		{name: "valid", change: func(c *cfg.Config) {}},
+		{name: "no store", change: func(c *cfg.Config) { c.Store = cfg.StoreConfig{} }},
+		{
+			name:   "no image",
+			change: func(c *cfg.Config) { c.Jobs.Image = "" },
+			want:   []string{"no job image"},
+		},
+		{
+			name:   "cloudrun without a project",
+			change: func(c *cfg.Config) { c.JobsProvider = "cloudrun" },
+			want:   []string{"needs a GCP project"},
+		},
+		{
+			name:   "cloudrun",
+			change: func(c *cfg.Config) { c.JobsProvider, c.GCPProjectID, c.GCPRegion = "cloudrun", "apollo", "us-central1" },
+		},
+		{
+			name:   "trigger URL without the scheduler's account",
+			change: func(c *cfg.Config) { c.SchedulerTriggerURL = "https://apollo.example.com" },
+			want:   []string{"without SCHEDULER_SERVICE_ACCOUNT"},
+		},
+		{
+			name: "trigger URL",
+			change: func(c *cfg.Config) {
+				c.SchedulerTriggerURL, c.SchedulerServiceAccount = "https://apollo.example.com", "scheduler@apollo.iam.gserviceaccount.com"
+			},
+		},
+		{
+			name:   "unknown driver",
+			change: func(c *cfg.Config) { c.Store.Driver = "mongodb" },
+			want:   []string{`unknown driver "mongodb"`},
+		},
+		{
+			name:   "postgres with a sqlite file",
+			change: func(c *cfg.Config) { c.Store.Driver = "postgres" },
+			want:   []string{`STORE_PATH: "jobs.db" does not look like a postgres DSN`},
+		},
+		{
+			name: "postgres URL",
+			change: func(c *cfg.Config) {
+				c.Store = cfg.StoreConfig{Driver: "postgres", Path: "postgres://apollo@db:5432/apollo"}
+			},
+		},
+		{
+			name:   "postgres key/value DSN",
+			change: func(c *cfg.Config) { c.Store = cfg.StoreConfig{Driver: "postgres", Path: "host=db dbname=apollo"} },
+		},
+		{
+			name: "mysql with a sqlite replica",
+			change: func(c *cfg.Config) {
+				c.Store = cfg.StoreConfig{Driver: "mysql", Path: "root@tcp(db:3306)/apollo", ReadPath: "replica.sqlite"}
+			},
+			want: []string{`STORE_READ_PATH: "replica.sqlite" does not look like a mysql DSN`},
+		},
+		{
+			name:   "sqlite with a DSN",
+			change: func(c *cfg.Config) { c.Store.Path = "postgres://apollo@db/apollo" },
+			want:   []string{"looks like a server DSN"},
+		},
+		{
+			name:   "sqlite with a DSN and a password",
+			change: func(c *cfg.Config) { c.Store.Path = "postgres://apollo:hunter2@db/apollo" },
+			want:   []string{`"postgres://apollo:xxxxx@db/apollo" looks like a server DSN`},
+			hide:   []string{"hunter2"},
+		},
+		{
+			name:   "sqlite with a mysql DSN and a password",
+			change: func(c *cfg.Config) { c.Store.Path = "root:hunter2@tcp(db:3306)/apollo" },
+			want:   []string{`"root:xxxxx@tcp(db:3306)/apollo" looks like a server DSN`},
+			hide:   []string{"hunter2"},
+		},
+		{
+			name:   "mysql key/value DSN with a password",
+			change: func(c *cfg.Config) { c.Store = cfg.StoreConfig{Driver: "mysql", Path: "host=db password=hunter2"} },
+			want:   []string{"password=xxxxx"},
+			hide:   []string{"hunter2"},
+		},
+		{
+			name:   "empty store path",
+			change: func(c *cfg.Config) { c.Store.Path = "" },
+			want:   []string{"STORE_PATH is empty"},
+		},
+		{
+			name: "every problem at once",
+			change: func(c *cfg.Config) {
+				c.Jobs.Image, c.JobsProvider, c.Store.Driver = "", "nomad", "postgres"
+			},
+			want: []string{"no job image", `unknown provider "nomad"`, "does not look like a postgres DSN"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := valid()
+			tc.change(c)
+			err := c.Validate()
+			if len(tc.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate accepted the config, want errors %q", tc.want)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("err = %v, want it to contain %q", err, want)
+				}
+			}
+			for _, hide := range tc.hide {
+				if strings.Contains(err.Error(), hide) {
+					t.Errorf("err = %v, want %q left out", err, hide)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/SyneHQ/apollo/keys"
+)
+
+// fakeVault serves KV v2 secrets under the "secret" mount for the token
+// "root", and logs in the AppRole role-1/secret-1 with that token. revoked
+// counts the tokens revoked since.
+func fakeVault(t *testing.T, data map[string]map[string]any) (srv *httptest.Server, revoked *atomic.Int32) {
+	t.Helper()
+	revoked = &atomic.Int32{}
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/approle/login" {
+			var login map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&login)
+			if login["role_id"] != "role-1" || login["secret_id"] != "secret-1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"root"}}`))
+			return
+		}
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		if r.URL.Path == "/v1/auth/token/revoke-self" {
+			revoked.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		secret, ok := data[strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"data": secret, "metadata": map[string]any{"version": 3}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, revoked
+}
+
+func TestVaultSecretsLaterPathsWin(t *testing.T) {
+	srv, revoked := fakeVault(t, map[string]map[string]any{
+		"apollo/shared": {"DATABASE_URL": "shared-db", "API_KEY": "shared-key"},
+		"apollo/prod":   {"DATABASE_URL": "prod-db", "MAX_CONNS": 10},
+	})
+	v := &keys.VaultSecrets{Address: srv.URL, Token: "root", Paths: []string{"apollo/shared", "apollo/prod"}}
+
+	secrets, err := v.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got := map[string]string{}
+	for _, s := range secrets {
+		got[s.SecretKey] = s.SecretValue
+	}
+	want := map[string]string{"DATABASE_URL": "prod-db", "API_KEY": "shared-key", "MAX_CONNS": "10"}
+	if len(got) != len(want) {
+		t.Fatalf("secrets = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q, want %q", k, got[k], v)
+		}
+	}
+	if n := revoked.Load(); n != 0 {
+		t.Errorf("revoked %d token(s), want the configured token kept", n)
+	}
+}
+
+func TestVaultSecretsAppRoleLogin(t *testing.T) {
+	srv, revoked := fakeVault(t, map[string]map[string]any{"apollo": {"TOKEN": "t"}})
+	v := &keys.VaultSecrets{Address: srv.URL, RoleID: "role-1", SecretID: "secret-1", Paths: []string{"apollo"}}
+	secrets, err := v.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(secrets) != 1 || secrets[0].SecretValue != "t" {
+		t.Fatalf("secrets = %+v", secrets)
+	}
+	if n := revoked.Load(); n != 1 {
+		t.Errorf("revoked %d token(s), want the login's token revoked", n)
+	}
+
+	v.SecretID = "wrong"
+	if _, err := v.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid role") {
+		t.Fatalf("Load with a bad secret ID: err = %v, want the login error", err)
+	}
+}
+
+func TestVaultSecretsMissingPathFails(t *testing.T) {
+	srv, _ := fakeVault(t, map[string]map[string]any{"apollo": {"TOKEN": "t"}})
+	v := &keys.VaultSecrets{Address: srv.URL, Token: "root", Paths: []string{"apollo", "missing"}}
+	if _, err := v.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("err = %v, want the missing path named", err)
+	}
+}
+
+func TestSecretsProviderFromEnv(t *testing.T) {
+	t.Setenv("USE_INFISICAL", "true")
+	t.Setenv("SECRETS_PROVIDER", "")
+	p, strict, err := keys.NewSecretsProvider()
+	if _, ok := p.(*keys.InfisicalProvider); err != nil || !ok || !strict {
+		t.Fatalf("default = %T strict=%v err=%v, want a strict Infisical provider", p, strict, err)
+	}
+
+	t.Setenv("SECRETS_PROVIDER", "vault")
+	t.Setenv("VAULT_ADDR", "http://vault:8200")
+	t.Setenv("VAULT_SECRET_PATHS", " apollo/shared , /apollo/prod/ ,")
+	p, strict, err = keys.NewSecretsProvider()
+	v, ok := p.(*keys.VaultSecrets)
+	if err != nil || !ok || !strict {
+		t.Fatalf("vault = %T strict=%v err=%v, want a strict Vault provider", p, strict, err)
+	}
+	if strings.Join(v.Paths, ",") != "apollo/shared,apollo/prod" {
+		t.Errorf("paths = %q", v.Paths)
+	}
+
+	t.Setenv("SECRETS_PROVIDER", "aws")
+	if _, _, err := keys.NewSecretsProvider(); err == nil {
+		t.Fatal("unknown provider: want an error")
+	}
+}
+
+func TestVaultSecretsAttachToTheProcessEnv(t *testing.T) {
+	srv, _ := fakeVault(t, map[string]map[string]any{"apollo": {"APOLLO_TEST_VAULT_NEW": "new", "APOLLO_TEST_VAULT_SET": "vault"}})
+	t.Setenv("APOLLO_TEST_VAULT_NEW", "")
+	t.Setenv("APOLLO_TEST_VAULT_SET", "env")
+	v := &keys.VaultSecrets{Address: srv.URL, Token: "root", Paths: []string{"apollo"}, AttachToProcessEnv: true}
+	if _, err := v.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := os.Getenv("APOLLO_TEST_VAULT_NEW"); got != "new" {
+		t.Errorf("APOLLO_TEST_VAULT_NEW = %q, want it set from Vault", got)
+	}
+	if got := os.Getenv("APOLLO_TEST_VAULT_SET"); got != "env" {
+		t.Errorf("APOLLO_TEST_VAULT_SET = %q, want the environment's value kept", got)
+	}
+}
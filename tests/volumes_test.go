@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	cfg "github.com/SyneHQ/apollo"
+	"github.com/SyneHQ/apollo/proto"
+	jobsserver "github.com/SyneHQ/apollo/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRunJobOnlyMountsVolumesUnderAllowedRoots(t *testing.T) {
+	run := func(roots []string, hostPath string) (*recordingRunner, error) {
+		rn := &recordingRunner{}
+		c := &cfg.Config{JobsProvider: "local", VolumeHostRoots: roots}
+		js := jobsserver.NewJobsServer(rn, nil, c, nil)
+		_, err := js.RunJob(context.Background(), &proto.RunJobRequest{
+			Name: "import", Command: "import",
+			Overrides: &proto.JobOverrides{Volumes: []*proto.VolumeMount{{HostPath: hostPath, ContainerPath: "/data"}}},
+		})
+		return rn, err
+	}
+
+	cases := []struct {
+		roots    []string
+		hostPath string
+		ok       bool
+	}{
+		{nil, "/srv/data", false},
+		{[]string{"/srv/data"}, "/srv/data", true},
+		{[]string{"/srv/data/"}, "/srv/data/in", true},
+		{[]string{"/srv/data"}, "/srv/database", false},
+		{[]string{"/srv/data"}, "/srv/data/../../var/run/docker.sock", false},
+		{[]string{"/srv/data"}, "/", false},
+		{[]string{"/srv/data"}, "data", false},
+	}
+	for _, tc := range cases {
+		rn, err := run(tc.roots, tc.hostPath)
+		if tc.ok {
+			if err != nil || len(rn.runs) != 1 {
+				t.Errorf("roots %v, %s: err = %v, want it mounted", tc.roots, tc.hostPath, err)
+			}
+			continue
+		}
+		if status.Code(err) != codes.InvalidArgument || len(rn.runs) != 0 {
+			t.Errorf("roots %v, %s: err = %v, want InvalidArgument and no run", tc.roots, tc.hostPath, err)
+		}
+	}
+}
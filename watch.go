@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// jobsConfigSource is the file or directory the jobs config is read from, or
+// "" when there is none.
+func jobsConfigSource() string {
+	if path := getEnv("JOBS_CONFIG_PATH", ""); path != "" {
+		return path
+	}
+	if dir := getEnv("JOBS_CONFIG_DIR", ""); dir != "" {
+		return dir
+	}
+	for _, path := range []string{"/app/jobs.yml", "jobs.yml"} {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// WatchJobsConfig re-reads the jobs config with LoadJobsConfig whenever the
+// file or directory it is read from changes, and hands the result, or the
+// error reading it, to onChange once changes have been quiet for debounce so
+// a file is not read half written. A file is watched through its directory,
+// so editors and Kubernetes ConfigMaps that replace it are seen too. It
+// returns when ctx is done, and at once when there is no jobs config.
+func WatchJobsConfig(ctx context.Context, debounce time.Duration, onChange func(*JobsConfig, error)) error {
+	source := jobsConfigSource()
+	if source == "" {
+		return nil
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	dir, file := source, ""
+	if !info.IsDir() {
+		dir, file = filepath.Dir(source), filepath.Base(source)
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if err := w.Add(dir); err != nil {
+		return err
+	}
+
+	var settled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			name := filepath.Base(ev.Name)
+			// ConfigMaps swap the file in through ..data and other .. entries
+			if ev.Op == fsnotify.Chmod || (file != "" && name != file && !strings.HasPrefix(name, "..")) {
+				continue
+			}
+			settled = time.After(debounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			log.Printf("jobs config watch: %v", err)
+		case <-settled:
+			settled = nil
+			onChange(LoadJobsConfig())
+		}
+	}
+}